@@ -3,20 +3,53 @@ package config
 import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
-	"github.com/dmalch/go-geni"
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniweb"
 )
 
 type GeniProviderConfig struct {
-	AccessToken              types.String `tfsdk:"access_token"`
-	ClientID                 types.String `tfsdk:"client_id"`
-	ClientSecret             types.String `tfsdk:"client_secret"`
-	UseSandboxEnv            types.Bool   `tfsdk:"use_sandbox_env"`
-	AutoUpdateMergedProfiles types.Bool   `tfsdk:"auto_update_merged_profiles"`
+	AccessToken              types.String  `tfsdk:"access_token"`
+	ClientID                 types.String  `tfsdk:"client_id"`
+	ClientSecret             types.String  `tfsdk:"client_secret"`
+	UseSandboxEnv            types.Bool    `tfsdk:"use_sandbox_env"`
+	APIBaseURL               types.String  `tfsdk:"api_base_url"`
+	OAuthBaseURL             types.String  `tfsdk:"oauth_base_url"`
+	AutoUpdateMergedProfiles types.Bool    `tfsdk:"auto_update_merged_profiles"`
+	BatchSize                types.Int64   `tfsdk:"batch_size"`
+	BatchFlushInterval       types.String  `tfsdk:"batch_flush_interval"`
+	BatchMaxInFlight         types.Int64   `tfsdk:"batch_max_in_flight"`
+	ReadCacheTTL             types.String  `tfsdk:"read_cache_ttl"`
+	RateLimit                types.Float64 `tfsdk:"rate_limit"`
+	RateLimitBurst           types.Int64   `tfsdk:"rate_limit_burst"`
+	Cassette                 types.String  `tfsdk:"cassette"`
+	CassetteMode             types.String  `tfsdk:"cassette_mode"`
+	WebSession               types.String  `tfsdk:"web_session"`
+	StrictValidation         types.Bool    `tfsdk:"strict_validation"`
+	MaxLifespan              types.Int64   `tfsdk:"max_lifespan"`
+	CheckPartnerGenders      types.Bool    `tfsdk:"check_partner_genders"`
+	Gazetteer                types.String  `tfsdk:"gazetteer"`
+	ExperimentalEvents       types.Bool    `tfsdk:"experimental_events"`
 }
 
 type ClientData struct {
-	Client                   *geni.Client
+	Client                   *geniclient.Client
 	BatchClient              *genibatch.Client
 	AutoUpdateMergedProfiles bool
+	// Web is nil unless a Geni website session is configured.
+	Web *geniweb.Client
+	// StrictValidation makes the chronology checks of resources fail the plan
+	// instead of warning.
+	StrictValidation bool
+	// MaxLifespan is the most years a profile may live, or zero for no limit.
+	MaxLifespan int64
+	// CheckPartnerGenders makes the chronology checks of unions report
+	// partners of the same gender.
+	CheckPartnerGenders bool
+	// Gazetteer is nil unless a gazetteer file is configured.
+	Gazetteer *gazetteer.Gazetteer
+	// ExperimentalEvents lets profiles read and write their own events
+	// through Geni's undocumented event endpoints.
+	ExperimentalEvents bool
 }
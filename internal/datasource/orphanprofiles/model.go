@@ -0,0 +1,27 @@
+package orphanprofiles
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Model struct {
+	IncludeUnnamed types.Bool `tfsdk:"include_unnamed"`
+	Profiles       types.List `tfsdk:"profiles"`
+}
+
+type ProfileModel struct {
+	ID        types.String `tfsdk:"id"`
+	Guid      types.String `tfsdk:"guid"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Unions    types.List   `tfsdk:"unions"`
+}
+
+func ProfileModelAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":         types.StringType,
+		"guid":       types.StringType,
+		"created_at": types.StringType,
+		"unions":     types.ListType{ElemType: types.StringType},
+	}
+}
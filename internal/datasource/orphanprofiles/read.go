@@ -0,0 +1,113 @@
+package orphanprofiles
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+// Read reads the data source.
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data Model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orphans, err := findOrphans(ctx, d.client, data.IncludeUnnamed.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing managed profiles", err.Error())
+		return
+	}
+
+	diags := ValueFrom(ctx, orphans, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findOrphans pages through the profiles the user manages and returns the
+// temporary ones, and with includeUnnamed the nameless ones too, oldest first.
+func findOrphans(ctx context.Context, client *geniclient.Client, includeUnnamed bool) ([]geniprofile.Profile, error) {
+	var orphans []geniprofile.Profile
+	seen := 0
+	for page := 1; ; page++ {
+		bulk, err := client.User().ManagedProfiles(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range bulk.Results {
+			if !p.Deleted && (union.IsTempProfile(&p) || includeUnnamed && unnamed(&p)) {
+				orphans = append(orphans, p)
+			}
+		}
+		seen += len(bulk.Results)
+		if len(bulk.Results) == 0 || seen >= bulk.TotalCount {
+			sortOldestFirst(orphans)
+			return orphans, nil
+		}
+	}
+}
+
+// sortOldestFirst sorts profiles by when they were created, keeping the order
+// Geni listed those created in the same second in.
+func sortOldestFirst(profiles []geniprofile.Profile) {
+	slices.SortStableFunc(profiles, func(a, b geniprofile.Profile) int {
+		return cmp.Compare(createdAt(&a), createdAt(&b))
+	})
+}
+
+// createdAt returns when p was created, in seconds since the epoch, or 0 when
+// Geni did not say.
+func createdAt(p *geniprofile.Profile) int64 {
+	seconds, _ := strconv.ParseInt(p.CreatedAt, 10, 64)
+	return seconds
+}
+
+// unnamed reports whether p has no name in any locale.
+func unnamed(p *geniprofile.Profile) bool {
+	if p.FirstName != nil && *p.FirstName != "" || p.LastName != nil && *p.LastName != "" {
+		return false
+	}
+	for _, name := range p.Names {
+		if name.FirstName != nil && *name.FirstName != "" || name.LastName != nil && *name.LastName != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func ValueFrom(ctx context.Context, orphans []geniprofile.Profile, model *Model) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	profiles := make([]ProfileModel, 0, len(orphans))
+	for _, p := range orphans {
+		// A profile in no union has an empty list of them, not a null one.
+		unions, d := types.ListValueFrom(ctx, types.StringType, append([]string{}, p.Unions...))
+		diags.Append(d...)
+		profiles = append(profiles, ProfileModel{
+			ID:        types.StringValue(p.ID),
+			Guid:      types.StringValue(p.Guid),
+			CreatedAt: types.StringValue(p.CreatedAt),
+			Unions:    unions,
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	model.Profiles, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: ProfileModelAttributeTypes()}, profiles)
+	return diags
+}
@@ -0,0 +1,91 @@
+package orphanprofiles
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genifake"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+func TestFindOrphans(t *testing.T) {
+	RegisterTestingT(t)
+	server := genifake.NewServer()
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL())
+	Expect(err).ToNot(HaveOccurred())
+	client := geniclient.New(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), true,
+		geniclient.Options{RequestsPerSecond: 1000, Burst: 1000, BaseURL: baseURL})
+
+	named := func(firstName string) *geniclient.ProfileRequest {
+		return &geniclient.ProfileRequest{Request: geniprofile.Request{Names: map[string]geniprofile.NameElement{"en-US": {FirstName: new(firstName)}}}}
+	}
+	anna, err := client.Profile().Create(t.Context(), named("Anna"))
+	Expect(err).ToNot(HaveOccurred())
+	temp, err := client.Profile().Create(t.Context(), named(union.TempProfileMarker))
+	Expect(err).ToNot(HaveOccurred())
+	deleted, err := client.Profile().Create(t.Context(), named(union.TempProfileMarker))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(client.Profile().Delete(t.Context(), deleted.ID)).To(Succeed())
+	nameless, err := client.Profile().Create(t.Context(), &geniclient.ProfileRequest{})
+	Expect(err).ToNot(HaveOccurred())
+
+	t.Run("Only live temporary profiles are listed", func(t *testing.T) {
+		RegisterTestingT(t)
+		orphans, err := findOrphans(t.Context(), client, false)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(idsOf(orphans)).To(Equal([]string{temp.ID}))
+	})
+
+	t.Run("Nameless profiles are listed on request", func(t *testing.T) {
+		RegisterTestingT(t)
+		orphans, err := findOrphans(t.Context(), client, true)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(idsOf(orphans)).To(Equal([]string{temp.ID, nameless.ID}))
+		Expect(idsOf(orphans)).ToNot(ContainElement(anna.ID))
+	})
+
+	t.Run("Profiles are sorted oldest first", func(t *testing.T) {
+		RegisterTestingT(t)
+		profiles := []geniprofile.Profile{
+			{ID: "profile-3", CreatedAt: "1719709300"},
+			{ID: "profile-1", CreatedAt: "999999999"},
+			{ID: "profile-2", CreatedAt: "1719709300"},
+		}
+
+		sortOldestFirst(profiles)
+
+		Expect(idsOf(profiles)).To(Equal([]string{"profile-1", "profile-3", "profile-2"}))
+	})
+
+	t.Run("Profiles are converted with their unions", func(t *testing.T) {
+		RegisterTestingT(t)
+		var model Model
+		diags := ValueFrom(t.Context(), []geniprofile.Profile{{ID: "profile-9", Guid: "9", CreatedAt: "1719709300", Unions: []string{"union-1"}}, {ID: "profile-10"}}, &model)
+
+		Expect(diags.HasError()).To(BeFalse())
+		var profiles []ProfileModel
+		Expect(model.Profiles.ElementsAs(t.Context(), &profiles, false).HasError()).To(BeFalse())
+		Expect(profiles).To(HaveLen(2))
+		Expect(profiles[0].ID.ValueString()).To(Equal("profile-9"))
+		Expect(profiles[0].Unions.Elements()).To(ConsistOf(types.StringValue("union-1")))
+		Expect(profiles[1].Unions.IsNull()).To(BeFalse())
+		Expect(profiles[1].Unions.Elements()).To(BeEmpty())
+	})
+}
+
+func idsOf(profiles []geniprofile.Profile) []string {
+	ids := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
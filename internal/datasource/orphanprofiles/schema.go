@@ -0,0 +1,46 @@
+package orphanprofiles
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The temporary profiles that `geni_union` left behind among the profiles the user manages. The provider builds every union edge by creating a temporary profile and merging it into the real one; when both the merge and the clean-up fail, the temporary profile stays on the tree, and the provider names it \"Terraform temporary profile\", which is how this data source finds it. One left behind by an apply that was interrupted is not named: see `include_unnamed`.",
+		Attributes: map[string]schema.Attribute{
+			"include_unnamed": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also list profiles with no name at all, as the temporary profiles left behind by an interrupted apply, or by provider versions that did not name them, are. Review these before deleting any: a real profile can be nameless too. Defaults to false.",
+			},
+			"profiles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The temporary profiles, oldest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the profile.",
+						},
+						"guid": schema.StringAttribute{
+							Computed:    true,
+							Description: "The profile's globally unique identifier.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "The date and time when the profile was created.",
+						},
+						"unions": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The unions the profile is a member of.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
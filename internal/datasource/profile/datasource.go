@@ -6,18 +6,21 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 
-	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 var _ datasource.DataSource = &DataSource{}
 
 type DataSource struct {
 	datasource.DataSourceWithConfigure
-	client                   *geni.Client
+	client                   *geniclient.Client
 	batchClient              *genibatch.Client
 	autoUpdateMergedProfiles bool
+	// experimentalEvents lets the `events` map use Geni's undocumented event
+	// endpoints.
+	experimentalEvents bool
 }
 
 func NewDataSource() datasource.DataSource {
@@ -45,4 +48,5 @@ func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureReques
 	d.client = cfg.Client
 	d.batchClient = cfg.BatchClient
 	d.autoUpdateMergedProfiles = cfg.AutoUpdateMergedProfiles
+	d.experimentalEvents = cfg.ExperimentalEvents
 }
@@ -6,9 +6,11 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/dmalch/go-geni"
-	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 	resourceprofile "github.com/dmalch/terraform-provider-genealogy/internal/resource/profile"
 )
 
@@ -71,14 +73,40 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	}
 
 	state := resourceprofile.NewEmptyResourceModel()
-	resp.Diagnostics.Append(resourceprofile.ValueFrom(ctx, response, &state)...)
+	state.AboutFormat = data.AboutFormat
+	resp.Diagnostics.Append(resourceprofile.ValueFrom(ctx, &response.Profile, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.MasterProfile = types.BoolValue(response.MasterProfile)
+	relatives, err := resourceprofile.RelativesFrom(ctx, &response.Profile, d.batchClient.GetUnion)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading the profile's unions", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resourceprofile.RelativesValueFrom(ctx, relatives, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Geni does not document the event endpoints, so events are only read
+	// when the provider opts in.
+	if d.experimentalEvents {
+		events, err := d.client.Event().List(ctx, response.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading profile events", err.Error())
+			return
+		}
+		var diags diag.Diagnostics
+		state.Events, diags = resourceprofile.EventsValueFrom(ctx, events, state.Events, true)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func (d *DataSource) getProfile(ctx context.Context, idOrGuid string) (*geniprofile.Profile, error) {
+func (d *DataSource) getProfile(ctx context.Context, idOrGuid string) (*geniclient.Profile, error) {
 	return d.batchClient.GetProfile(ctx, idOrGuid)
 }
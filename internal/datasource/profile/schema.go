@@ -10,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	resourceprofile "github.com/dmalch/terraform-provider-genealogy/internal/resource/profile"
 )
 
 var (
@@ -79,6 +81,26 @@ func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp
 				Computed:    true,
 				Description: "List of union IDs the profile belongs to.",
 			},
+			"parents": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the partners of the unions the profile is a child in.",
+			},
+			"partners": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the other partners of the unions the profile is a partner in.",
+			},
+			"children": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the children of the unions the profile is a partner in, including foster and adopted children.",
+			},
+			"siblings": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the other children of the unions the profile is a child in, including half-siblings.",
+			},
 			"projects": schema.SetAttribute{
 				ElementType: types.StringType,
 				Computed:    true,
@@ -90,13 +112,37 @@ func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp
 			"burial":            eventSchema("Burial event information."),
 			"cause_of_death":    schema.StringAttribute{Computed: true, Description: "Profile's death cause."},
 			"current_residence": locationSchema("Profile's current residence."),
+			"events": schema.MapNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":          schema.StringAttribute{Computed: true, Description: "The event's id, such as event-1."},
+						"name":        schema.StringAttribute{Computed: true, Description: "Event's name."},
+						"description": schema.StringAttribute{Computed: true, Description: "Event's description."},
+						"date":        dateRangeSchema("Event's date."),
+						"location":    locationSchema("Event's location."),
+					},
+				},
+				Description: "The profile's own events besides its birth, baptism, death and burial, keyed by their ids. Experimental: null unless `experimental_events` is set in the provider configuration, since the Geni API documentation does not list the event endpoints this uses.",
+			},
 			"about": schema.MapAttribute{
-				ElementType: types.StringType,
+				ElementType: resourceprofile.AboutType{},
 				Computed:    true,
 				Description: "Profile's about-me section, keyed by locale.",
 			},
+			"about_format": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("html", "markdown")},
+				Description: "Format to report the about texts in: `html`, the default, as Geni stores them, or `markdown`, converted from Geni's HTML.",
+			},
+			"master_profile": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether a curator has made the profile a master profile.",
+			},
 			"public":      schema.BoolAttribute{Computed: true, Description: "Profile's public visibility."},
 			"alive":       schema.BoolAttribute{Computed: true, Description: "Profile's alive status."},
+			"locked":      schema.BoolAttribute{Computed: true, Description: "Whether the profile is locked down by a curator."},
+			"language":    schema.StringAttribute{Computed: true, Description: "The profile's language, as set on Geni."},
 			"deleted":     schema.BoolAttribute{Computed: true, Description: "Profile's deleted status."},
 			"merged_into": schema.StringAttribute{Computed: true, Description: "The ID of the profile this profile was merged into, if any."},
 			"created_at":  schema.StringAttribute{Computed: true, Description: "The Unix epoch time in seconds when the profile was created."},
@@ -127,10 +173,22 @@ func locationSchema(description string) schema.SingleNestedAttribute {
 			"latitude":        schema.Float64Attribute{Computed: true, Description: "Latitude coordinate."},
 			"longitude":       schema.Float64Attribute{Computed: true, Description: "Longitude coordinate."},
 			"place_name":      schema.StringAttribute{Computed: true, Description: "Place name."},
+			"place_id":        schema.StringAttribute{Computed: true, Description: "Id of the place in the provider's gazetteer. Always null, since Geni does not record one."},
 			"state":           schema.StringAttribute{Computed: true, Description: "State name."},
 			"street_address1": schema.StringAttribute{Computed: true, Description: "First line of the street address."},
 			"street_address2": schema.StringAttribute{Computed: true, Description: "Second line of the street address."},
 			"street_address3": schema.StringAttribute{Computed: true, Description: "Third line of the street address."},
+			"historical": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"city":       schema.StringAttribute{Computed: true, Description: "City name as recorded."},
+					"country":    schema.StringAttribute{Computed: true, Description: "Country name as recorded."},
+					"county":     schema.StringAttribute{Computed: true, Description: "County name as recorded."},
+					"place_name": schema.StringAttribute{Computed: true, Description: "Place name as recorded."},
+					"state":      schema.StringAttribute{Computed: true, Description: "State name as recorded."},
+				},
+				Description: "Names of the location's jurisdictions as recorded at the time of the event, read from the last line of the event's description.",
+			},
 		},
 		Description: description,
 	}
@@ -149,6 +207,9 @@ func dateRangeSchema(description string) schema.SingleNestedAttribute {
 			"end_day":   schema.Int32Attribute{Computed: true, Description: "Date's end day (only valid if range is between)."},
 			"end_month": schema.Int32Attribute{Computed: true, Description: "Date's end month (only valid if range is between)."},
 			"end_year":  schema.Int32Attribute{Computed: true, Description: "Date's end year (only valid if range is between)."},
+			"calendar":  schema.StringAttribute{Computed: true, Description: "Calendar the date is written in. Always null, since Geni only stores Gregorian dates."},
+			"dual_year": schema.Int32Attribute{Computed: true, Description: "Second year of a dual date. Always null, since Geni does not store dual dates."},
+			"gregorian": schema.StringAttribute{Computed: true, Description: "The start date in the Gregorian calendar: YYYY-MM-DD, YYYY-MM or YYYY. Null when the date has no year."},
 		},
 		Description: description,
 	}
@@ -6,8 +6,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 
-	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 // Ensure the implementation satisfies the desired interfaces.
@@ -15,7 +15,7 @@ var _ datasource.DataSource = &DataSource{}
 
 type DataSource struct {
 	datasource.DataSourceWithConfigure
-	client *geni.Client
+	client *geniclient.Client
 }
 
 func NewDataSource() datasource.DataSource {
@@ -0,0 +1,195 @@
+// Package gazetteer loads a local list of places that event locations can
+// reference by id, so that one place is spelled, and located, the same way
+// across every profile that mentions it.
+//
+// A gazetteer file is tab-separated text, as GeoNames extracts are. Its first
+// line names the columns, which may come in any order:
+//
+//	id               the place's id, such as its GeoNames id (required)
+//	place_name       the names of the location attributes the place fills in
+//	city
+//	county
+//	state
+//	country
+//	latitude         decimal degrees
+//	longitude
+//	alternate_names  other spellings of the place's city or place name,
+//	                 comma-separated, as in GeoNames' alternatenames column
+//
+// Other columns are ignored, so a GeoNames extract only needs its columns
+// renamed. Empty lines and lines starting with "#" are skipped.
+package gazetteer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Components are the location attributes a place fills in.
+var Components = []string{"place_name", "city", "county", "state", "country"}
+
+// Place is an entry of a gazetteer.
+type Place struct {
+	ID string
+	// components are the place's values of the location attributes in
+	// Components, by name. A missing one is unset.
+	components map[string]string
+	// Latitude and Longitude are nil when the gazetteer has no coordinates for
+	// the place.
+	Latitude  *float64
+	Longitude *float64
+	// AlternateNames are other spellings of the place's city or place name.
+	AlternateNames []string
+}
+
+// Component returns the place's value of the location attribute name, one of
+// Components, or "" when it has none.
+func (p Place) Component(name string) string {
+	return p.components[name]
+}
+
+// Matches reports whether value names the place's component name: it is the
+// component's value or, for the city and the place name, one of the place's
+// alternate names, ignoring case and spacing.
+func (p Place) Matches(name, value string) bool {
+	if sameName(p.Component(name), value) {
+		return true
+	}
+	if name != "city" && name != "place_name" {
+		return false
+	}
+	for _, alternate := range p.AlternateNames {
+		if sameName(alternate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameName(a, b string) bool {
+	return a != "" && strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
+// Gazetteer is a set of places by id.
+type Gazetteer struct {
+	places map[string]Place
+}
+
+// Place returns the place with the given id, and false when there is none.
+func (g *Gazetteer) Place(id string) (Place, bool) {
+	p, ok := g.places[id]
+	return p, ok
+}
+
+// Len returns the number of places in g.
+func (g *Gazetteer) Len() int {
+	return len(g.places)
+}
+
+// Load reads the gazetteer file at name.
+func Load(name string) (*Gazetteer, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return g, nil
+}
+
+// Read reads a gazetteer from r, in the format of a gazetteer file.
+func Read(r io.Reader) (*Gazetteer, error) {
+	scanner := bufio.NewScanner(r)
+	// GeoNames' alternate names make for long lines.
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var columns map[string]int
+	g := &Gazetteer{places: make(map[string]Place)}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+
+		if columns == nil {
+			columns = make(map[string]int, len(fields))
+			for i, name := range fields {
+				columns[strings.TrimSpace(name)] = i
+			}
+			if _, ok := columns["id"]; !ok {
+				return nil, fmt.Errorf("line %d: the header has no id column", line)
+			}
+			continue
+		}
+
+		p, err := placeFrom(fields, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if _, ok := g.places[p.ID]; ok {
+			return nil, fmt.Errorf("line %d: place %q is listed twice", line, p.ID)
+		}
+		g.places[p.ID] = p
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("the gazetteer has no header")
+	}
+	return g, nil
+}
+
+// placeFrom returns the place on a line split into fields, whose columns are
+// the indexes of the header's column names.
+func placeFrom(fields []string, columns map[string]int) (Place, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	p := Place{ID: field("id"), components: make(map[string]string)}
+	if p.ID == "" {
+		return p, fmt.Errorf("the place has no id")
+	}
+	for _, name := range Components {
+		if value := field(name); value != "" {
+			p.components[name] = value
+		}
+	}
+	for _, coordinate := range []struct {
+		name string
+		to   **float64
+	}{{"latitude", &p.Latitude}, {"longitude", &p.Longitude}} {
+		value := field(coordinate.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return p, fmt.Errorf("place %q has an invalid %s %q", p.ID, coordinate.name, value)
+		}
+		*coordinate.to = &parsed
+	}
+	if (p.Latitude == nil) != (p.Longitude == nil) {
+		return p, fmt.Errorf("place %q has only one of latitude and longitude", p.ID)
+	}
+	for _, name := range strings.Split(field("alternate_names"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			p.AlternateNames = append(p.AlternateNames, name)
+		}
+	}
+	return p, nil
+}
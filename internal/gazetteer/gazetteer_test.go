@@ -0,0 +1,90 @@
+package gazetteer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const geonamesExtract = `# Extract of RU.txt, with the admin codes resolved
+id	city	alternate_names	latitude	longitude	county	state	country	population
+524901	Moscow	Moskva,Москва, Moscou	55.75222	37.61556			Russia	10381222
+520555	Nizhniy Novgorod	Nizhny Novgorod,Нижний Новгород	56.32867	44.00205		Nizhny Novgorod Oblast	Russia	1284164
+
+6255148	Europe
+
+`
+
+func TestRead(t *testing.T) {
+	t.Run("Places are read by their header's columns", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		g, err := Read(strings.NewReader(geonamesExtract))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.Len()).To(Equal(3))
+		moscow, ok := g.Place("524901")
+		Expect(ok).To(BeTrue())
+		Expect(moscow.Component("city")).To(Equal("Moscow"))
+		Expect(moscow.Component("country")).To(Equal("Russia"))
+		Expect(moscow.Component("state")).To(BeEmpty())
+		Expect(*moscow.Latitude).To(Equal(55.75222))
+		Expect(*moscow.Longitude).To(Equal(37.61556))
+		Expect(moscow.AlternateNames).To(Equal([]string{"Moskva", "Москва", "Moscou"}))
+
+		europe, ok := g.Place("6255148")
+		Expect(ok).To(BeTrue())
+		Expect(europe.Latitude).To(BeNil())
+
+		_, ok = g.Place("1")
+		Expect(ok).To(BeFalse())
+	})
+
+	t.Run("Invalid gazetteers are reported with their line", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for text, message := range map[string]string{
+			"city\tcountry\nMoscow\tRussia\n":           "line 1: the header has no id column",
+			"id\tcity\n1\tMoscow\n1\tMoskva\n":          `line 3: place "1" is listed twice`,
+			"id\tlatitude\tlongitude\n1\tnorth\t37.6\n": `line 2: place "1" has an invalid latitude "north"`,
+			"id\tlatitude\tlongitude\n1\t55.7\t\n":      `line 2: place "1" has only one of latitude and longitude`,
+			"id\tcity\n\tMoscow\n":                      "line 2: the place has no id",
+			"# nothing\n":                               "the gazetteer has no header",
+		} {
+			_, err := Read(strings.NewReader(text))
+			Expect(err).To(MatchError(message), text)
+		}
+	})
+
+	t.Run("A file is read with its name in errors", func(t *testing.T) {
+		RegisterTestingT(t)
+		name := filepath.Join(t.TempDir(), "places.tsv")
+		Expect(os.WriteFile(name, []byte(geonamesExtract), 0o600)).To(Succeed())
+
+		g, err := Load(name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.Len()).To(Equal(3))
+
+		Expect(os.WriteFile(name, []byte("city\n"), 0o600)).To(Succeed())
+		_, err = Load(name)
+		Expect(err).To(MatchError(name + ": line 1: the header has no id column"))
+	})
+}
+
+func TestPlaceMatches(t *testing.T) {
+	RegisterTestingT(t)
+	g, err := Read(strings.NewReader(geonamesExtract))
+	Expect(err).ToNot(HaveOccurred())
+	moscow, _ := g.Place("524901")
+
+	Expect(moscow.Matches("city", "Moscow")).To(BeTrue())
+	Expect(moscow.Matches("city", "  moskva ")).To(BeTrue())
+	Expect(moscow.Matches("city", "Москва")).To(BeTrue())
+	Expect(moscow.Matches("city", "Saint Petersburg")).To(BeFalse())
+	Expect(moscow.Matches("country", "russia")).To(BeTrue())
+	Expect(moscow.Matches("country", "Moskva")).To(BeFalse())
+	Expect(moscow.Matches("state", "")).To(BeFalse())
+}
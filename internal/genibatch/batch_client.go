@@ -2,539 +2,190 @@ package genibatch
 
 import (
 	"context"
-	"fmt"
+	"sync"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	"github.com/dmalch/go-geni"
 	genidocument "github.com/dmalch/go-geni/document"
 	geniphoto "github.com/dmalch/go-geni/photo"
-	geniprofile "github.com/dmalch/go-geni/profile"
 	geniunion "github.com/dmalch/go-geni/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
-type Client struct {
-	client           *geni.Client
-	unionRequests    chan asyncRequest[geniunion.Union]
-	profileRequests  chan asyncRequest[geniprofile.Profile]
-	documentRequests chan asyncRequest[genidocument.Document]
-	photoRequests    chan asyncRequest[geniphoto.Photo]
-}
-
-func NewClient(client *geni.Client) *Client {
-	return &Client{
-		client:           client,
-		unionRequests:    make(chan asyncRequest[geniunion.Union]),
-		profileRequests:  make(chan asyncRequest[geniprofile.Profile]),
-		documentRequests: make(chan asyncRequest[genidocument.Document]),
-		photoRequests:    make(chan asyncRequest[geniphoto.Photo]),
-	}
-}
-
-// asyncRequest is a single batched read awaiting fulfilment by a batch worker.
-// Exactly one value is ever delivered, on Response or on Error.
-type asyncRequest[T any] struct {
-	Id       string
-	Response chan *T
-	Error    chan error
-}
-
-func (c *Client) GetUnion(ctx context.Context, id string) (*geniunion.Union, error) {
-	response := make(chan *geniunion.Union)
-	errors := make(chan error)
-
-	c.unionRequests <- asyncRequest[geniunion.Union]{
-		Id:       id,
-		Response: response,
-		Error:    errors,
-	}
-
-	select {
-	case res := <-response:
-		return res, nil
-	case err := <-errors:
-		tflog.Error(ctx, "Error processing request", map[string]any{"error": err})
-		return nil, err
-	case <-ctx.Done():
-		tflog.Error(ctx, "Context done", map[string]any{"error": ctx.Err()})
-		return nil, ctx.Err()
-	}
-}
-
-func (c *Client) GetProfile(ctx context.Context, id string) (*geniprofile.Profile, error) {
-	response := make(chan *geniprofile.Profile)
-	errors := make(chan error)
-
-	c.profileRequests <- asyncRequest[geniprofile.Profile]{
-		Id:       id,
-		Response: response,
-		Error:    errors,
-	}
-
-	select {
-	case res := <-response:
-		return res, nil
-	case err := <-errors:
-		tflog.Error(ctx, "Error processing request", map[string]any{"error": err})
-		return nil, err
-	case <-ctx.Done():
-		tflog.Error(ctx, "Context done", map[string]any{"error": ctx.Err()})
-		return nil, ctx.Err()
-	}
-}
-
-func (c *Client) GetDocument(ctx context.Context, id string) (*genidocument.Document, error) {
-	response := make(chan *genidocument.Document)
-	errors := make(chan error)
-
-	c.documentRequests <- asyncRequest[genidocument.Document]{
-		Id:       id,
-		Response: response,
-		Error:    errors,
-	}
-
-	select {
-	case res := <-response:
-		return res, nil
-	case err := <-errors:
-		tflog.Error(ctx, "Error processing request", map[string]any{"error": err})
-		return nil, err
-	case <-ctx.Done():
-		tflog.Error(ctx, "Context done", map[string]any{"error": ctx.Err()})
-		return nil, ctx.Err()
-	}
-}
-
-const batchSize = 50
-
-func (c *Client) UnionBulkProcessor(ctx context.Context) {
-	batch := make([]asyncRequest[geniunion.Union], 0, batchSize)
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case req := <-c.unionRequests:
-			batch = append(batch, req)
-			if len(batch) >= batchSize {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[geniunion.Union], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfUnions(ctx, requests)
-			}
-		case <-ticker.C:
-			if len(batch) > 0 {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[geniunion.Union], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfUnions(ctx, requests)
-			}
-		case <-ctx.Done():
-			err := ctx.Err()
-			if err != nil {
-				tflog.Error(ctx, "Context done", map[string]any{"error": err})
-			}
-			return
-		}
-	}
-}
-
-func (c *Client) ProfileBulkProcessor(ctx context.Context) {
-	batch := make([]asyncRequest[geniprofile.Profile], 0, batchSize)
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case req := <-c.profileRequests:
-			batch = append(batch, req)
-			if len(batch) >= batchSize {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[geniprofile.Profile], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfProfiles(ctx, requests)
-			}
-		case <-ticker.C:
-			if len(batch) > 0 {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[geniprofile.Profile], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfProfiles(ctx, requests)
-			}
-		case <-ctx.Done():
-			err := ctx.Err()
-			if err != nil {
-				tflog.Error(ctx, "Context done", map[string]any{"error": err})
-			}
-			return
-		}
-	}
-}
-
-func (c *Client) DocumentBulkProcessor(ctx context.Context) {
-	batch := make([]asyncRequest[genidocument.Document], 0, batchSize)
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case req := <-c.documentRequests:
-			batch = append(batch, req)
-			if len(batch) >= batchSize {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[genidocument.Document], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfDocuments(ctx, requests)
-			}
-		case <-ticker.C:
-			if len(batch) > 0 {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[genidocument.Document], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfDocuments(ctx, requests)
-			}
-		case <-ctx.Done():
-			err := ctx.Err()
-			if err != nil {
-				tflog.Error(ctx, "Context done", map[string]any{"error": err})
-			}
-			return
-		}
-	}
-}
-
-// recoverBatch converts a panic in a batch worker into an error delivered to
-// every request in the batch. Batch workers run in their own goroutines, so an
-// unrecovered panic would crash the entire provider process. Because a worker
-// only panics before it has answered any request (the panic-prone work — the
-// API call — happens up front, before any channel send), broadcasting the error
-// also unblocks every caller that would otherwise wait forever on its response
-// channel. The send is abandoned if ctx is cancelled so the worker cannot leak.
-func recoverBatch[T any](ctx context.Context, kind string, batch []asyncRequest[T]) {
-	r := recover()
-	if r == nil {
-		return
-	}
-
-	err := fmt.Errorf("recovered from panic while processing %s batch: %v", kind, r)
-	tflog.Error(ctx, "Panic in batch processor", map[string]any{"error": err})
-
-	for _, req := range batch {
-		select {
-		case req.Error <- err:
-		case <-ctx.Done():
-		}
-	}
-}
-
-func (c *Client) processBatchOfUnions(ctx context.Context, batch []asyncRequest[geniunion.Union]) {
-	defer recoverBatch(ctx, "union", batch)
-
-	// Create a hashset to store unique IDs
-	ids := make(map[string]struct{}, len(batch))
-	for _, req := range batch {
-		ids[req.Id] = struct{}{}
-	}
-
-	// Get keys from the hashset as a slice
-	keys := make([]string, 0, len(ids))
-	for id := range ids {
-		keys = append(keys, id)
-	}
-
-	if len(keys) == 1 {
-		result, err := c.client.Union().Get(ctx, keys[0])
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
-
-		for _, req := range batch {
-			req.Response <- result
-		}
-	}
-
-	if len(keys) > 1 {
-		res, err := c.client.Union().GetBulk(ctx, keys)
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
+// Defaults applied to any Config field left at its zero value.
+const (
+	DefaultBatchSize     = 50
+	DefaultFlushInterval = 1 * time.Second
+	DefaultMaxInFlight   = 4
+	DefaultCacheTTL      = 5 * time.Minute
+)
 
-		fulfillUnionRequests(batch, res.Results)
-	}
-}
+// Config tunes how reads are grouped into bulk requests.
+type Config struct {
+	// BatchSize is the most requests collected into one bulk call. A batch is
+	// sent as soon as it fills, without waiting for the flush interval.
+	BatchSize int
+	// FlushInterval is how long a partially filled batch waits for more
+	// requests before it is sent anyway.
+	FlushInterval time.Duration
+	// MaxInFlight caps the bulk calls outstanding at once, across every entity
+	// type. A batch that finds no free slot waits for one.
+	MaxInFlight int
+	// CacheTTL is how long a successful read is reused before it is fetched
+	// again. A negative value disables the cache.
+	CacheTTL time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultBatchSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = DefaultFlushInterval
+	}
+	if c.MaxInFlight <= 0 {
+		c.MaxInFlight = DefaultMaxInFlight
+	}
+	if c.CacheTTL == 0 {
+		c.CacheTTL = DefaultCacheTTL
+	}
+	return c
+}
+
+// Client batches the provider's reads of unions, profiles, documents and
+// photos. One in-flight limit is shared by every entity type.
+//
+// Reads are only served between Start and Close: before Start they wait (up
+// to their context), after Close they fail with ErrClosed. A read answered
+// within the last Config.CacheTTL is served from memory until Invalidate is
+// called; the provider calls it after every write.
+type Client struct {
+	unions    *Batcher[geniunion.Union]
+	profiles  *Batcher[geniclient.Profile]
+	documents *Batcher[genidocument.Document]
+	photos    *Batcher[geniphoto.Photo]
 
-// fulfillUnionRequests dispatches per-request results from a bulk union response.
-// IDs absent from the bulk results are treated as not-found, because the Geni bulk
-// endpoint silently omits missing IDs from its response — that absence is the domain
-// signal that the union no longer exists.
-func fulfillUnionRequests(batch []asyncRequest[geniunion.Union], results []geniunion.Union) {
-	idToResponse := make(map[string]*geniunion.Union, len(results))
-	for i := range results {
-		idToResponse[results[i].ID] = &results[i]
-	}
+	unionCache    *cache[geniunion.Union]
+	profileCache  *cache[geniclient.Profile]
+	documentCache *cache[genidocument.Document]
+	photoCache    *cache[geniphoto.Photo]
 
-	for _, req := range batch {
-		if result, ok := idToResponse[req.Id]; ok {
-			req.Response <- result
-		} else {
-			req.Error <- fmt.Errorf("union %s not found in the response: %w", req.Id, geni.ErrResourceNotFound)
-		}
-	}
+	// logCtx is the context Start was given, kept so that Close can log the
+	// cache statistics through the same logger.
+	logCtx    context.Context
+	startOnce sync.Once
+	closeOnce sync.Once
+	cancel    context.CancelFunc
+	running   sync.WaitGroup
 }
 
-func (c *Client) processBatchOfProfiles(ctx context.Context, batch []asyncRequest[geniprofile.Profile]) {
-	defer recoverBatch(ctx, "profile", batch)
-
-	// Create a hashset to store unique IDs
-	ids := make(map[string]struct{}, len(batch))
-	for _, req := range batch {
-		ids[req.Id] = struct{}{}
-	}
-
-	// Get keys from the hashset as a slice
-	keys := make([]string, 0, len(ids))
-	for id := range ids {
-		keys = append(keys, id)
-	}
-
-	if len(keys) == 1 {
-		result, err := c.client.Profile().Get(ctx, keys[0])
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
+func NewClient(client *geniclient.Client, cfg Config) *Client {
+	cfg = cfg.withDefaults()
+	inFlight := make(chan struct{}, cfg.MaxInFlight)
 
-		for _, req := range batch {
-			req.Response <- result
-		}
-	}
-
-	if len(keys) > 1 {
-		res, err := c.client.Profile().GetBulk(ctx, keys)
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
-
-		fulfillProfileRequests(batch, res.Results)
+	return &Client{
+		unions: NewBatcher("union", client.Union().Get,
+			func(ctx context.Context, ids []string) ([]geniunion.Union, error) {
+				res, err := client.Union().GetBulk(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				return res.Results, nil
+			},
+			func(u *geniunion.Union) string { return u.ID }, cfg, inFlight),
+		profiles: NewBatcher("profile", client.Profile().Get,
+			func(ctx context.Context, ids []string) ([]geniclient.Profile, error) {
+				res, err := client.Profile().GetBulk(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				return res.Results, nil
+			},
+			func(p *geniclient.Profile) string { return p.ID }, cfg, inFlight),
+		documents: NewBatcher("document", client.Document().Get,
+			func(ctx context.Context, ids []string) ([]genidocument.Document, error) {
+				res, err := client.Document().GetBulk(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				return res.Results, nil
+			},
+			func(d *genidocument.Document) string { return d.ID }, cfg, inFlight),
+		photos: NewBatcher("photo", client.Photo().Get,
+			func(ctx context.Context, ids []string) ([]geniphoto.Photo, error) {
+				res, err := client.Photo().GetBulk(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				return res.Results, nil
+			},
+			func(p *geniphoto.Photo) string { return p.ID }, cfg, inFlight),
+
+		unionCache:    newCache[geniunion.Union]("union", cfg.CacheTTL),
+		profileCache:  newCache[geniclient.Profile]("profile", cfg.CacheTTL),
+		documentCache: newCache[genidocument.Document]("document", cfg.CacheTTL),
+		photoCache:    newCache[geniphoto.Photo]("photo", cfg.CacheTTL),
 	}
 }
 
-// fulfillProfileRequests dispatches per-request results from a bulk profile response.
-// IDs absent from the bulk results are treated as not-found, because the Geni bulk
-// endpoint silently omits missing IDs from its response.
-func fulfillProfileRequests(batch []asyncRequest[geniprofile.Profile], results []geniprofile.Profile) {
-	idToResponse := make(map[string]*geniprofile.Profile, len(results))
-	for i := range results {
-		idToResponse[results[i].ID] = &results[i]
-	}
-
-	for _, req := range batch {
-		if result, ok := idToResponse[req.Id]; ok {
-			req.Response <- result
-		} else {
-			req.Error <- fmt.Errorf("profile %s not found in the response: %w", req.Id, geni.ErrResourceNotFound)
-		}
-	}
+func (c *Client) GetUnion(ctx context.Context, id string) (*geniunion.Union, error) {
+	return c.unionCache.get(ctx, id, c.unions.Get)
 }
 
-func (c *Client) processBatchOfDocuments(ctx context.Context, batch []asyncRequest[genidocument.Document]) {
-	defer recoverBatch(ctx, "document", batch)
-
-	// Create a hashset to store unique IDs
-	ids := make(map[string]struct{}, len(batch))
-	for _, req := range batch {
-		ids[req.Id] = struct{}{}
-	}
-
-	// Get keys from the hashset as a slice
-	keys := make([]string, 0, len(ids))
-	for id := range ids {
-		keys = append(keys, id)
-	}
-
-	if len(keys) == 1 {
-		result, err := c.client.Document().Get(ctx, keys[0])
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
-
-		for _, req := range batch {
-			req.Response <- result
-		}
-	}
-
-	if len(keys) > 1 {
-		res, err := c.client.Document().GetBulk(ctx, keys)
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
-
-		fulfillDocumentRequests(batch, res.Results)
-	}
+func (c *Client) GetProfile(ctx context.Context, id string) (*geniclient.Profile, error) {
+	return c.profileCache.get(ctx, id, c.profiles.Get)
 }
 
-// fulfillDocumentRequests dispatches per-request results from a bulk document response.
-// IDs absent from the bulk results are treated as not-found, because the Geni bulk
-// endpoint silently omits missing IDs from its response.
-func fulfillDocumentRequests(batch []asyncRequest[genidocument.Document], results []genidocument.Document) {
-	idToResponse := make(map[string]*genidocument.Document, len(results))
-	for i := range results {
-		idToResponse[results[i].ID] = &results[i]
-	}
-
-	for _, req := range batch {
-		if result, ok := idToResponse[req.Id]; ok {
-			req.Response <- result
-		} else {
-			req.Error <- fmt.Errorf("document %s not found in the response: %w", req.Id, geni.ErrResourceNotFound)
-		}
-	}
+func (c *Client) GetDocument(ctx context.Context, id string) (*genidocument.Document, error) {
+	return c.documentCache.get(ctx, id, c.documents.Get)
 }
 
 func (c *Client) GetPhoto(ctx context.Context, id string) (*geniphoto.Photo, error) {
-	response := make(chan *geniphoto.Photo)
-	errors := make(chan error)
-
-	c.photoRequests <- asyncRequest[geniphoto.Photo]{
-		Id:       id,
-		Response: response,
-		Error:    errors,
-	}
-
-	select {
-	case res := <-response:
-		return res, nil
-	case err := <-errors:
-		tflog.Error(ctx, "Error processing request", map[string]any{"error": err})
-		return nil, err
-	case <-ctx.Done():
-		tflog.Error(ctx, "Context done", map[string]any{"error": ctx.Err()})
-		return nil, ctx.Err()
-	}
-}
-
-func (c *Client) PhotoBulkProcessor(ctx context.Context) {
-	batch := make([]asyncRequest[geniphoto.Photo], 0, batchSize)
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case req := <-c.photoRequests:
-			batch = append(batch, req)
-			if len(batch) >= batchSize {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[geniphoto.Photo], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfPhotos(ctx, requests)
-			}
-		case <-ticker.C:
-			if len(batch) > 0 {
-				// copy the batch to a new slice
-				requests := make([]asyncRequest[geniphoto.Photo], len(batch))
-				copy(requests, batch)
-				batch = batch[:0] // Reset batch
-
-				go c.processBatchOfPhotos(ctx, requests)
-			}
-		case <-ctx.Done():
-			err := ctx.Err()
-			if err != nil {
-				tflog.Error(ctx, "Context done", map[string]any{"error": err})
-			}
-			return
-		}
-	}
-}
-
-func (c *Client) processBatchOfPhotos(ctx context.Context, batch []asyncRequest[geniphoto.Photo]) {
-	defer recoverBatch(ctx, "photo", batch)
-
-	// Create a hashset to store unique IDs
-	ids := make(map[string]struct{}, len(batch))
-	for _, req := range batch {
-		ids[req.Id] = struct{}{}
-	}
-
-	// Get keys from the hashset as a slice
-	keys := make([]string, 0, len(ids))
-	for id := range ids {
-		keys = append(keys, id)
-	}
-
-	if len(keys) == 1 {
-		result, err := c.client.Photo().Get(ctx, keys[0])
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
-
-		for _, req := range batch {
-			req.Response <- result
-		}
-	}
-
-	if len(keys) > 1 {
-		res, err := c.client.Photo().GetBulk(ctx, keys)
-		if err != nil {
-			for _, req := range batch {
-				req.Error <- err
-			}
-			return
-		}
-
-		fulfillPhotoRequests(batch, res.Results)
-	}
-}
-
-// fulfillPhotoRequests dispatches per-request results from a bulk photo response.
-// IDs absent from the bulk results are treated as not-found, because the Geni bulk
-// endpoint silently omits missing IDs from its response.
-func fulfillPhotoRequests(batch []asyncRequest[geniphoto.Photo], results []geniphoto.Photo) {
-	idToResponse := make(map[string]*geniphoto.Photo, len(results))
-	for i := range results {
-		idToResponse[results[i].ID] = &results[i]
-	}
-
-	for _, req := range batch {
-		if result, ok := idToResponse[req.Id]; ok {
-			req.Response <- result
-		} else {
-			req.Error <- fmt.Errorf("photo %s not found in the response: %w", req.Id, geni.ErrResourceNotFound)
-		}
-	}
+	return c.photoCache.get(ctx, id, c.photos.Get)
+}
+
+// Start launches the batch processors. They run until ctx is done or Close
+// is called. Calls after the first are no-ops.
+func (c *Client) Start(ctx context.Context) {
+	c.startOnce.Do(func() {
+		c.logCtx = ctx
+		ctx, c.cancel = context.WithCancel(ctx)
+		c.running.Go(func() { c.unions.Run(ctx) })
+		c.running.Go(func() { c.profiles.Run(ctx) })
+		c.running.Go(func() { c.documents.Run(ctx) })
+		c.running.Go(func() { c.photos.Run(ctx) })
+	})
+}
+
+// Close stops the batch processors, cancelling the bulk requests in flight,
+// and returns once every goroutine started by Start has exited. Reads pending
+// at that point, and any made afterwards, fail with ErrClosed. Close is safe
+// to call more than once, and without a prior Start.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		// A client that was never started is started on a cancelled context:
+		// its processors exit at once and mark themselves stopped, so later
+		// reads fail with ErrClosed instead of waiting, and a later Start is a
+		// no-op.
+		stopped, cancel := context.WithCancel(context.Background())
+		cancel()
+		c.Start(stopped)
+
+		c.cancel()
+		c.running.Wait()
+
+		c.unionCache.logStats(c.logCtx)
+		c.profileCache.logStats(c.logCtx)
+		c.documentCache.logStats(c.logCtx)
+		c.photoCache.logStats(c.logCtx)
+	})
+	return nil
+}
+
+// Invalidate drops every cached read, so the next read of any entity goes to
+// Geni. Call it after anything that writes to Geni.
+func (c *Client) Invalidate() {
+	c.unionCache.invalidate()
+	c.profileCache.invalidate()
+	c.documentCache.invalidate()
+	c.photoCache.invalidate()
 }
@@ -0,0 +1,265 @@
+package genibatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/dmalch/go-geni"
+)
+
+// ErrClosed is returned for reads made after the batcher or client has been
+// shut down, and for reads still queued when it was.
+var ErrClosed = errors.New("batch client is closed")
+
+// Batcher coalesces concurrent reads of one Geni entity type into bulk
+// requests. Callers block in Get while Run collects their ids; a batch is sent
+// when it reaches the configured size or when the flush interval ticks,
+// whichever comes first.
+//
+// Batching a new entity type takes a single-get function, a bulk-get function
+// and an id extractor — everything else (dedup, not-found mapping, panic
+// recovery, the in-flight limit) lives here.
+type Batcher[T any] struct {
+	kind     string
+	get      func(context.Context, string) (*T, error)
+	getBulk  func(context.Context, []string) ([]T, error)
+	idOf     func(*T) string
+	cfg      Config
+	inFlight chan struct{}
+	requests chan asyncRequest[T]
+	// stopped is closed once Run has returned, so Get stops waiting for a
+	// loop that will never receive.
+	stopped chan struct{}
+	// workers tracks the batch goroutines started by Run.
+	workers sync.WaitGroup
+}
+
+// NewBatcher returns a Batcher for the entity type named kind (used in errors
+// and logs). inFlight is the semaphore bounding outstanding bulk calls; share
+// one channel between batchers to make the limit span entity types, or pass
+// nil for a batcher of its own sized by cfg.MaxInFlight.
+func NewBatcher[T any](
+	kind string,
+	get func(context.Context, string) (*T, error),
+	getBulk func(context.Context, []string) ([]T, error),
+	idOf func(*T) string,
+	cfg Config,
+	inFlight chan struct{},
+) *Batcher[T] {
+	cfg = cfg.withDefaults()
+	if inFlight == nil {
+		inFlight = make(chan struct{}, cfg.MaxInFlight)
+	}
+	return &Batcher[T]{
+		kind:     kind,
+		get:      get,
+		getBulk:  getBulk,
+		idOf:     idOf,
+		cfg:      cfg,
+		inFlight: inFlight,
+		requests: make(chan asyncRequest[T]),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// asyncRequest is a single batched read awaiting fulfilment by a batch worker.
+// Exactly one value is ever delivered, on Response or on Error. Both channels
+// are buffered so that delivery never blocks, even when the caller has
+// already given up on its context.
+type asyncRequest[T any] struct {
+	Id       string
+	Response chan *T
+	Error    chan error
+}
+
+func newAsyncRequest[T any](id string) asyncRequest[T] {
+	return asyncRequest[T]{
+		Id:       id,
+		Response: make(chan *T, 1),
+		Error:    make(chan error, 1),
+	}
+}
+
+// fail delivers err to every request in batch.
+func fail[T any](batch []asyncRequest[T], err error) {
+	for _, req := range batch {
+		req.Error <- err
+	}
+}
+
+// Get enqueues a read of id and waits for the batch carrying it to complete.
+// It returns early with ctx's error if ctx ends first, and with ErrClosed if
+// the batcher has stopped.
+func (b *Batcher[T]) Get(ctx context.Context, id string) (*T, error) {
+	req := newAsyncRequest[T](id)
+
+	select {
+	case b.requests <- req:
+	case <-b.stopped:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-req.Response:
+		return res, nil
+	case err := <-req.Error:
+		tflog.Error(ctx, "Error processing request", map[string]any{"error": err})
+		return nil, err
+	case <-ctx.Done():
+		tflog.Error(ctx, "Context done", map[string]any{"error": ctx.Err()})
+		return nil, ctx.Err()
+	}
+}
+
+// Run collects requests into batches and sends them until ctx is done. On the
+// way out it fails the requests it has not sent yet with ErrClosed and waits
+// for the batches already in flight, which see ctx cancelled and abort. Run
+// must be called at most once per Batcher.
+func (b *Batcher[T]) Run(ctx context.Context) {
+	batch := make([]asyncRequest[T], 0, b.cfg.BatchSize)
+	ticker := time.NewTicker(b.cfg.FlushInterval)
+
+	defer func() {
+		ticker.Stop()
+		fail(batch, ErrClosed)
+		b.workers.Wait()
+		close(b.stopped)
+		tflog.Debug(ctx, "Batcher stopped", map[string]any{"kind": b.kind})
+	}()
+
+	// flush hands the current batch to a worker, reporting false if ctx ended
+	// while it waited for an in-flight slot. The batch is then left in place
+	// for the deferred cleanup to fail.
+	flush := func() bool {
+		// copy the batch to a new slice
+		requests := make([]asyncRequest[T], len(batch))
+		copy(requests, batch)
+
+		if !b.dispatch(ctx, func(ctx context.Context) { b.process(ctx, requests) }) {
+			return false
+		}
+		batch = batch[:0] // Reset batch
+		return true
+	}
+
+	for {
+		select {
+		case req := <-b.requests:
+			batch = append(batch, req)
+			if len(batch) >= b.cfg.BatchSize && !flush() {
+				return
+			}
+		case <-ticker.C:
+			if len(batch) > 0 && !flush() {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatch runs process on its own goroutine once an in-flight slot is free.
+// It blocks the calling Run loop while every slot is taken, which is the
+// backpressure: the loop stops draining its request channel, so callers queue
+// in Get instead of piling more bulk calls onto Geni. It reports false if ctx
+// ends first, in which case process never runs.
+func (b *Batcher[T]) dispatch(ctx context.Context, process func(context.Context)) bool {
+	select {
+	case b.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	b.workers.Go(func() {
+		defer func() { <-b.inFlight }()
+		process(ctx)
+	})
+	return true
+}
+
+// process fetches one batch and answers every request in it. Duplicate ids
+// are fetched once; a batch with a single distinct id uses the single-get
+// endpoint.
+func (b *Batcher[T]) process(ctx context.Context, batch []asyncRequest[T]) {
+	defer recoverBatch(ctx, b.kind, batch)
+
+	// Create a hashset to store unique IDs
+	ids := make(map[string]struct{}, len(batch))
+	for _, req := range batch {
+		ids[req.Id] = struct{}{}
+	}
+
+	// Get keys from the hashset as a slice
+	keys := make([]string, 0, len(ids))
+	for id := range ids {
+		keys = append(keys, id)
+	}
+
+	if len(keys) == 1 {
+		result, err := b.get(ctx, keys[0])
+		if err != nil {
+			fail(batch, err)
+			return
+		}
+
+		for _, req := range batch {
+			req.Response <- result
+		}
+	}
+
+	if len(keys) > 1 {
+		results, err := b.getBulk(ctx, keys)
+		if err != nil {
+			fail(batch, err)
+			return
+		}
+
+		b.fulfill(batch, results)
+	}
+}
+
+// fulfill dispatches per-request results from a bulk response. IDs absent from
+// the bulk results are treated as not-found, because the Geni bulk endpoints
+// silently omit missing IDs from their response — that absence is the domain
+// signal that the entity no longer exists.
+func (b *Batcher[T]) fulfill(batch []asyncRequest[T], results []T) {
+	idToResponse := make(map[string]*T, len(results))
+	for i := range results {
+		idToResponse[b.idOf(&results[i])] = &results[i]
+	}
+
+	for _, req := range batch {
+		if result, ok := idToResponse[req.Id]; ok {
+			req.Response <- result
+		} else {
+			req.Error <- fmt.Errorf("%s %s not found in the response: %w", b.kind, req.Id, geni.ErrResourceNotFound)
+		}
+	}
+}
+
+// recoverBatch converts a panic in a batch worker into an error delivered to
+// every request in the batch. Batch workers run in their own goroutines, so an
+// unrecovered panic would crash the entire provider process. Because a worker
+// only panics before it has answered any request (the panic-prone work — the
+// API call — happens up front, before any channel send), broadcasting the error
+// also unblocks every caller that would otherwise wait forever on its response
+// channel.
+func recoverBatch[T any](ctx context.Context, kind string, batch []asyncRequest[T]) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err := fmt.Errorf("recovered from panic while processing %s batch: %v", kind, r)
+	tflog.Error(ctx, "Panic in batch processor", map[string]any{"error": err})
+
+	fail(batch, err)
+}
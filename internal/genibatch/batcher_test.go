@@ -0,0 +1,512 @@
+package genibatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	"github.com/dmalch/go-geni"
+	genidocument "github.com/dmalch/go-geni/document"
+	geniphoto "github.com/dmalch/go-geni/photo"
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+)
+
+type item struct {
+	ID string
+}
+
+// fakeAPI stands in for one Geni entity endpoint behind a Batcher. Every call
+// records the ids it was asked for and, while gate is non-nil, blocks until
+// gate is closed so a test can hold calls in flight and count them. Ids in
+// missing are left out of bulk responses, as Geni does for absent entities.
+type fakeAPI struct {
+	gate    chan struct{}
+	missing map[string]bool
+	err     error
+
+	mu          sync.Mutex
+	calls       [][]string
+	inFlight    int
+	maxInFlight int
+}
+
+func (f *fakeAPI) enter(ctx context.Context, ids []string) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, ids)
+	f.inFlight++
+	f.maxInFlight = max(f.maxInFlight, f.inFlight)
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	if f.gate != nil {
+		select {
+		case <-f.gate:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return f.err
+}
+
+func (f *fakeAPI) snapshot() (calls [][]string, inFlight, maxInFlight int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls), f.inFlight, f.maxInFlight
+}
+
+func (f *fakeAPI) get(ctx context.Context, id string) (*item, error) {
+	if err := f.enter(ctx, []string{id}); err != nil {
+		return nil, err
+	}
+	return &item{ID: id}, nil
+}
+
+func (f *fakeAPI) getBulk(ctx context.Context, ids []string) ([]item, error) {
+	if err := f.enter(ctx, ids); err != nil {
+		return nil, err
+	}
+	var results []item
+	for _, id := range ids {
+		if !f.missing[id] {
+			results = append(results, item{ID: id})
+		}
+	}
+	return results, nil
+}
+
+func newTestBatcher(f *fakeAPI, cfg Config, inFlight chan struct{}) *Batcher[item] {
+	return NewBatcher("item", f.get, f.getBulk, func(i *item) string { return i.ID }, cfg, inFlight)
+}
+
+// getConcurrently issues one Get per id in parallel and returns every error,
+// in no particular order, once every call has returned.
+func getConcurrently(ctx context.Context, b *Batcher[item], ids []string) []error {
+	errs := make(chan error, len(ids))
+	for _, id := range ids {
+		go func() {
+			_, err := b.Get(ctx, id)
+			errs <- err
+		}()
+	}
+
+	var out []error
+	for range ids {
+		if err := <-errs; err != nil {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+func TestBatcherProcess(t *testing.T) {
+	t.Run("Duplicate ids are fetched once through the single-get endpoint", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := &fakeAPI{}
+		b := newTestBatcher(fake, Config{}, nil)
+		req1, req2 := newAsyncRequest[item]("item-1"), newAsyncRequest[item]("item-1")
+
+		b.process(t.Context(), []asyncRequest[item]{req1, req2})
+
+		calls, _, _ := fake.snapshot()
+		Expect(calls).To(Equal([][]string{{"item-1"}}))
+		for _, req := range []asyncRequest[item]{req1, req2} {
+			var res *item
+			Expect(req.Response).To(Receive(&res))
+			Expect(res.ID).To(Equal("item-1"))
+		}
+	})
+
+	t.Run("Distinct ids share one bulk call", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := &fakeAPI{}
+		b := newTestBatcher(fake, Config{}, nil)
+		req1, req2, req3 := newAsyncRequest[item]("item-1"), newAsyncRequest[item]("item-2"), newAsyncRequest[item]("item-1")
+
+		b.process(t.Context(), []asyncRequest[item]{req1, req2, req3})
+
+		calls, _, _ := fake.snapshot()
+		Expect(calls).To(HaveLen(1))
+		Expect(calls[0]).To(ConsistOf("item-1", "item-2"))
+		for _, req := range []asyncRequest[item]{req1, req2, req3} {
+			var res *item
+			Expect(req.Response).To(Receive(&res))
+			Expect(res.ID).To(Equal(req.Id))
+		}
+	})
+
+	t.Run("Missing ID in bulk response surfaces ErrResourceNotFound", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := &fakeAPI{missing: map[string]bool{"item-miss": true}}
+		b := newTestBatcher(fake, Config{}, nil)
+		hit, miss := newAsyncRequest[item]("item-hit"), newAsyncRequest[item]("item-miss")
+
+		b.process(t.Context(), []asyncRequest[item]{hit, miss})
+
+		var hitResp *item
+		Expect(hit.Response).To(Receive(&hitResp))
+		Expect(hitResp.ID).To(Equal("item-hit"))
+		Expect(hit.Error).ToNot(Receive())
+
+		var missErr error
+		Expect(miss.Error).To(Receive(&missErr))
+		Expect(errors.Is(missErr, geni.ErrResourceNotFound)).To(BeTrue())
+		Expect(missErr).To(MatchError(ContainSubstring("item item-miss")))
+		Expect(miss.Response).ToNot(Receive())
+	})
+
+	t.Run("An API error fails every request in the batch", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := &fakeAPI{err: errors.New("boom")}
+		b := newTestBatcher(fake, Config{}, nil)
+		req1, req2 := newAsyncRequest[item]("item-1"), newAsyncRequest[item]("item-2")
+
+		b.process(t.Context(), []asyncRequest[item]{req1, req2})
+
+		for _, req := range []asyncRequest[item]{req1, req2} {
+			Expect(req.Error).To(Receive(MatchError("boom")))
+			Expect(req.Response).ToNot(Receive())
+		}
+	})
+
+	// A panic inside a batch worker goroutine must be recovered and broadcast
+	// as an error to every request in the batch, rather than crashing the
+	// provider process and stranding every caller.
+	t.Run("A panic in the worker fails every request", func(t *testing.T) {
+		RegisterTestingT(t)
+		panicking := func(context.Context, []string) ([]item, error) { panic("boom") }
+		b := NewBatcher("item", nil, panicking, func(i *item) string { return i.ID }, Config{}, nil)
+		req1, req2 := newAsyncRequest[item]("item-1"), newAsyncRequest[item]("item-2")
+
+		b.process(t.Context(), []asyncRequest[item]{req1, req2})
+
+		for _, req := range []asyncRequest[item]{req1, req2} {
+			var err error
+			Expect(req.Error).To(Receive(&err))
+			Expect(err).To(MatchError(ContainSubstring("panic while processing item batch")))
+			Expect(req.Response).ToNot(Receive())
+		}
+	})
+}
+
+// TestClientBatchers checks the batchers NewClient builds for each entity
+// type, whose id functions decide which bulk results answer which request.
+func TestClientBatchers(t *testing.T) {
+	newClient := func(transport http.RoundTripper) *Client {
+		tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+		return NewClient(geniclient.New(tokenSource, true, geniclient.Options{RequestsPerSecond: 1000, Burst: 1000, Transport: transport}), Config{})
+	}
+
+	t.Run("A union missing from the bulk response is not found", func(t *testing.T) {
+		RegisterTestingT(t)
+		b := newClient(nil).unions
+
+		expectMissingNotFound(b, "union", []geniunion.Union{{ID: "union-hit"}}, func(u *geniunion.Union) string { return u.ID })
+	})
+
+	t.Run("A profile missing from the bulk response is not found", func(t *testing.T) {
+		RegisterTestingT(t)
+		b := newClient(nil).profiles
+
+		expectMissingNotFound(b, "profile", []geniclient.Profile{{Profile: geniprofile.Profile{ID: "profile-hit"}}}, func(p *geniclient.Profile) string { return p.ID })
+	})
+
+	t.Run("A document missing from the bulk response is not found", func(t *testing.T) {
+		RegisterTestingT(t)
+		b := newClient(nil).documents
+
+		expectMissingNotFound(b, "document", []genidocument.Document{{ID: "document-hit", Title: "found"}}, func(d *genidocument.Document) string { return d.ID })
+	})
+
+	t.Run("A photo missing from the bulk response is not found", func(t *testing.T) {
+		RegisterTestingT(t)
+		b := newClient(nil).photos
+
+		expectMissingNotFound(b, "photo", []geniphoto.Photo{{ID: "photo-hit"}}, func(p *geniphoto.Photo) string { return p.ID })
+	})
+
+	// A transport that panics stands in for any unexpected panic inside the
+	// bulk call of a worker. Each kind gets a client of its own, whose first
+	// request go-geni's limiter lets through at once.
+	panicking := roundTripperFunc(func(*http.Request) (*http.Response, error) { panic("boom") })
+
+	t.Run("A panic in the union batch worker fails every request", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectPanicFailsBatch(t, newClient(panicking).unions, "union")
+	})
+
+	t.Run("A panic in the profile batch worker fails every request", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectPanicFailsBatch(t, newClient(panicking).profiles, "profile")
+	})
+
+	t.Run("A panic in the document batch worker fails every request", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectPanicFailsBatch(t, newClient(panicking).documents, "document")
+	})
+
+	t.Run("A panic in the photo batch worker fails every request", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectPanicFailsBatch(t, newClient(panicking).photos, "photo")
+	})
+}
+
+// expectMissingNotFound fulfils a batch of a hit, the only entry of results,
+// and a miss, and checks the hit gets its result and the miss
+// ErrResourceNotFound.
+func expectMissingNotFound[T any](b *Batcher[T], kind string, results []T, idOf func(*T) string) {
+	hit, miss := newAsyncRequest[T](kind+"-hit"), newAsyncRequest[T](kind+"-miss")
+
+	b.fulfill([]asyncRequest[T]{hit, miss}, results)
+
+	var hitResp *T
+	Expect(hit.Response).To(Receive(&hitResp))
+	Expect(idOf(hitResp)).To(Equal(kind + "-hit"))
+	Expect(hit.Error).ToNot(Receive())
+
+	var missErr error
+	Expect(miss.Error).To(Receive(&missErr))
+	Expect(errors.Is(missErr, geni.ErrResourceNotFound)).To(BeTrue())
+	Expect(missErr).To(MatchError(ContainSubstring(kind + " " + kind + "-miss")))
+	Expect(miss.Response).ToNot(Receive())
+}
+
+// expectPanicFailsBatch processes a batch of two ids and checks both requests
+// fail with the recovered panic.
+func expectPanicFailsBatch[T any](t *testing.T, b *Batcher[T], kind string) {
+	req1, req2 := newAsyncRequest[T](kind+"-1"), newAsyncRequest[T](kind+"-2")
+
+	b.process(t.Context(), []asyncRequest[T]{req1, req2})
+
+	for _, req := range []asyncRequest[T]{req1, req2} {
+		var err error
+		Expect(req.Error).To(Receive(&err))
+		Expect(err).To(MatchError(ContainSubstring("panic while processing " + kind + " batch")))
+		Expect(req.Response).ToNot(Receive())
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestBatcherLimits(t *testing.T) {
+	t.Run("No bulk call carries more ids than the batch size", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := &fakeAPI{}
+		b := newTestBatcher(fake, Config{BatchSize: 3, FlushInterval: 10 * time.Millisecond}, nil)
+		go b.Run(t.Context())
+
+		ids := make([]string, 10)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("item-%d", i)
+		}
+
+		Expect(getConcurrently(t.Context(), b, ids)).To(BeEmpty())
+
+		calls, _, _ := fake.snapshot()
+		var requested []string
+		for _, call := range calls {
+			Expect(len(call)).To(BeNumerically("<=", 3))
+			requested = append(requested, call...)
+		}
+		Expect(requested).To(ConsistOf(ids))
+	})
+
+	t.Run("A partial batch is sent after the flush interval", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := &fakeAPI{}
+		b := newTestBatcher(fake, Config{BatchSize: 50, FlushInterval: 10 * time.Millisecond}, nil)
+		go b.Run(t.Context())
+
+		start := time.Now()
+		_, err := b.Get(t.Context(), "item-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		// Well under DefaultFlushInterval, which the old hard-coded ticker used.
+		Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+	})
+
+	t.Run("Batchers sharing a semaphore never exceed MaxInFlight together", func(t *testing.T) {
+		RegisterTestingT(t)
+		gate := make(chan struct{})
+		first, second := &fakeAPI{gate: gate}, &fakeAPI{gate: gate}
+		cfg := Config{BatchSize: 1, FlushInterval: time.Millisecond, MaxInFlight: 2}
+		inFlight := make(chan struct{}, cfg.MaxInFlight)
+		b1 := newTestBatcher(first, cfg, inFlight)
+		b2 := newTestBatcher(second, cfg, inFlight)
+		go b1.Run(t.Context())
+		go b2.Run(t.Context())
+
+		done := make(chan []error, 2)
+		go func() { done <- getConcurrently(t.Context(), b1, []string{"item-1", "item-2", "item-3", "item-4"}) }()
+		go func() { done <- getConcurrently(t.Context(), b2, []string{"item-5", "item-6"}) }()
+
+		total := func() int {
+			_, n1, _ := first.snapshot()
+			_, n2, _ := second.snapshot()
+			return n1 + n2
+		}
+		Eventually(total).Should(Equal(2))
+		Consistently(total, 100*time.Millisecond).Should(Equal(2))
+
+		close(gate)
+
+		Eventually(done).Should(Receive(BeEmpty()))
+		Eventually(done).Should(Receive(BeEmpty()))
+		calls1, _, _ := first.snapshot()
+		calls2, _, _ := second.snapshot()
+		Expect(len(calls1) + len(calls2)).To(Equal(6))
+	})
+}
+
+// expectNoGoroutineLeak fails the test if, once it and its cleanups have run,
+// more goroutines are alive than when it started. It is a hand-rolled stand-in
+// for goleak: the count is polled because exiting goroutines take a moment to
+// be reaped.
+func expectNoGoroutineLeak(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", before),
+			"goroutines still running after the test")
+	})
+}
+
+func TestBatcherLifecycle(t *testing.T) {
+	t.Run("Get gives up on its context when nothing is receiving", func(t *testing.T) {
+		RegisterTestingT(t)
+		b := newTestBatcher(&fakeAPI{}, Config{}, nil)
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := b.Get(ctx, "item-1")
+
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	t.Run("Get fails with ErrClosed once Run has returned", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectNoGoroutineLeak(t)
+		b := newTestBatcher(&fakeAPI{}, Config{}, nil)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		b.Run(ctx)
+
+		_, err := b.Get(t.Context(), "item-1")
+
+		Expect(err).To(MatchError(ErrClosed))
+	})
+
+	t.Run("Requests not yet sent fail with ErrClosed when Run stops", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectNoGoroutineLeak(t)
+		fake := &fakeAPI{}
+		b := newTestBatcher(fake, Config{FlushInterval: time.Hour}, nil)
+		ctx, cancel := context.WithCancel(t.Context())
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			b.Run(ctx)
+		}()
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := b.Get(t.Context(), "item-1")
+			errs <- err
+		}()
+		// Let the request reach the batch; the hour-long flush interval keeps it
+		// there.
+		Consistently(errs, 50*time.Millisecond).ShouldNot(Receive())
+
+		cancel()
+
+		Eventually(errs).Should(Receive(MatchError(ErrClosed)))
+		Eventually(stopped).Should(BeClosed())
+		calls, _, _ := fake.snapshot()
+		Expect(calls).To(BeEmpty())
+	})
+
+	t.Run("An in-flight batch is cancelled when Run stops", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectNoGoroutineLeak(t)
+		fake := &fakeAPI{gate: make(chan struct{})}
+		b := newTestBatcher(fake, Config{BatchSize: 1}, nil)
+		ctx, cancel := context.WithCancel(t.Context())
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			b.Run(ctx)
+		}()
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := b.Get(t.Context(), "item-1")
+			errs <- err
+		}()
+		Eventually(func() int {
+			_, n, _ := fake.snapshot()
+			return n
+		}).Should(Equal(1))
+
+		cancel()
+
+		Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+		Eventually(stopped).Should(BeClosed())
+		_, inFlight, _ := fake.snapshot()
+		Expect(inFlight).To(BeZero())
+	})
+
+	t.Run("A caller that gives up does not block the worker answering it", func(t *testing.T) {
+		RegisterTestingT(t)
+		expectNoGoroutineLeak(t)
+		gate := make(chan struct{})
+		fake := &fakeAPI{gate: gate}
+		b := newTestBatcher(fake, Config{BatchSize: 1}, nil)
+		ctx, cancel := context.WithCancel(t.Context())
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			b.Run(ctx)
+		}()
+
+		getCtx, getCancel := context.WithCancel(t.Context())
+		errs := make(chan error, 1)
+		go func() {
+			_, err := b.Get(getCtx, "item-1")
+			errs <- err
+		}()
+		Eventually(func() int {
+			_, n, _ := fake.snapshot()
+			return n
+		}).Should(Equal(1))
+
+		getCancel()
+		Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+		close(gate)
+
+		// The worker's answer goes unread; it must still finish and free its
+		// slot rather than wait forever for the caller.
+		Eventually(func() int {
+			_, n, _ := fake.snapshot()
+			return n
+		}).Should(BeZero())
+		cancel()
+		Eventually(stopped).Should(BeClosed())
+	})
+}
@@ -0,0 +1,137 @@
+package genibatch
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cache remembers successful reads of one entity type for a while, so the
+// several resources of a plan that look at the same profile or union share a
+// single fetch instead of one per batch window.
+//
+// Any write through the provider may change what a read would return, and
+// Geni's merges and auto-merges ripple across entities, so invalidate drops
+// every entry rather than trying to work out which ones a write touched.
+type cache[T any] struct {
+	kind string
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry[T]
+	// generation is bumped by every invalidate. A fetch that started before an
+	// invalidate must not store its result afterwards: the write that caused
+	// the invalidate may have landed after Geni answered.
+	generation uint64
+	hits       int
+	misses     int
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func newCache[T any](kind string, ttl time.Duration) *cache[T] {
+	return &cache[T]{
+		kind:    kind,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]cacheEntry[T]{},
+	}
+}
+
+// get returns the cached value for id, or calls fetch and caches what it
+// returns. Errors are not cached. The cache keeps a deep copy of what fetch
+// returned and answers each hit with another, so a caller that modifies its
+// value, down to the slices and maps in it, cannot corrupt what later callers
+// see.
+func (c *cache[T]) get(ctx context.Context, id string, fetch func(context.Context, string) (*T, error)) (*T, error) {
+	if c.ttl <= 0 {
+		return fetch(ctx, id)
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[id]; ok && c.now().Before(entry.expires) {
+		c.hits++
+		c.logAccess(ctx, id, "hit")
+		c.mu.Unlock()
+		return deepCopy(&entry.value)
+	}
+	c.misses++
+	c.logAccess(ctx, id, "miss")
+	generation := c.generation
+	c.mu.Unlock()
+
+	value, err := fetch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := deepCopy(value)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[id] = cacheEntry[T]{value: *stored, expires: c.now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+// logAccess logs a read the cache answered or passed on, with the counts so
+// far. c.mu must be held.
+func (c *cache[T]) logAccess(ctx context.Context, id, outcome string) {
+	tflog.Debug(ctx, "Batch client read cache "+outcome, map[string]any{
+		"kind":   c.kind,
+		"id":     id,
+		"hits":   c.hits,
+		"misses": c.misses,
+	})
+}
+
+// deepCopy returns a copy of value that shares nothing with it. The cached
+// types are Geni's JSON documents, so a JSON round trip copies all of them.
+func deepCopy[T any](value *T) (*T, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// invalidate drops every entry.
+func (c *cache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.generation++
+}
+
+// logStats reports how many reads the cache answered and how many it passed
+// on to Geni.
+func (c *cache[T]) logStats(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+
+	tflog.Info(ctx, "Batch client read cache statistics", map[string]any{
+		"kind":   c.kind,
+		"hits":   c.hits,
+		"misses": c.misses,
+	})
+}
@@ -0,0 +1,154 @@
+package genibatch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// countingFetch returns a fetch function that answers any id and counts how
+// often it was called.
+func countingFetch(calls *atomic.Int32) func(context.Context, string) (*item, error) {
+	return func(_ context.Context, id string) (*item, error) {
+		calls.Add(1)
+		return &item{ID: id}, nil
+	}
+}
+
+// nestedItem is an entity whose value holds slices and maps, as Geni's do.
+type nestedItem struct {
+	ID    string
+	Tags  []string
+	Names map[string]string
+}
+
+func TestCache(t *testing.T) {
+	t.Run("A repeated read is served from the cache", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+
+		for range 3 {
+			res, err := c.get(t.Context(), "item-1", countingFetch(&calls))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.ID).To(Equal("item-1"))
+		}
+
+		Expect(calls.Load()).To(Equal(int32(1)))
+		Expect(c.hits).To(Equal(2))
+		Expect(c.misses).To(Equal(1))
+	})
+
+	t.Run("Entries are per id", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+		_, _ = c.get(t.Context(), "item-2", countingFetch(&calls))
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	t.Run("An expired entry is fetched again", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+		now = now.Add(time.Minute)
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	t.Run("Invalidate drops every entry", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+		c.invalidate()
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	t.Run("A fetch overtaken by an invalidate is not cached", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+
+		_, _ = c.get(t.Context(), "item-1", func(ctx context.Context, id string) (*item, error) {
+			// A write lands while this read is on the wire.
+			c.invalidate()
+			return countingFetch(&calls)(ctx, id)
+		})
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+
+		_, err := c.get(t.Context(), "item-1", func(context.Context, string) (*item, error) {
+			return nil, errors.New("boom")
+		})
+		Expect(err).To(MatchError("boom"))
+		_, err = c.get(t.Context(), "item-1", countingFetch(&calls))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	t.Run("Callers cannot modify the cached value", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", time.Minute)
+
+		first, _ := c.get(t.Context(), "item-1", countingFetch(&calls))
+		first.ID = "changed"
+		second, _ := c.get(t.Context(), "item-1", countingFetch(&calls))
+
+		Expect(second.ID).To(Equal("item-1"))
+	})
+
+	t.Run("Callers cannot modify the slices and maps of the cached value", func(t *testing.T) {
+		RegisterTestingT(t)
+		c := newCache[nestedItem]("item", time.Minute)
+		fetch := func(_ context.Context, id string) (*nestedItem, error) {
+			return &nestedItem{ID: id, Tags: []string{"fetched"}, Names: map[string]string{"en-US": "fetched"}}, nil
+		}
+
+		fetched, _ := c.get(t.Context(), "item-1", fetch)
+		fetched.Tags[0] = "changed"
+		fetched.Names["en-US"] = "changed"
+		hit, _ := c.get(t.Context(), "item-1", fetch)
+		hit.Tags[0] = "changed"
+		hit.Names["en-US"] = "changed"
+		again, _ := c.get(t.Context(), "item-1", fetch)
+
+		Expect(again.Tags).To(Equal([]string{"fetched"}))
+		Expect(again.Names).To(Equal(map[string]string{"en-US": "fetched"}))
+	})
+
+	t.Run("A negative TTL disables the cache", func(t *testing.T) {
+		RegisterTestingT(t)
+		var calls atomic.Int32
+		c := newCache[item]("item", -1)
+
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+		_, _ = c.get(t.Context(), "item-1", countingFetch(&calls))
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+}
@@ -0,0 +1,137 @@
+// Package genicassette records the provider's conversation with Geni to a
+// cassette file and replays it later without a network.
+//
+// A cassette is a JSON Lines file: one Interaction — a request and the response
+// Geni gave it — per line, in the order the responses arrived. Lines are
+// written as each response comes in, so a cassette survives Terraform stopping
+// the provider mid-operation, and it can be read, trimmed or attached to a bug
+// report as plain text. The access token never reaches the file, and neither
+// do the website's session cookies or the authenticity tokens of its forms.
+//
+// Replaying matches a request by method, path and query, ignoring the host, so
+// a cassette recorded through a proxy or against a stand-in server replays the
+// same as one recorded against Geni.
+package genicassette
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// redacted replaces every secret written to a cassette.
+const redacted = "REDACTED"
+
+// secretQueryParams are the query parameters that carry credentials.
+var secretQueryParams = []string{"access_token"}
+
+// secretHeaders are the response headers that carry credentials. Geni returns
+// a new account's token in one when a user is created, and the website renews
+// the session cookies in Set-Cookie. Request headers, Cookie among them, are
+// not recorded at all.
+var secretHeaders = []string{"X-Api-Oauth-Access_token", "Set-Cookie"}
+
+// secretBodyValues match the credentials in request and response bodies: the
+// authenticity token the website's forms post, and the pages and scripts it
+// is scraped from. Each keeps what surrounds the secret in its first and
+// second groups.
+var secretBodyValues = []*regexp.Regexp{
+	regexp.MustCompile(`(authenticity_token=)[^&\s"]*()`),
+	regexp.MustCompile(`("authenticity_token"\s*:\s*")[^"]*(")`),
+	regexp.MustCompile(`(<input[^>]*name="authenticity_token"[^>]*value=")[^"]*(")`),
+	regexp.MustCompile(`(<input[^>]*value=")[^"]*("[^>]*name="authenticity_token")`),
+	regexp.MustCompile(`(<meta[^>]*name="csrf-token"[^>]*content=")[^"]*(")`),
+	regexp.MustCompile(`(Tr8n\.csrfToken\s*=\s*")[^"]*(")`),
+}
+
+// Interaction is one request and the response Geni gave it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded part of a request: enough to recognise it when it is
+// sent again, and to read what the provider asked for.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Body is the request body when it is text. Binary bodies, such as photo
+	// uploads, are left out: they are not needed to match the request.
+	Body string `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	// Body is the response body when it is text.
+	Body string `json:"body,omitempty"`
+	// BodyBase64 is the response body when it is not text.
+	BodyBase64 string `json:"body_base64,omitempty"`
+}
+
+// body returns the response body however it was recorded.
+func (r Response) body() ([]byte, error) {
+	if r.BodyBase64 != "" {
+		return base64.StdEncoding.DecodeString(r.BodyBase64)
+	}
+	return []byte(r.Body), nil
+}
+
+// newResponse records status, header and body, with credentials redacted.
+func newResponse(status int, header http.Header, body []byte) Response {
+	response := Response{Status: status, Header: header.Clone()}
+	for _, name := range secretHeaders {
+		if response.Header.Get(name) != "" {
+			response.Header.Set(name, redacted)
+		}
+	}
+	if utf8.Valid(body) {
+		response.Body = redactBody(string(body))
+	} else {
+		response.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	return response
+}
+
+// redactBody returns body with the credentials secretBodyValues match
+// replaced.
+func redactBody(body string) string {
+	for _, secret := range secretBodyValues {
+		body = secret.ReplaceAllString(body, "${1}"+redacted+"${2}")
+	}
+	return body
+}
+
+// redactURL returns u as a string with its credentials replaced.
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	query := redactedURL.Query()
+	for _, param := range secretQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// matchKey identifies the requests a recorded response may answer: the method,
+// the path and the query without credentials. Bulk reads list their ids in
+// whatever order the batch happened to collect them, so the ids are sorted.
+func matchKey(method string, u *url.URL) string {
+	query := u.Query()
+	for _, param := range secretQueryParams {
+		query.Del(param)
+	}
+	if ids := query.Get("ids"); ids != "" {
+		sorted := strings.Split(ids, ",")
+		slices.Sort(sorted)
+		query.Set("ids", strings.Join(sorted, ","))
+	}
+	return method + " " + u.Path + "?" + query.Encode()
+}
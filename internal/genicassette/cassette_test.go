@@ -0,0 +1,189 @@
+package genicassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// record sends each request through a Recorder to an echoing server and
+// returns the cassette's path.
+func record(t *testing.T, requests ...*http.Request) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-API-OAuth-access_token", "issued-token")
+		w.Header().Set("X-API-Rate-Limit", "40")
+		_, _ = io.WriteString(w, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("ids")+" "+string(body))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	recorder, err := NewRecorder(path, http.DefaultTransport)
+	Expect(err).ToNot(HaveOccurred())
+
+	client := &http.Client{Transport: recorder}
+	for _, req := range requests {
+		req.URL.Scheme = "http"
+		req.URL.Host = strings.TrimPrefix(server.URL, "http://")
+		res, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		_ = res.Body.Close()
+	}
+	Expect(recorder.Close()).To(Succeed())
+	return path
+}
+
+func newRequest(t *testing.T, method, target, body string) *http.Request {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(t.Context(), method, target, reader)
+	Expect(err).ToNot(HaveOccurred())
+	return req
+}
+
+// replay sends req through replayer and returns the response body.
+func replay(replayer *Replayer, req *http.Request) (string, error) {
+	res, err := (&http.Client{Transport: replayer}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+	body, err := io.ReadAll(res.Body)
+	return string(body), err
+}
+
+func TestRecorder(t *testing.T) {
+	t.Run("Credentials never reach the cassette", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		path := record(t, newRequest(t, http.MethodGet, "https://www.geni.com/api/profile-1?access_token=secret-token&only_ids=true", ""))
+
+		content, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).ToNot(ContainSubstring("secret-token"))
+		Expect(string(content)).ToNot(ContainSubstring("issued-token"))
+		Expect(string(content)).To(ContainSubstring("access_token=REDACTED"))
+		Expect(string(content)).To(ContainSubstring(`"X-Api-Rate-Limit":["40"]`))
+	})
+
+	t.Run("Website session cookies and form tokens never reach the cassette", func(t *testing.T) {
+		RegisterTestingT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "_geni_session", Value: "session-secret"})
+			_, _ = io.WriteString(w, `<meta name="csrf-token" content="meta-secret" />`+
+				`<input type="hidden" name="authenticity_token" value="form-secret" />`+
+				`<script>Tr8n.csrfToken = "script-secret";</script>`)
+		}))
+		t.Cleanup(server.Close)
+		path := filepath.Join(t.TempDir(), "cassette.jsonl")
+		recorder, err := NewRecorder(path, http.DefaultTransport)
+		Expect(err).ToNot(HaveOccurred())
+
+		req := newRequest(t, http.MethodPost, server.URL+"/union/remove_relationships", "authenticity_token=posted-secret&profile_ids=1")
+		req.Header.Set("Cookie", "_geni_session=cookie-secret")
+		res, err := (&http.Client{Transport: recorder}).Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		_ = res.Body.Close()
+		Expect(recorder.Close()).To(Succeed())
+
+		content, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		for _, secret := range []string{"session-secret", "cookie-secret", "meta-secret", "form-secret", "script-secret", "posted-secret"} {
+			Expect(string(content)).ToNot(ContainSubstring(secret))
+		}
+		Expect(string(content)).To(ContainSubstring(`"Set-Cookie":["REDACTED"]`))
+		Expect(string(content)).To(ContainSubstring("authenticity_token=REDACTED&profile_ids=1"))
+		Expect(string(content)).To(ContainSubstring(`name=\"authenticity_token\" value=\"REDACTED\"`))
+	})
+
+	t.Run("Request bodies still reach the server and are recorded", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		path := record(t, newRequest(t, http.MethodPost, "https://www.geni.com/api/profile-1/update", `{"first_name":"Ada"}`))
+
+		replayer, err := Load(path)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := replay(replayer, newRequest(t, http.MethodPost, "http://127.0.0.1/api/profile-1/update", `{"first_name":"Ada"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal(`POST /api/profile-1/update  {"first_name":"Ada"}`))
+
+		content, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring(`"body":"{\"first_name\":\"Ada\"}"`))
+	})
+}
+
+func TestReplayer(t *testing.T) {
+	t.Run("Responses to the same request replay in recorded order, ignoring the host", func(t *testing.T) {
+		RegisterTestingT(t)
+		path := record(t,
+			newRequest(t, http.MethodPost, "https://www.geni.com/api/profile-1/add-partner?access_token=a", "first"),
+			newRequest(t, http.MethodPost, "https://www.geni.com/api/profile-1/add-partner?access_token=b", "second"),
+		)
+		replayer, err := Load(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		first, err := replay(replayer, newRequest(t, http.MethodPost, "https://sandbox.geni.com/api/profile-1/add-partner?access_token=c", ""))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := replay(replayer, newRequest(t, http.MethodPost, "https://sandbox.geni.com/api/profile-1/add-partner?access_token=c", ""))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(first).To(HaveSuffix("first"))
+		Expect(second).To(HaveSuffix("second"))
+		Expect(replayer.Remaining()).To(BeZero())
+	})
+
+	t.Run("A read keeps its last response; a write that was never recorded fails", func(t *testing.T) {
+		RegisterTestingT(t)
+		path := record(t,
+			newRequest(t, http.MethodGet, "https://www.geni.com/api/union-1", ""),
+			newRequest(t, http.MethodPost, "https://www.geni.com/api/union-1/update", "{}"),
+		)
+		replayer, err := Load(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		for range 3 {
+			body, err := replay(replayer, newRequest(t, http.MethodGet, "https://www.geni.com/api/union-1", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(HavePrefix("GET /api/union-1"))
+		}
+		_, err = replay(replayer, newRequest(t, http.MethodPost, "https://www.geni.com/api/union-1/update", "{}"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = replay(replayer, newRequest(t, http.MethodPost, "https://www.geni.com/api/union-1/update", "{}"))
+		Expect(err).To(MatchError(ContainSubstring("cassette has no response for POST")))
+		_, err = replay(replayer, newRequest(t, http.MethodPost, "https://www.geni.com/api/union-1/add-child", ""))
+		Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("Bulk reads match whatever order their ids were batched in", func(t *testing.T) {
+		RegisterTestingT(t)
+		path := record(t, newRequest(t, http.MethodGet, "https://www.geni.com/api/profile?ids=profile-2,profile-1", ""))
+		replayer, err := Load(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		body, err := replay(replayer, newRequest(t, http.MethodGet, "https://www.geni.com/api/profile?ids=profile-1,profile-2", ""))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(ContainSubstring("profile-2,profile-1"))
+	})
+
+	t.Run("A malformed cassette is reported with its line", func(t *testing.T) {
+		RegisterTestingT(t)
+		path := filepath.Join(t.TempDir(), "broken.jsonl")
+		Expect(os.WriteFile(path, []byte("\n{not json}\n"), 0o600)).To(Succeed())
+
+		_, err := Load(path)
+
+		Expect(err).To(MatchError(ContainSubstring("line 2")))
+	})
+}
@@ -0,0 +1,116 @@
+package genicassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Recorder is an http.RoundTripper that sends requests on through next and
+// appends each one, with its response, to a cassette file.
+type Recorder struct {
+	next http.RoundTripper
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder creates the cassette file at path, replacing any file already
+// there, and returns a Recorder writing to it.
+func NewRecorder(path string, next http.RoundTripper) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cassette: %w", err)
+	}
+	return &Recorder{next: next, file: file}, nil
+}
+
+// RoundTrip sends req and records it together with the response. A request
+// that fails without a response is not recorded.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := req
+	if requestBody != nil {
+		sent = req.Clone(req.Context())
+		sent.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	res, err := r.next.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := Interaction{
+		Request:  Request{Method: req.Method, URL: redactURL(req.URL)},
+		Response: newResponse(res.StatusCode, res.Header, responseBody),
+	}
+	if utf8.Valid(requestBody) && !strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		interaction.Request.Body = redactBody(string(requestBody))
+	}
+
+	if err := r.write(interaction); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Recorder) write(interaction Interaction) error {
+	// Query strings and JSON bodies stay readable with HTML escaping off.
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(interaction); err != nil {
+		return fmt.Errorf("error encoding cassette interaction: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return errors.New("cassette is closed")
+	}
+	if _, err := r.file.Write(line.Bytes()); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	return nil
+}
+
+// Close closes the cassette file. Requests sent afterwards fail.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// readRequestBody reads and closes the body of req, if it has one.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer func() { _ = req.Body.Close() }()
+	return io.ReadAll(req.Body)
+}
@@ -0,0 +1,133 @@
+package genicassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// maxLineSize bounds one line of a cassette, which holds a whole response.
+const maxLineSize = 64 << 20
+
+// Replayer is an http.RoundTripper that answers requests from a cassette and
+// never touches the network.
+//
+// Responses recorded for the same request are served in the order they were
+// recorded. Once they run out, a read (GET) keeps getting the last of them, as
+// reads are cached and batched differently from run to run; any other request
+// fails, since the provider did something the recording never saw.
+type Replayer struct {
+	mu        sync.Mutex
+	responses map[string][]Response
+	last      map[string]Response
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening cassette: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	r := &Replayer{
+		responses: make(map[string][]Response),
+		last:      make(map[string]Response),
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("error reading cassette %s, line %d: %w", path, line, err)
+		}
+		key, err := interactionKey(interaction.Request)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette %s, line %d: %w", path, line, err)
+		}
+		r.responses[key] = append(r.responses[key], interaction.Response)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading cassette %s: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// RoundTrip answers req with the next response recorded for it.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	recorded, err := r.next(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := recorded.body()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding recorded response: %w", err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Replayer) next(req *http.Request) (Response, error) {
+	key := matchKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if queue := r.responses[key]; len(queue) > 0 {
+		r.responses[key] = queue[1:]
+		r.last[key] = queue[0]
+		return queue[0], nil
+	}
+	if last, found := r.last[key]; found && req.Method == http.MethodGet {
+		return last, nil
+	}
+	return Response{}, fmt.Errorf("cassette has no response for %s %s", req.Method, redactURL(req.URL))
+}
+
+// Remaining returns how many recorded responses have not been replayed yet.
+// A test replaying a whole operation expects none.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remaining := 0
+	for _, queue := range r.responses {
+		remaining += len(queue)
+	}
+	return remaining
+}
+
+// interactionKey returns the matchKey of a recorded request.
+func interactionKey(request Request) (string, error) {
+	u, err := url.Parse(request.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid request URL %q: %w", request.URL, err)
+	}
+	return matchKey(request.Method, u), nil
+}
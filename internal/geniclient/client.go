@@ -0,0 +1,148 @@
+// Package geniclient builds the Geni API client the provider talks through.
+//
+// It mirrors the geni.Client façade from go-geni, accessor for accessor, but
+// constructs the shared transport itself. That is the only way to reach the
+// *http.Client underneath, and the provider needs it to put its own request
+// budget in front of every call Geni sees — resources, data sources, list
+// resources and the batch client alike. It also carries a client for Geni's
+// event endpoints, which go-geni does not cover.
+package geniclient
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+
+	"github.com/dmalch/go-geni/document"
+	"github.com/dmalch/go-geni/photo"
+	"github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/go-geni/project"
+	"github.com/dmalch/go-geni/transport"
+	"github.com/dmalch/go-geni/union"
+	"github.com/dmalch/go-geni/user"
+)
+
+// Defaults applied to any Options field left at its zero value.
+const (
+	// DefaultRequestsPerSecond leaves requests unlimited until Geni pushes
+	// back.
+	DefaultRequestsPerSecond = float64(rate.Inf)
+	DefaultBurst             = 5
+)
+
+// PushBackRequestsPerSecond is the rate an unlimited client drops to when
+// Geni first pushes back. Further push-back halves it from there.
+const PushBackRequestsPerSecond = 3.0
+
+// RequestTimeout bounds a single HTTP attempt. go-geni applies the same bound
+// to the client it builds by default; replacing that client must not lose it,
+// and other clients sending to Geni should apply it too.
+const RequestTimeout = 60 * time.Second
+
+// Options tunes the request budget.
+type Options struct {
+	// RequestsPerSecond is the steady rate requests are sent at while Geni is
+	// not pushing back. Zero leaves them unlimited until it does.
+	RequestsPerSecond float64
+	// Burst is how many requests may go out back to back after a quiet spell.
+	Burst int
+	// BaseURL, when set, sends every request to this server instead of Geni —
+	// an in-process fake in tests, say. The path of each request is kept and
+	// appended to BaseURL's own path.
+	BaseURL *url.URL
+	// Transport, when set, replaces http.DefaultTransport as the last step of
+	// every request — a cassette recorder or replayer, for instance.
+	Transport http.RoundTripper
+}
+
+func (o Options) withDefaults() Options {
+	if o.RequestsPerSecond <= 0 {
+		o.RequestsPerSecond = DefaultRequestsPerSecond
+	}
+	if o.Burst <= 0 {
+		o.Burst = DefaultBurst
+	}
+	return o
+}
+
+// Client is the provider's Geni API client. Every request made through any of
+// its resource clients passes the same adaptive rate limiter.
+type Client struct {
+	transport *transport.Client
+	limiter   *adaptiveLimiter
+	profile   *ProfileClient
+	union     *union.Client
+	document  *document.Client
+	photo     *photo.Client
+	project   *project.Client
+	user      *user.Client
+	event     *EventClient
+}
+
+// New constructs a Client. useSandboxEnv selects between sandbox.geni.com
+// (true) and www.geni.com (false).
+func New(tokenSource oauth2.TokenSource, useSandboxEnv bool, opts Options) *Client {
+	opts = opts.withDefaults()
+	limiter := newAdaptiveLimiter(opts.RequestsPerSecond, opts.Burst)
+
+	var next http.RoundTripper = http.DefaultTransport
+	if opts.Transport != nil {
+		next = opts.Transport
+	}
+	if opts.BaseURL != nil {
+		next = &rebasingTransport{base: opts.BaseURL, next: next}
+	}
+
+	t := transport.New(tokenSource, useSandboxEnv)
+	t.SetHTTPClient(&http.Client{
+		Timeout:   RequestTimeout,
+		Transport: &limitedTransport{next: next, limiter: limiter},
+	})
+
+	return &Client{
+		transport: t,
+		limiter:   limiter,
+		profile:   &ProfileClient{Client: profile.NewClient(t), transport: t},
+		union:     union.NewClient(t),
+		document:  document.NewClient(t),
+		photo:     photo.NewClient(t),
+		project:   project.NewClient(t),
+		user:      user.NewClient(t),
+		event:     &EventClient{transport: t},
+	}
+}
+
+// Limit wraps next, or http.DefaultTransport when it is nil, in the client's
+// adaptive rate limiter, so requests sent by other clients, such as the
+// website's, share the provider's budget and slow it down when Geni pushes
+// back.
+func (c *Client) Limit(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &limitedTransport{next: next, limiter: c.limiter}
+}
+
+// Profile returns the client for the Profile resource.
+func (c *Client) Profile() *ProfileClient { return c.profile }
+
+// Union returns the client for the Union resource.
+func (c *Client) Union() *union.Client { return c.union }
+
+// Document returns the client for the Document resource.
+func (c *Client) Document() *document.Client { return c.document }
+
+// Photo returns the client for the Photo resource.
+func (c *Client) Photo() *photo.Client { return c.photo }
+
+// Project returns the client for the Project resource.
+func (c *Client) Project() *project.Client { return c.project }
+
+// User returns the client for the User resource and its listings.
+func (c *Client) User() *user.Client { return c.user }
+
+// Event returns the client for profiles' own events.
+func (c *Client) Event() *EventClient { return c.event }
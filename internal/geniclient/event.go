@@ -0,0 +1,108 @@
+package geniclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/go-geni/transport"
+)
+
+// Event is one of a profile's own events: any event besides the birth,
+// baptism, death and burial Geni keeps on the profile itself, such as an
+// emigration or a census entry. Unlike those, it has an id of its own.
+type Event struct {
+	// ID is the event's node id, e.g. event-1.
+	ID string `json:"id,omitempty"`
+	profile.EventElement
+}
+
+// EventBulkResponse is the envelope Geni lists a profile's events in.
+type EventBulkResponse struct {
+	Results []Event `json:"results,omitempty"`
+}
+
+// EventClient talks to Geni's event endpoints, which go-geni has no client
+// for. Its requests go through the same transport, and so the same request
+// budget, as every other client.
+//
+// The endpoints are not in Geni's API documentation: their paths and payloads
+// follow those of the profile endpoints, and are only checked against the
+// fake in genifake. Resources use the client only when the provider opts in
+// with experimental_events.
+type EventClient struct {
+	transport *transport.Client
+}
+
+// List returns the events of the profile profileId.
+func (c *EventClient) List(ctx context.Context, profileId string) ([]Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.transport.BaseURL()+"api/"+profileId+"/events", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.transport.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var events EventBulkResponse
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, err
+	}
+	return events.Results, nil
+}
+
+// Create adds the event element to the profile profileId.
+func (c *EventClient) Create(ctx context.Context, profileId string, element *profile.EventElement) (*Event, error) {
+	return c.post(ctx, "api/"+profileId+"/add-event", element)
+}
+
+// Update replaces the fields of the event eventId with those of element.
+func (c *EventClient) Update(ctx context.Context, eventId string, element *profile.EventElement) (*Event, error) {
+	return c.post(ctx, "api/"+eventId+"/update", element)
+}
+
+// Delete deletes the event eventId.
+func (c *EventClient) Delete(ctx context.Context, eventId string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.transport.BaseURL()+"api/"+eventId+"/delete", nil)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.transport.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	var result transport.Result
+	return json.Unmarshal(body, &result)
+}
+
+func (c *EventClient) post(ctx context.Context, path string, element *profile.EventElement) (*Event, error) {
+	jsonBody, err := json.Marshal(element)
+	if err != nil {
+		return nil, err
+	}
+	// Encode the body the way go-geni encodes its own, non-ASCII text included.
+	jsonStr := transport.EscapeStringToUTF(strings.ReplaceAll(string(jsonBody), "\\\\", "\\"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.transport.BaseURL()+path, bytes.NewBufferString(jsonStr))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.transport.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
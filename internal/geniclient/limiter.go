@@ -0,0 +1,176 @@
+package geniclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minRequestsPerSecond is the floor repeated slowdowns stop at.
+	minRequestsPerSecond = 1.0 / 30
+	// slowdownCooldown keeps one episode of push-back from counting many
+	// times: the requests that were already in flight when Geni started
+	// refusing tend to come back refused together.
+	slowdownCooldown = 5 * time.Second
+	// recoveryInterval is how long the rate must go without push-back before
+	// it is raised a step back towards the configured rate.
+	recoveryInterval = 30 * time.Second
+)
+
+// incapsulaMarker identifies the page Incapsula, the DDoS protection in front
+// of Geni, serves instead of the API response when it blocks a request. It is
+// the same text go-geni looks for.
+const incapsulaMarker = "Request unsuccessful. Incapsula incident ID:"
+
+// adaptiveLimiter is a token bucket whose rate halves whenever Geni pushes
+// back and creeps back up, one doubling per recoveryInterval, once it stops.
+// An unlimited one drops to PushBackRequestsPerSecond instead of halving, and
+// is unlimited again once it recovers past that rate.
+//
+// go-geni keeps a limiter of its own and tunes it from Geni's rate headers.
+// That one paces retries of individual requests; this one is the provider's
+// overall budget, so a large apply slows down as a whole instead of every
+// request discovering the throttling by itself.
+type adaptiveLimiter struct {
+	base    rate.Limit
+	limiter *rate.Limiter
+	now     func() time.Time
+
+	mu sync.Mutex
+	// lastSlowdown is when the rate was last lowered, lastChange when it was
+	// last lowered or raised.
+	lastSlowdown time.Time
+	lastChange   time.Time
+}
+
+func newAdaptiveLimiter(requestsPerSecond float64, burst int) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		base:    rate.Limit(requestsPerSecond),
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst),
+		now:     time.Now,
+	}
+}
+
+func (l *adaptiveLimiter) wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// slowDown halves the rate, down to minRequestsPerSecond. It reports the new
+// rate and whether it changed.
+func (l *adaptiveLimiter) slowDown() (rate.Limit, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	current := l.limiter.Limit()
+	if !l.lastSlowdown.IsZero() && now.Sub(l.lastSlowdown) < slowdownCooldown {
+		return current, false
+	}
+
+	// Even at the floor, push-back restarts the recovery clock.
+	l.lastSlowdown, l.lastChange = now, now
+	next := max(current/2, rate.Limit(minRequestsPerSecond))
+	if current == rate.Inf {
+		next = PushBackRequestsPerSecond
+	}
+	if next == current {
+		return current, false
+	}
+	l.limiter.SetLimitAt(now, next)
+	return next, true
+}
+
+// speedUp doubles the rate, up to the configured one, if it has been lowered
+// and recoveryInterval has passed since the last change. It reports the new
+// rate and whether it changed.
+func (l *adaptiveLimiter) speedUp() (rate.Limit, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	current := l.limiter.Limit()
+	if current >= l.base || now.Sub(l.lastChange) < recoveryInterval {
+		return current, false
+	}
+
+	next := min(current*2, l.base)
+	if l.base == rate.Inf && next > PushBackRequestsPerSecond {
+		next = rate.Inf
+	}
+	l.lastChange = now
+	l.limiter.SetLimitAt(now, next)
+	return next, true
+}
+
+// limitedTransport makes every request wait for the adaptive limiter and
+// feeds the responses back into it. It sees each attempt go-geni makes,
+// retries included, which is what lets a retry storm slow itself down.
+type limitedTransport struct {
+	next    http.RoundTripper
+	limiter *adaptiveLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := t.limiter.wait(ctx); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		if limit, changed := t.limiter.speedUp(); changed {
+			tflog.Info(ctx, "Geni stopped pushing back, raising the request rate", map[string]any{
+				"requests_per_second": float64(limit),
+			})
+		}
+		return res, nil
+	}
+
+	pushedBack, err := isPushBack(res)
+	if err != nil {
+		return nil, err
+	}
+	if pushedBack {
+		if limit, changed := t.limiter.slowDown(); changed {
+			tflog.Warn(ctx, "Geni is throttling requests, lowering the request rate", map[string]any{
+				"status":              res.StatusCode,
+				"requests_per_second": float64(limit),
+			})
+		}
+	}
+	return res, nil
+}
+
+// isPushBack reports whether a non-2xx response is Geni refusing to serve the
+// request rate: a 429, or an Incapsula block page. Telling the latter apart
+// needs the body, which is read here and put back for go-geni to classify
+// in turn.
+func isPushBack(res *http.Response) (bool, error) {
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return false, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return bytes.Contains(body, []byte(incapsulaMarker)), nil
+}
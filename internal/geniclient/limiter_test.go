@@ -0,0 +1,251 @@
+package geniclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
+)
+
+// newTestLimiter returns a limiter whose clock the test moves by hand.
+func newTestLimiter(requestsPerSecond float64) (*adaptiveLimiter, *time.Time) {
+	l := newAdaptiveLimiter(requestsPerSecond, 1)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	t.Run("Push-back halves the rate", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, _ := newTestLimiter(4)
+
+		limit, changed := l.slowDown()
+
+		Expect(changed).To(BeTrue())
+		Expect(limit).To(Equal(rate.Limit(2)))
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(2)))
+	})
+
+	t.Run("Push-back within the cooldown counts once", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(4)
+
+		l.slowDown()
+		*now = now.Add(slowdownCooldown / 2)
+		_, changed := l.slowDown()
+
+		Expect(changed).To(BeFalse())
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(2)))
+
+		*now = now.Add(slowdownCooldown)
+		_, changed = l.slowDown()
+
+		Expect(changed).To(BeTrue())
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(1)))
+	})
+
+	t.Run("The rate never drops below the floor", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(4)
+
+		for range 20 {
+			l.slowDown()
+			*now = now.Add(slowdownCooldown)
+		}
+
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(minRequestsPerSecond)))
+	})
+
+	t.Run("The rate recovers one doubling per interval up to the configured rate", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(4)
+		l.slowDown()
+		*now = now.Add(slowdownCooldown)
+		l.slowDown()
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(1)))
+
+		_, changed := l.speedUp()
+		Expect(changed).To(BeFalse(), "too soon after the slowdown")
+
+		*now = now.Add(recoveryInterval)
+		l.speedUp()
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(2)))
+
+		l.speedUp()
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(2)), "too soon after the last step")
+
+		*now = now.Add(recoveryInterval)
+		l.speedUp()
+		*now = now.Add(recoveryInterval)
+		_, changed = l.speedUp()
+		Expect(changed).To(BeFalse())
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(4)))
+	})
+
+	t.Run("An unlimited rate drops to the push-back rate and halves from there", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(DefaultRequestsPerSecond)
+		Expect(l.limiter.Limit()).To(Equal(rate.Inf))
+
+		limit, changed := l.slowDown()
+		Expect(changed).To(BeTrue())
+		Expect(limit).To(Equal(rate.Limit(PushBackRequestsPerSecond)))
+
+		*now = now.Add(slowdownCooldown)
+		l.slowDown()
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(PushBackRequestsPerSecond / 2)))
+	})
+
+	t.Run("An unlimited rate is lifted again once it recovers past the push-back rate", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(DefaultRequestsPerSecond)
+		l.slowDown()
+		*now = now.Add(slowdownCooldown)
+		l.slowDown()
+
+		*now = now.Add(recoveryInterval)
+		l.speedUp()
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(PushBackRequestsPerSecond)))
+
+		*now = now.Add(recoveryInterval)
+		limit, changed := l.speedUp()
+		Expect(changed).To(BeTrue())
+		Expect(limit).To(Equal(rate.Inf))
+	})
+}
+
+func TestLimitedTransport(t *testing.T) {
+	// serve answers every request with status and body.
+	serve := func(t *testing.T, status int, body string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, body)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	get := func(t *testing.T, client *http.Client, url string) (int, string) {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+		Expect(err).ToNot(HaveOccurred())
+		res, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() { _ = res.Body.Close() }()
+		body, err := io.ReadAll(res.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return res.StatusCode, string(body)
+	}
+
+	newClient := func(l *adaptiveLimiter) *http.Client {
+		return &http.Client{Transport: &limitedTransport{next: http.DefaultTransport, limiter: l}}
+	}
+
+	t.Run("A 429 slows the limiter down", func(t *testing.T) {
+		RegisterTestingT(t)
+		l := newAdaptiveLimiter(100, 10)
+		server := serve(t, http.StatusTooManyRequests, "")
+
+		status, _ := get(t, newClient(l), server.URL)
+
+		Expect(status).To(Equal(http.StatusTooManyRequests))
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(50)))
+	})
+
+	t.Run("An Incapsula block slows the limiter down and keeps its body", func(t *testing.T) {
+		RegisterTestingT(t)
+		l := newAdaptiveLimiter(100, 10)
+		page := "<html>Request unsuccessful. Incapsula incident ID: 123-456</html>"
+		server := serve(t, http.StatusForbidden, page)
+
+		status, body := get(t, newClient(l), server.URL)
+
+		Expect(status).To(Equal(http.StatusForbidden))
+		Expect(body).To(Equal(page))
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(50)))
+	})
+
+	t.Run("Other errors leave the rate alone", func(t *testing.T) {
+		RegisterTestingT(t)
+		l := newAdaptiveLimiter(100, 10)
+		server := serve(t, http.StatusNotFound, `{"error":"not found"}`)
+
+		status, body := get(t, newClient(l), server.URL)
+
+		Expect(status).To(Equal(http.StatusNotFound))
+		Expect(body).To(Equal(`{"error":"not found"}`))
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(100)))
+	})
+
+	t.Run("A success after the recovery interval raises the rate", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(100)
+		l.limiter.SetBurst(10)
+		l.slowDown()
+		*now = now.Add(recoveryInterval)
+		server := serve(t, http.StatusOK, "{}")
+
+		get(t, newClient(l), server.URL)
+
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(100)))
+	})
+
+	t.Run("Any 2xx after the recovery interval raises the rate", func(t *testing.T) {
+		RegisterTestingT(t)
+		l, now := newTestLimiter(100)
+		l.limiter.SetBurst(10)
+		l.slowDown()
+		*now = now.Add(recoveryInterval)
+		server := serve(t, http.StatusNoContent, "")
+
+		get(t, newClient(l), server.URL)
+
+		Expect(l.limiter.Limit()).To(Equal(rate.Limit(100)))
+	})
+
+	t.Run("Transports wrapped by Limit share the client's limiter", func(t *testing.T) {
+		RegisterTestingT(t)
+		client := New(nil, true, Options{RequestsPerSecond: 100, Burst: 10})
+		server := serve(t, http.StatusTooManyRequests, "")
+
+		get(t, &http.Client{Transport: client.Limit(nil)}, server.URL)
+
+		Expect(client.limiter.limiter.Limit()).To(Equal(rate.Limit(50)))
+	})
+
+	t.Run("Waiting for the limiter honours the request context", func(t *testing.T) {
+		RegisterTestingT(t)
+		l := newAdaptiveLimiter(0.1, 1)
+		Expect(l.limiter.Allow()).To(BeTrue(), "drain the only token")
+		server := serve(t, http.StatusOK, "{}")
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		start := time.Now()
+		_, err = newClient(l).Do(req)
+
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("Zero fields fall back to the defaults", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(Options{}.withDefaults()).To(Equal(Options{RequestsPerSecond: DefaultRequestsPerSecond, Burst: DefaultBurst}))
+	})
+
+	t.Run("Set fields are kept", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(Options{RequestsPerSecond: 0.5, Burst: 1}.withDefaults()).To(Equal(Options{RequestsPerSecond: 0.5, Burst: 1}))
+	})
+}
@@ -0,0 +1,146 @@
+package geniclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/go-geni/transport"
+)
+
+// profileFields are the fields ProfileClient asks Geni for: go-geni's own,
+// and those of Profile that go-geni leaves out. Geni only returns the fields
+// a request names.
+const profileFields = profile.FieldsQueryValue + ",locked,language,master_profile"
+
+// Profile is go-geni's profile with the fields it does not decode.
+type Profile struct {
+	profile.Profile
+	// MasterProfile is whether a curator has made the profile the master
+	// profile, the one Geni keeps for the person among its duplicates.
+	MasterProfile bool `json:"master_profile,omitempty"`
+}
+
+// ProfileBulkResponse is the envelope Geni lists profiles in.
+type ProfileBulkResponse struct {
+	Results []Profile `json:"results,omitempty"`
+}
+
+// ProfileRequest is go-geni's profile request with the fields it cannot
+// leave out made optional, so that a request only changes them when set.
+type ProfileRequest struct {
+	profile.Request
+	// Locked is whether the profile is locked down by a curator. It replaces
+	// profile.Request.Locked, which is always sent and so unlocks the profile
+	// unless set.
+	Locked *bool `json:"locked,omitempty"`
+	// Language is the profile's language.
+	Language *string `json:"language,omitempty"`
+}
+
+// ProfileClient is go-geni's profile client with its reads and writes of
+// whole profiles replaced by ones that use Profile and ProfileRequest. Its
+// other endpoints are go-geni's.
+type ProfileClient struct {
+	*profile.Client
+	transport *transport.Client
+}
+
+// Get fetches the profile profileId.
+func (c *ProfileClient) Get(ctx context.Context, profileId string) (*Profile, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "api/"+profileId, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+// GetBulk fetches the profiles profileIds in one request. Geni leaves the
+// ones it cannot find out of the results.
+func (c *ProfileClient) GetBulk(ctx context.Context, profileIds []string) (*ProfileBulkResponse, error) {
+	// Geni's bulk endpoint returns nothing for a single id.
+	if len(profileIds) == 1 {
+		one, err := c.Get(ctx, profileIds[0])
+		if err != nil {
+			return nil, err
+		}
+		return &ProfileBulkResponse{Results: []Profile{*one}}, nil
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "api/profile", nil)
+	if err != nil {
+		return nil, err
+	}
+	query := req.URL.Query()
+	query.Set("ids", strings.Join(profileIds, ","))
+	req.URL.RawQuery = query.Encode()
+
+	body, err := c.transport.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles ProfileBulkResponse
+	if err := json.Unmarshal(body, &profiles); err != nil {
+		return nil, err
+	}
+	for i := range profiles.Results {
+		profile.StripURLs(&profiles.Results[i].Profile, c.transport.APIURL())
+	}
+	return &profiles, nil
+}
+
+// Create creates a profile from request.
+func (c *ProfileClient) Create(ctx context.Context, request *ProfileRequest) (*Profile, error) {
+	return c.post(ctx, "api/profile/add", request)
+}
+
+// Update sets the fields of the profile profileId to those of request.
+func (c *ProfileClient) Update(ctx context.Context, profileId string, request *ProfileRequest) (*Profile, error) {
+	return c.post(ctx, "api/"+profileId+"/update", request)
+}
+
+func (c *ProfileClient) post(ctx context.Context, path string, request *ProfileRequest) (*Profile, error) {
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	// Encode the body the way go-geni encodes its own, non-ASCII text included.
+	jsonStr := transport.EscapeStringToUTF(strings.ReplaceAll(string(jsonBody), "\\\\", "\\"))
+
+	req, err := c.newRequest(ctx, http.MethodPost, path, bytes.NewBufferString(jsonStr))
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+// newRequest returns a request to path that asks for profileFields.
+func (c *ProfileClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.transport.BaseURL()+path, body)
+	if err != nil {
+		return nil, err
+	}
+	query := req.URL.Query()
+	query.Set("fields", profileFields)
+	req.URL.RawQuery = query.Encode()
+	return req, nil
+}
+
+func (c *ProfileClient) do(ctx context.Context, req *http.Request) (*Profile, error) {
+	body, err := c.transport.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Profile
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+	profile.StripURLs(&p.Profile, c.transport.APIURL())
+	return &p, nil
+}
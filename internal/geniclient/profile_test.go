@@ -0,0 +1,80 @@
+package geniclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	"github.com/dmalch/go-geni/profile"
+)
+
+func TestProfileClient(t *testing.T) {
+	// serve answers every request with body and records the fields and the
+	// body of each request.
+	serve := func(fields, bodies *[]string, body string) *Client {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sent, _ := io.ReadAll(r.Body)
+			*fields = append(*fields, r.URL.Query().Get("fields"))
+			*bodies = append(*bodies, string(sent))
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		base, _ := url.Parse(server.URL)
+		return New(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), true, Options{BaseURL: base})
+	}
+
+	t.Run("Profile reads ask for and decode the lock, the language and the master profile", func(t *testing.T) {
+		RegisterTestingT(t)
+		var fields, bodies []string
+		client := serve(&fields, &bodies, `{"id":"profile-1","locked":true,"language":"ru","master_profile":true}`)
+
+		read, err := client.Profile().Get(context.Background(), "profile-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fields).To(Equal([]string{profile.FieldsQueryValue + ",locked,language,master_profile"}))
+		Expect(read.ID).To(Equal("profile-1"))
+		Expect(read.Locked).To(BeTrue())
+		Expect(read.Language).To(Equal("ru"))
+		Expect(read.MasterProfile).To(BeTrue())
+	})
+
+	t.Run("Bulk reads decode the master profile of each profile", func(t *testing.T) {
+		RegisterTestingT(t)
+		var fields, bodies []string
+		client := serve(&fields, &bodies, `{"results":[{"id":"profile-1","master_profile":true},{"id":"profile-2"}]}`)
+
+		read, err := client.Profile().GetBulk(context.Background(), []string{"profile-1", "profile-2"})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fields).To(Equal([]string{profileFields}))
+		Expect(read.Results).To(HaveLen(2))
+		Expect(read.Results[0].MasterProfile).To(BeTrue())
+		Expect(read.Results[1].MasterProfile).To(BeFalse())
+	})
+
+	t.Run("The lock and the language are only sent when set", func(t *testing.T) {
+		RegisterTestingT(t)
+		var fields, bodies []string
+		client := serve(&fields, &bodies, `{"id":"profile-1"}`)
+		request := &ProfileRequest{Request: profile.Request{Public: true}}
+
+		_, err := client.Profile().Update(context.Background(), "profile-1", request)
+		Expect(err).ToNot(HaveOccurred())
+		request.Locked, request.Language = new(false), new("ru")
+		_, err = client.Profile().Update(context.Background(), "profile-1", request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(bodies).To(HaveLen(2))
+		Expect(bodies[0]).To(ContainSubstring(`"public":true`))
+		Expect(bodies[0]).ToNot(ContainSubstring(`"locked"`))
+		Expect(bodies[0]).ToNot(ContainSubstring(`"language"`))
+		Expect(bodies[1]).To(ContainSubstring(`"locked":false`))
+		Expect(bodies[1]).To(ContainSubstring(`"language":"ru"`))
+	})
+}
@@ -0,0 +1,26 @@
+package geniclient
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// rebasingTransport redirects requests that go-geni addressed to Geni to
+// another server. go-geni derives its URLs from the sandbox flag alone, so
+// this is the one place a different host can be slotted in.
+type rebasingTransport struct {
+	base *url.URL
+	next http.RoundTripper
+}
+
+func (t *rebasingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the request it was given.
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.base.Scheme
+	req.URL.Host = t.base.Host
+	req.URL.Path = strings.TrimSuffix(t.base.Path, "/") + req.URL.Path
+	req.URL.RawPath = ""
+	req.Host = ""
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,51 @@
+package geniclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+)
+
+func TestRebasingTransport(t *testing.T) {
+	// serve records the path of every request it answers.
+	serve := func(paths *[]string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*paths = append(*paths, r.URL.Path)
+			_, _ = w.Write([]byte(`{"id":"profile-1"}`))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("Requests go to the base URL instead of Geni", func(t *testing.T) {
+		RegisterTestingT(t)
+		var paths []string
+		server := serve(&paths)
+		base, _ := url.Parse(server.URL)
+
+		client := New(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), true, Options{BaseURL: base})
+		profile, err := client.Profile().Get(context.Background(), "profile-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(profile.ID).To(Equal("profile-1"))
+		Expect(paths).To(Equal([]string{"/api/profile-1"}))
+	})
+
+	t.Run("The base URL's path prefixes the request path", func(t *testing.T) {
+		RegisterTestingT(t)
+		var paths []string
+		server := serve(&paths)
+		base, _ := url.Parse(server.URL + "/geni/")
+
+		client := New(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), false, Options{BaseURL: base})
+		_, err := client.Profile().Get(context.Background(), "profile-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(paths).To(Equal([]string{"/geni/api/profile-1"}))
+	})
+}
@@ -0,0 +1,123 @@
+package genifake
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	genidocument "github.com/dmalch/go-geni/document"
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+func (s *Server) document(id string) (*genidocument.Document, error) {
+	document, found := s.documents[id]
+	if !found {
+		return nil, notFound(id)
+	}
+	return document, nil
+}
+
+// createDocument answers POST /api/document/add. Like Geni it derives the
+// content type from the kind of content when the request does not set one.
+func (s *Server) createDocument(r *http.Request) (any, error) {
+	var request genidocument.Request
+	if err := decodeBody(r, &request); err != nil {
+		return nil, err
+	}
+
+	contentType := request.ContentType
+	if contentType == nil {
+		switch {
+		case request.Text != nil:
+			contentType = new("text/plain")
+		case request.SourceUrl != nil:
+			contentType = new("text/html")
+		default:
+			contentType = new("application/octet-stream")
+		}
+	}
+
+	document := &genidocument.Document{
+		ID:          s.newID("document"),
+		Title:       request.Title,
+		Description: request.Description,
+		SourceUrl:   request.SourceUrl,
+		ContentType: contentType,
+		Date:        request.Date,
+		Location:    request.Location,
+		Tags:        []string{},
+		Labels:      labels(request.Labels),
+		CreatedAt:   s.timestamp(),
+		UpdatedAt:   s.timestamp(),
+	}
+	s.documents[document.ID] = document
+	return document, nil
+}
+
+// actOnDocument answers POST /api/<documentId>/<action>.
+func (s *Server) actOnDocument(id, action string, r *http.Request) (any, error) {
+	document, err := s.document(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case "update":
+		var request genidocument.Request
+		if err := decodeBody(r, &request); err != nil {
+			return nil, err
+		}
+		if request.Title != "" {
+			document.Title = request.Title
+		}
+		if request.Description != nil {
+			document.Description = request.Description
+		}
+		if request.Date != nil {
+			document.Date = request.Date
+		}
+		if request.Location != nil {
+			document.Location = request.Location
+		}
+		if request.Labels != nil {
+			document.Labels = labels(request.Labels)
+		}
+		document.UpdatedAt = s.timestamp()
+		return document, nil
+
+	case "delete":
+		delete(s.documents, id)
+		return resultOK, nil
+	}
+
+	return nil, badRequest("unknown document action %q", action)
+}
+
+// tagDocument tags or untags profile on a document and answers with the
+// profiles the document is tagged with afterwards.
+func (s *Server) tagDocument(id string, profile *geniprofile.Profile, tag bool) (any, error) {
+	document, err := s.document(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag {
+		document.Tags = appendMissing(document.Tags, profile.ID)
+	} else {
+		document.Tags = slices.DeleteFunc(document.Tags, func(tagged string) bool { return tagged == profile.ID })
+	}
+
+	var response geniprofile.BulkResponse
+	for _, tagged := range document.Tags {
+		response.Results = append(response.Results, *s.profiles[tagged])
+	}
+	return response, nil
+}
+
+// labels splits the comma-separated labels of a document request.
+func labels(joined *string) []string {
+	if joined == nil || *joined == "" {
+		return []string{}
+	}
+	return strings.Split(*joined, ",")
+}
@@ -0,0 +1,180 @@
+package genifake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+// mergeEvent deep-merges an event from a request body into *event, the way
+// Geni's update endpoints do: a date or location field sent as null is left
+// alone, and the only way to clear one is to send its whole sub-object as {}.
+// The profile endpoints also clear a sub-object sent as null; the union
+// endpoint ignores that, and nullClears says which behaviour applies.
+func mergeEvent(event **geniprofile.EventElement, raw json.RawMessage, nullClears bool) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		return nil
+	}
+
+	merged := &geniprofile.EventElement{}
+	if *event != nil {
+		copied := **event
+		merged = &copied
+	}
+
+	for key, value := range fields {
+		cleared := isEmptyObject(value) || (nullClears && isNull(value))
+		var err error
+		switch key {
+		case "date":
+			switch {
+			case cleared:
+				merged.Date = nil
+			case !isNull(value):
+				var date geniprofile.DateElement
+				if err = json.Unmarshal(value, &date); err == nil {
+					merged.Date = overlay(merged.Date, &date)
+				}
+			}
+		case "location":
+			switch {
+			case cleared:
+				merged.Location = nil
+			case !isNull(value):
+				var location geniprofile.LocationElement
+				if err = json.Unmarshal(value, &location); err == nil {
+					merged.Location = overlay(merged.Location, &location)
+				}
+			}
+		case "name":
+			err = json.Unmarshal(value, &merged.Name)
+		case "description":
+			err = json.Unmarshal(value, &merged.Description)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if merged.Date == nil && merged.Location == nil && merged.Name == "" && merged.Description == nil {
+		merged = nil
+	}
+	*event = merged
+	return nil
+}
+
+// overlay returns a copy of base with every non-nil field of patch written
+// over it. T is one of go-geni's all-pointer wire structs.
+func overlay[T any](base, patch *T) *T {
+	var merged T
+	if base != nil {
+		merged = *base
+	}
+	target := reflect.ValueOf(&merged).Elem()
+	source := reflect.ValueOf(patch).Elem()
+	for i := range source.NumField() {
+		if field := source.Field(i); !field.IsNil() {
+			target.Field(i).Set(field)
+		}
+	}
+	return &merged
+}
+
+func isNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+func isEmptyObject(raw json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	return json.Unmarshal(raw, &fields) == nil && fields != nil && len(fields) == 0
+}
+
+// profileEvent is an event a profile has besides its birth, baptism, death
+// and burial, which Geni addresses by an id of its own.
+type profileEvent struct {
+	ID string `json:"id"`
+	geniprofile.EventElement
+	profileID string
+}
+
+// eventList is the envelope Geni lists a profile's events in.
+type eventList struct {
+	Results []profileEvent `json:"results"`
+}
+
+// listEvents answers GET /api/<profileId>/events.
+func (s *Server) listEvents(profileID string) (any, error) {
+	profile, err := s.profile(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := eventList{Results: []profileEvent{}}
+	for _, id := range s.order {
+		if event, found := s.events[id]; found && event.profileID == profile.ID {
+			response.Results = append(response.Results, *event)
+		}
+	}
+	return response, nil
+}
+
+// createEvent answers POST /api/<profileId>/add-event.
+func (s *Server) createEvent(profile *geniprofile.Profile, r *http.Request) (any, error) {
+	event := &profileEvent{profileID: profile.ID}
+	if err := s.writeEvent(event, r); err != nil {
+		return nil, err
+	}
+	event.ID = s.newID("event")
+	s.events[event.ID] = event
+	return event, nil
+}
+
+// actOnEvent answers POST /api/<eventId>/<action>. Events of deleted profiles
+// are gone with them.
+func (s *Server) actOnEvent(id, action string, r *http.Request) (any, error) {
+	event, found := s.events[id]
+	if !found {
+		return nil, notFound(id)
+	}
+
+	switch action {
+	case "update":
+		if err := s.writeEvent(event, r); err != nil {
+			return nil, err
+		}
+		return event, nil
+
+	case "delete":
+		delete(s.events, id)
+		return resultOK, nil
+	}
+
+	return nil, badRequest("unknown event action %q", action)
+}
+
+// writeEvent merges the event in the request body into event, the way the
+// profile endpoints merge the birth and the other events of a profile. An
+// event must keep a name.
+func (s *Server) writeEvent(event *profileEvent, r *http.Request) error {
+	var raw json.RawMessage
+	if err := decodeBody(r, &raw); err != nil {
+		return err
+	}
+
+	merged := &event.EventElement
+	if err := mergeEvent(&merged, raw, true); err != nil {
+		return badRequest("invalid event: %v", err)
+	}
+	if merged == nil || merged.Name == "" {
+		return badRequest("an event needs a name")
+	}
+	event.EventElement = *merged
+	return nil
+}
@@ -0,0 +1,105 @@
+package genifake
+
+import (
+	"io"
+	"net/http"
+	"slices"
+
+	geniphoto "github.com/dmalch/go-geni/photo"
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+// maxUploadSize bounds the multipart body of a photo upload held in memory.
+const maxUploadSize = 32 << 20
+
+func (s *Server) photo(id string) (*geniphoto.Photo, error) {
+	photo, found := s.photos[id]
+	if !found {
+		return nil, notFound(id)
+	}
+	return photo, nil
+}
+
+// createPhoto answers the multipart POST /api/photo/add. The content type is
+// sniffed from the uploaded bytes, which are then discarded.
+func (s *Server) createPhoto(r *http.Request) (any, error) {
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		return nil, badRequest("invalid upload: %v", err)
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, badRequest("invalid upload: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, badRequest("invalid upload: %v", err)
+	}
+
+	id := s.newID("photo")
+	url := s.server.URL + "/photos/" + id
+	photo := &geniphoto.Photo{
+		ID:          id,
+		Guid:        id,
+		AlbumId:     r.FormValue("album_id"),
+		Title:       r.FormValue("title"),
+		Description: r.FormValue("description"),
+		Date:        r.FormValue("date"),
+		ContentType: http.DetectContentType(content),
+		Sizes:       map[string]string{"original": url, "medium": url + "?size=medium"},
+		Url:         url,
+		CreatedAt:   s.timestamp(),
+		UpdatedAt:   s.timestamp(),
+	}
+	s.photos[id] = photo
+	return photo, nil
+}
+
+// actOnPhoto answers POST /api/<photoId>/<action>.
+func (s *Server) actOnPhoto(id, action string, r *http.Request) (any, error) {
+	photo, err := s.photo(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case "update":
+		var request geniphoto.Request
+		if err := decodeBody(r, &request); err != nil {
+			return nil, err
+		}
+		// The request omits empty fields, so an empty value means "unchanged".
+		if request.Title != "" {
+			photo.Title = request.Title
+		}
+		if request.Description != "" {
+			photo.Description = request.Description
+		}
+		if request.Date != "" {
+			photo.Date = request.Date
+		}
+		photo.UpdatedAt = s.timestamp()
+		return photo, nil
+
+	case "delete":
+		delete(s.photos, id)
+		return resultOK, nil
+	}
+
+	return nil, badRequest("unknown photo action %q", action)
+}
+
+// tagPhoto tags or untags profile on a photo and answers with the photo.
+func (s *Server) tagPhoto(id string, profile *geniprofile.Profile, tag bool) (any, error) {
+	photo, err := s.photo(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag {
+		photo.Tags = appendMissing(photo.Tags, profile.ID)
+	} else {
+		photo.Tags = slices.DeleteFunc(photo.Tags, func(tagged string) bool { return tagged == profile.ID })
+	}
+	return photo, nil
+}
@@ -0,0 +1,353 @@
+package genifake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+)
+
+// profile returns the profile with the given id, or with the given guid when
+// id has the "profile-g<guid>" form. Deleted profiles are returned too, flagged
+// as such, as Geni does.
+func (s *Server) profile(id string) (*geniprofile.Profile, error) {
+	if guid, byGuid := strings.CutPrefix(id, "profile-g"); byGuid {
+		id = s.guids[guid]
+	}
+	profile, found := s.profiles[id]
+	if !found {
+		return nil, notFound(id)
+	}
+	return profile, nil
+}
+
+// liveProfile returns the profile with the given id if it can still be
+// changed. Geni denies any change to a deleted or merged profile.
+func (s *Server) liveProfile(id string) (*geniprofile.Profile, error) {
+	profile, err := s.profile(id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Deleted {
+		return nil, accessDenied(id)
+	}
+	return profile, nil
+}
+
+// newProfile stores an empty profile and returns it.
+func (s *Server) newProfile() *geniprofile.Profile {
+	id := s.newID("profile")
+	guid := strconv.Itoa(6000000000000 + s.lastID)
+	profile := &geniprofile.Profile{
+		ID:        id,
+		Guid:      guid,
+		CreatedAt: s.timestamp(),
+		UpdatedAt: s.timestamp(),
+	}
+	s.profiles[id] = profile
+	s.guids[guid] = id
+	return profile
+}
+
+// createProfile answers POST /api/profile/add.
+func (s *Server) createProfile(r *http.Request) (any, error) {
+	var fields map[string]json.RawMessage
+	if err := decodeBody(r, &fields); err != nil {
+		return nil, err
+	}
+
+	profile := s.newProfile()
+	if err := applyProfileFields(profile, fields); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// actOnProfile answers POST /api/<profileId>/<action>.
+func (s *Server) actOnProfile(id, action string, r *http.Request) (any, error) {
+	profile, err := s.liveProfile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case "update", "update-basics":
+		var fields map[string]json.RawMessage
+		if err := decodeBody(r, &fields); err != nil {
+			return nil, err
+		}
+		if err := applyProfileFields(profile, fields); err != nil {
+			return nil, err
+		}
+		profile.UpdatedAt = s.timestamp()
+		return profile, nil
+
+	case "delete":
+		s.deleteProfile(profile)
+		return resultOK, nil
+
+	case "add-partner":
+		union := s.newUnion()
+		s.addPartner(union, profile)
+		partner := s.newProfile()
+		s.addPartner(union, partner)
+		return partner, nil
+
+	case "add-child":
+		modifier := r.URL.Query().Get("relationship_modifier")
+		if err := checkModifier(modifier); err != nil {
+			return nil, err
+		}
+		union := s.familyAsPartner(profile)
+		if union == nil {
+			union = s.newUnion()
+			s.addPartner(union, profile)
+		}
+		child := s.newProfile()
+		s.addChild(union, child, modifier)
+		return child, nil
+
+	case "add-sibling":
+		modifier := r.URL.Query().Get("relationship_modifier")
+		if err := checkModifier(modifier); err != nil {
+			return nil, err
+		}
+		union := s.familyAsChild(profile)
+		if union == nil {
+			union = s.newUnion()
+			s.addChild(union, profile, "")
+		}
+		sibling := s.newProfile()
+		s.addChild(union, sibling, modifier)
+		return sibling, nil
+
+	case "add-parent":
+		modifier := r.URL.Query().Get("relationship_modifier")
+		if err := checkModifier(modifier); err != nil {
+			return nil, err
+		}
+		var fields map[string]json.RawMessage
+		if err := decodeBody(r, &fields); err != nil {
+			return nil, err
+		}
+		parent := s.newProfile()
+		if err := applyProfileFields(parent, fields); err != nil {
+			return nil, err
+		}
+		union := s.familyAsChild(profile)
+		if union == nil || len(union.Partners) >= 2 {
+			union = s.newUnion()
+			s.addChild(union, profile, modifier)
+		}
+		s.addPartner(union, parent)
+		return parent, nil
+
+	case "add-event":
+		return s.createEvent(profile, r)
+
+	case "follow", "unfollow":
+		return profile, nil
+	}
+
+	return nil, badRequest("unknown profile action %q", action)
+}
+
+// merge answers POST /api/<survivorId>/merge/<mergedId>: the merged profile's
+// relationships and events move onto the survivor, its names and gender fill
+// in those the survivor lacks, and the merged profile is deleted, pointing at
+// the survivor.
+func (s *Server) merge(survivorID, mergedID string) (any, error) {
+	survivor, err := s.liveProfile(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := s.liveProfile(mergedID)
+	if err != nil {
+		return nil, err
+	}
+	if survivor == merged {
+		return nil, badRequest("cannot merge %s into itself", survivorID)
+	}
+
+	for _, unionID := range merged.Unions {
+		union := s.unions[unionID]
+		replaceMember(union, merged.ID, survivor.ID)
+		survivor.Unions = appendMissing(survivor.Unions, unionID)
+	}
+	for _, projectID := range merged.Projects {
+		survivor.Projects = appendMissing(survivor.Projects, projectID)
+	}
+	for _, event := range s.events {
+		if event.profileID == merged.ID {
+			event.profileID = survivor.ID
+		}
+	}
+	for locale, name := range merged.Names {
+		if _, ok := survivor.Names[locale]; ok {
+			continue
+		}
+		if survivor.Names == nil {
+			survivor.Names = make(map[string]geniprofile.NameElement, len(merged.Names))
+		}
+		survivor.Names[locale] = name
+	}
+	if survivor.Gender == nil {
+		survivor.Gender = merged.Gender
+	}
+
+	merged.Unions = nil
+	merged.Deleted = true
+	merged.MergedInto = survivor.ID
+	survivor.UpdatedAt = s.timestamp()
+
+	s.mergeDuplicateUnions(survivor)
+	return resultOK, nil
+}
+
+// deleteProfile flags profile deleted, deletes its events and removes it from
+// its unions and from every document and photo it is tagged in.
+func (s *Server) deleteProfile(profile *geniprofile.Profile) {
+	for _, unionID := range profile.Unions {
+		removeMember(s.unions[unionID], profile.ID)
+	}
+	for _, document := range s.documents {
+		document.Tags = slices.DeleteFunc(document.Tags, func(id string) bool { return id == profile.ID })
+	}
+	for _, photo := range s.photos {
+		photo.Tags = slices.DeleteFunc(photo.Tags, func(id string) bool { return id == profile.ID })
+	}
+	for id, event := range s.events {
+		if event.profileID == profile.ID {
+			delete(s.events, id)
+		}
+	}
+	profile.Unions = nil
+	profile.Deleted = true
+	profile.UpdatedAt = s.timestamp()
+}
+
+// familyAsPartner returns the union a new child of profile joins: its only
+// union as a partner. With none, or several to choose between, it returns nil
+// and the child gets a union of its own.
+func (s *Server) familyAsPartner(profile *geniprofile.Profile) *geniunion.Union {
+	var family *geniunion.Union
+	for _, unionID := range profile.Unions {
+		if union := s.unions[unionID]; slices.Contains(union.Partners, profile.ID) {
+			if family != nil {
+				return nil
+			}
+			family = union
+		}
+	}
+	return family
+}
+
+// familyAsChild returns the union profile is a child in, or nil if it has no
+// parents yet.
+func (s *Server) familyAsChild(profile *geniprofile.Profile) *geniunion.Union {
+	for _, unionID := range profile.Unions {
+		if union := s.unions[unionID]; slices.Contains(union.Children, profile.ID) {
+			return union
+		}
+	}
+	return nil
+}
+
+// checkModifier rejects relationship modifiers Geni does not know.
+func checkModifier(modifier string) error {
+	switch modifier {
+	case "", "adopt", "foster":
+		return nil
+	}
+	return badRequest("unknown relationship_modifier %q", modifier)
+}
+
+// applyProfileFields applies a profile-create or -update body to profile. Only
+// the keys present in the body change anything: events are deep-merged, so a
+// date or location sub-object is cleared by sending it as null or {}, and
+// detail strings are merged per locale, so a locale is removed by sending it
+// with no about_me.
+func applyProfileFields(profile *geniprofile.Profile, fields map[string]json.RawMessage) error {
+	var err error
+	for key, raw := range fields {
+		switch key {
+		case "names":
+			var names map[string]geniprofile.NameElement
+			if err = json.Unmarshal(raw, &names); err == nil && len(names) > 0 {
+				if profile.Names == nil {
+					profile.Names = make(map[string]geniprofile.NameElement, len(names))
+				}
+				for locale, name := range names {
+					profile.Names[locale] = name
+				}
+			}
+		case "gender":
+			var gender *string
+			if err = json.Unmarshal(raw, &gender); err == nil && gender != nil {
+				profile.Gender = gender
+			}
+		case "display_name":
+			err = json.Unmarshal(raw, &profile.DisplayName)
+		case "nicknames":
+			err = json.Unmarshal(raw, &profile.Nicknames)
+		case "birth":
+			err = mergeEvent(&profile.Birth, raw, true)
+		case "baptism":
+			err = mergeEvent(&profile.Baptism, raw, true)
+		case "death":
+			err = mergeEvent(&profile.Death, raw, true)
+		case "burial":
+			err = mergeEvent(&profile.Burial, raw, true)
+		case "cause_of_death":
+			err = json.Unmarshal(raw, &profile.CauseOfDeath)
+		case "is_alive":
+			err = json.Unmarshal(raw, &profile.IsAlive)
+		case "title":
+			err = json.Unmarshal(raw, &profile.Title)
+		case "occupation":
+			err = json.Unmarshal(raw, &profile.Occupation)
+		case "suffix":
+			err = json.Unmarshal(raw, &profile.Suffix)
+		case "current_residence":
+			err = json.Unmarshal(raw, &profile.CurrentResidence)
+		case "about_me":
+			err = json.Unmarshal(raw, &profile.AboutMe)
+		case "detail_strings":
+			var details map[string]geniprofile.DetailsString
+			if err = json.Unmarshal(raw, &details); err == nil {
+				for locale, detail := range details {
+					if detail.AboutMe == nil {
+						delete(profile.DetailStrings, locale)
+						continue
+					}
+					if profile.DetailStrings == nil {
+						profile.DetailStrings = make(map[string]geniprofile.DetailsString, len(details))
+					}
+					profile.DetailStrings[locale] = detail
+				}
+			}
+		case "public":
+			err = json.Unmarshal(raw, &profile.Public)
+		case "locked":
+			err = json.Unmarshal(raw, &profile.Locked)
+		case "language":
+			err = json.Unmarshal(raw, &profile.Language)
+		}
+		if err != nil {
+			return badRequest("invalid %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// appendMissing appends id to ids unless it is already there.
+func appendMissing(ids []string, id string) []string {
+	if slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
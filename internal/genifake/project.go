@@ -0,0 +1,90 @@
+package genifake
+
+import (
+	"net/http"
+	"strings"
+
+	genidocument "github.com/dmalch/go-geni/document"
+	geniphoto "github.com/dmalch/go-geni/photo"
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+// actOnProject answers POST /api/<projectId>/<action>.
+func (s *Server) actOnProject(id, action string, r *http.Request) (any, error) {
+	if _, found := s.projects[id]; !found {
+		return nil, notFound(id)
+	}
+
+	switch action {
+	case "add_profiles":
+		// Geni answers with the last profile added.
+		var response any
+		for profileID := range strings.SplitSeq(r.URL.Query().Get("profile_ids"), ",") {
+			profile, err := s.liveProfile(profileID)
+			if err != nil {
+				return nil, err
+			}
+			profile.Projects = appendMissing(profile.Projects, id)
+			response = profile
+		}
+		return response, nil
+
+	case "add_documents":
+		var response genidocument.BulkResponse
+		for documentID := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+			document, err := s.document(documentID)
+			if err != nil {
+				return nil, err
+			}
+			response.Results = append(response.Results, *document)
+		}
+		return response, nil
+	}
+
+	return nil, badRequest("unknown project action %q", action)
+}
+
+// listUploads answers the paginated GET /api/user/<listing> endpoints. Every
+// profile, document and photo in the fake counts as the caller's own.
+func (s *Server) listUploads(listing string, page int) (any, error) {
+	switch listing {
+	case "managed-profiles":
+		var profiles []geniprofile.Profile
+		for _, id := range s.order {
+			if profile, found := s.profiles[id]; found && !profile.Deleted {
+				profiles = append(profiles, *profile)
+			}
+		}
+		return geniprofile.BulkResponse{Results: pageOf(profiles, page), Page: page, TotalCount: len(profiles)}, nil
+
+	case "uploaded-documents":
+		var documents []genidocument.Document
+		for _, id := range s.order {
+			if document, found := s.documents[id]; found {
+				documents = append(documents, *document)
+			}
+		}
+		return genidocument.BulkResponse{Results: pageOf(documents, page), Page: page, TotalCount: len(documents)}, nil
+
+	case "uploaded-photos":
+		// Geni reports no total for photos; a client stops at an empty page.
+		var photos []geniphoto.Photo
+		for _, id := range s.order {
+			if photo, found := s.photos[id]; found {
+				photos = append(photos, *photo)
+			}
+		}
+		return geniphoto.BulkResponse{Results: pageOf(photos, page), Page: page}, nil
+	}
+
+	return nil, notFound("user/" + listing)
+}
+
+// pageOf returns the given 1-based page of items.
+func pageOf[T any](items []T, page int) []T {
+	start := (page - 1) * pageSize
+	if start >= len(items) {
+		return nil
+	}
+	return items[start:min(start+pageSize, len(items))]
+}
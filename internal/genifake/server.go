@@ -0,0 +1,396 @@
+// Package genifake is an in-memory stand-in for the Geni API, served over
+// httptest so tests can point the provider at it with geniclient's BaseURL
+// option.
+//
+// It implements the endpoints the provider calls — profiles and their events,
+// unions, documents, photos, projects and the user listings — and the family-tree
+// semantics the union resource relies on: add-partner, add-child and
+// add-sibling create a temporary profile inside a union, merging a profile
+// moves its relationships onto the surviving profile, and two unions left with
+// the same pair of partners are auto-merged the way Geni does it. Edits only
+// the Geni website can make are offered as methods, such as DetachFromUnion
+// and SetParentModifier. Behaviour that only matters to Geni's web UI
+// (privacy, managers, revisions) is not modelled.
+package genifake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	genidocument "github.com/dmalch/go-geni/document"
+	geniphoto "github.com/dmalch/go-geni/photo"
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniproject "github.com/dmalch/go-geni/project"
+	geniunion "github.com/dmalch/go-geni/union"
+)
+
+// pageSize is how many results the user listings return per page.
+const pageSize = 50
+
+// Server is a fake Geni API. All state lives in memory and is lost on Close.
+type Server struct {
+	server *httptest.Server
+	now    func() time.Time
+
+	// mu serialises requests: every handler runs with it held, so the store
+	// below needs no finer locking.
+	mu        sync.Mutex
+	lastID    int
+	profiles  map[string]*geniprofile.Profile
+	guids     map[string]string
+	unions    map[string]*geniunion.Union
+	documents map[string]*genidocument.Document
+	photos    map[string]*geniphoto.Photo
+	projects  map[string]*geniproject.Project
+	events    map[string]*profileEvent
+	// order records every id in creation order, so listings are stable.
+	order []string
+}
+
+// NewServer starts a fake Geni API on a local port. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		now:       time.Now,
+		profiles:  make(map[string]*geniprofile.Profile),
+		guids:     make(map[string]string),
+		unions:    make(map[string]*geniunion.Union),
+		documents: make(map[string]*genidocument.Document),
+		photos:    make(map[string]*geniphoto.Photo),
+		projects:  make(map[string]*geniproject.Project),
+		events:    make(map[string]*profileEvent),
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the base URL of the server, for geniclient.Options.BaseURL.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddProject registers a project, which the API cannot create. Profiles and
+// documents can then be added to it.
+func (s *Server) AddProject(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.projects[id] = &geniproject.Project{
+		ID:        id,
+		Name:      name,
+		CreatedAt: s.timestamp(),
+		UpdatedAt: s.timestamp(),
+	}
+}
+
+// DetachFromUnion removes the profile with the given guid from a union, as the
+// Geni website's "remove relationships" action does; the API has no endpoint
+// for it. The union stays, even when it is left empty.
+func (s *Server) DetachFromUnion(profileGuid, unionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, err := s.liveProfile("profile-g" + profileGuid)
+	if err != nil {
+		return err
+	}
+	union, err := s.union(unionID)
+	if err != nil {
+		return err
+	}
+	removeMember(union, profile.ID)
+	profile.Unions = slices.DeleteFunc(profile.Unions, func(id string) bool { return id == unionID })
+	return nil
+}
+
+// SetParentModifier makes the profile with the given guid a biological
+// ("bio"), adopted ("adopt") or foster ("foster") child of a union, as the
+// relationship form of the Geni website does; the API has no endpoint for it.
+func (s *Server) SetParentModifier(childGuid, unionID, modifier string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	child, err := s.liveProfile("profile-g" + childGuid)
+	if err != nil {
+		return err
+	}
+	union, err := s.union(unionID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(union.Children, child.ID) {
+		return badRequest("%s is not a child of %s", child.ID, unionID)
+	}
+	if modifier == "bio" {
+		modifier = ""
+	}
+	if err := checkModifier(modifier); err != nil {
+		return err
+	}
+	removeMember(union, child.ID)
+	s.addChild(union, child, modifier)
+	return nil
+}
+
+// apiError is a failed request. Its status is chosen to produce the error the
+// go-geni client returns for the same failure on Geni: 404 for
+// ErrResourceNotFound, 403 for ErrAccessDenied.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func notFound(id string) error {
+	return &apiError{status: http.StatusNotFound, message: id + " not found"}
+}
+
+func accessDenied(id string) error {
+	return &apiError{status: http.StatusForbidden, message: "access denied to " + id}
+}
+
+func badRequest(format string, args ...any) error {
+	return &apiError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// result is the envelope Geni answers no-content mutations with.
+type result struct {
+	Result string `json:"result"`
+}
+
+var resultOK = result{Result: "OK"}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response, err := s.route(r)
+	if err != nil {
+		apiErr, isAPIError := errors.AsType[*apiError](err)
+		if !isAPIError {
+			apiErr = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+		}
+		writeJSON(w, apiErr.status, map[string]any{
+			"error": map[string]string{"message": apiErr.message},
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	// go-geni re-tunes its own limiter from these headers; the fake has no
+	// rate limit to announce, so it announces a generous one.
+	w.Header().Set("X-API-Rate-Window", "1")
+	w.Header().Set("X-API-Rate-Limit", "1000")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// route dispatches a request by the shape of its path below /api/.
+func (s *Server) route(r *http.Request) (any, error) {
+	path, isAPI := strings.CutPrefix(r.URL.Path, "/api/")
+	if !isAPI {
+		return nil, notFound(r.URL.Path)
+	}
+	segments := strings.Split(path, "/")
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 1:
+		if ids := query.Get("ids"); ids != "" {
+			return s.getBulk(segments[0], strings.Split(ids, ","))
+		}
+		return s.get(segments[0])
+
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "user":
+		return s.listUploads(segments[1], page(query))
+
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[1] == "events":
+		return s.listEvents(segments[0])
+
+	case r.Method == http.MethodPost && len(segments) == 2 && segments[1] == "add":
+		switch segments[0] {
+		case "profile":
+			return s.createProfile(r)
+		case "document":
+			return s.createDocument(r)
+		case "photo":
+			return s.createPhoto(r)
+		}
+
+	case r.Method == http.MethodPost && len(segments) == 2:
+		return s.act(segments[0], segments[1], r)
+
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[1] == "merge":
+		return s.merge(segments[0], segments[2])
+
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[1] == "tag":
+		return s.tag(segments[0], segments[2], true)
+
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[1] == "untag":
+		return s.tag(segments[0], segments[2], false)
+	}
+
+	return nil, &apiError{status: http.StatusNotImplemented, message: r.Method + " " + r.URL.Path + " is not implemented by the fake"}
+}
+
+// get answers GET /api/<id>.
+func (s *Server) get(id string) (any, error) {
+	switch kindOf(id) {
+	case "profile":
+		return s.profile(id)
+	case "union":
+		return s.union(id)
+	case "document":
+		return s.document(id)
+	case "photo":
+		return s.photo(id)
+	case "project":
+		if project, found := s.projects[id]; found {
+			return project, nil
+		}
+	case "event":
+		if event, found := s.events[id]; found {
+			return event, nil
+		}
+	}
+	return nil, notFound(id)
+}
+
+// getBulk answers GET /api/<kind>?ids=… and its coalesced form, GET
+// /api/<id>?ids=…. Ids that do not exist are left out of the results.
+func (s *Server) getBulk(target string, ids []string) (any, error) {
+	kind := kindOf(target)
+	if kind == "" {
+		kind = target
+	}
+
+	switch kind {
+	case "profile":
+		var response geniprofile.BulkResponse
+		for _, id := range ids {
+			if profile, err := s.profile(id); err == nil {
+				response.Results = append(response.Results, *profile)
+			}
+		}
+		return response, nil
+	case "union":
+		var response geniunion.BulkResponse
+		for _, id := range ids {
+			if union, err := s.union(id); err == nil {
+				response.Results = append(response.Results, *union)
+			}
+		}
+		return response, nil
+	case "document":
+		var response genidocument.BulkResponse
+		for _, id := range ids {
+			if document, err := s.document(id); err == nil {
+				response.Results = append(response.Results, *document)
+			}
+		}
+		return response, nil
+	case "photo":
+		var response geniphoto.BulkResponse
+		for _, id := range ids {
+			if photo, err := s.photo(id); err == nil {
+				response.Results = append(response.Results, *photo)
+			}
+		}
+		return response, nil
+	}
+	return nil, notFound(target)
+}
+
+// act answers POST /api/<id>/<action>.
+func (s *Server) act(id, action string, r *http.Request) (any, error) {
+	switch kindOf(id) {
+	case "profile":
+		return s.actOnProfile(id, action, r)
+	case "union":
+		return s.actOnUnion(id, action, r)
+	case "document":
+		return s.actOnDocument(id, action, r)
+	case "photo":
+		return s.actOnPhoto(id, action, r)
+	case "project":
+		return s.actOnProject(id, action, r)
+	case "event":
+		return s.actOnEvent(id, action, r)
+	}
+	return nil, notFound(id)
+}
+
+// tag answers POST /api/<id>/tag/<profileId> and its untag counterpart.
+func (s *Server) tag(id, profileID string, tag bool) (any, error) {
+	profile, err := s.liveProfile(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kindOf(id) {
+	case "document":
+		return s.tagDocument(id, profile, tag)
+	case "photo":
+		return s.tagPhoto(id, profile, tag)
+	}
+	return nil, notFound(id)
+}
+
+// newID returns a fresh id of the given kind, e.g. "profile-12".
+func (s *Server) newID(kind string) string {
+	s.lastID++
+	id := kind + "-" + strconv.Itoa(s.lastID)
+	s.order = append(s.order, id)
+	return id
+}
+
+func (s *Server) timestamp() string {
+	return strconv.FormatInt(s.now().Unix(), 10)
+}
+
+// kindOf returns the resource kind an id belongs to, e.g. "profile" for
+// "profile-12", or "" if id is not an id.
+func kindOf(id string) string {
+	kind, number, found := strings.Cut(id, "-")
+	if !found || number == "" {
+		return ""
+	}
+	return kind
+}
+
+// page returns the 1-based page a listing request asks for.
+func page(query url.Values) int {
+	if value := query.Get("page"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
+
+// decodeBody decodes a JSON request body into v.
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package genifake
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	"github.com/dmalch/go-geni"
+	genidocument "github.com/dmalch/go-geni/document"
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+)
+
+// newTestClient starts a fake server and returns a client pointed at it.
+func newTestClient(t *testing.T) (*Server, *geniclient.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	base, err := url.Parse(server.URL())
+	Expect(err).ToNot(HaveOccurred())
+
+	client := geniclient.New(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), true, geniclient.Options{
+		RequestsPerSecond: 1000,
+		Burst:             1000,
+		BaseURL:           base,
+	})
+	return server, client
+}
+
+func TestProfiles(t *testing.T) {
+	t.Run("A created profile reads back by id and by guid", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		created, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{Request: geniprofile.Request{
+			Names: map[string]geniprofile.NameElement{"en-US": {FirstName: new("Иван")}},
+			Birth: &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(int32(1900))}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		byID, err := client.Profile().Get(ctx, created.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*byID.Names["en-US"].FirstName).To(Equal("Иван"))
+		Expect(*byID.Birth.Date.Year).To(Equal(int32(1900)))
+
+		byGuid, err := client.Profile().Get(ctx, "profile-g"+created.Guid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(byGuid.ID).To(Equal(created.ID))
+	})
+
+	t.Run("Updates deep-merge event dates until the date is wiped", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		created, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{Request: geniprofile.Request{
+			Birth: &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(int32(1900)), Month: new(int32(5))}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		updated, err := client.Profile().Update(ctx, created.ID, &geniclient.ProfileRequest{Request: geniprofile.Request{
+			Birth: &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(int32(1901))}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*updated.Birth.Date.Year).To(Equal(int32(1901)))
+		Expect(updated.Birth.Date.Month).To(HaveValue(Equal(int32(5))))
+
+		Expect(client.Profile().WipeEventDates(ctx, created.ID, []string{"birth"})).To(Succeed())
+		wiped, err := client.Profile().Get(ctx, created.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(wiped.Birth).To(BeNil())
+	})
+
+	t.Run("The lock and the language are kept unless an update sends them", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		created, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{Locked: new(true), Language: new("ru")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created.Locked).To(BeTrue())
+		Expect(created.Language).To(Equal("ru"))
+
+		kept, err := client.Profile().Update(ctx, created.ID, &geniclient.ProfileRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kept.Locked).To(BeTrue())
+		Expect(kept.Language).To(Equal("ru"))
+
+		unlocked, err := client.Profile().Update(ctx, created.ID, &geniclient.ProfileRequest{Locked: new(false), Language: new("en")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unlocked.Locked).To(BeFalse())
+		Expect(unlocked.Language).To(Equal("en"))
+	})
+
+	t.Run("A deleted profile reads back flagged and cannot be deleted again", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		created, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.Profile().Delete(ctx, created.ID)).To(Succeed())
+
+		deleted, err := client.Profile().Get(ctx, created.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted.Deleted).To(BeTrue())
+		Expect(client.Profile().Delete(ctx, created.ID)).To(MatchError(geni.ErrAccessDenied))
+	})
+
+	t.Run("An unknown profile is not found", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+
+		_, err := client.Profile().Get(context.Background(), "profile-99999")
+
+		Expect(err).To(MatchError(geni.ErrResourceNotFound))
+	})
+
+	t.Run("Bulk reads leave out unknown ids", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		first, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		second, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		Expect(err).ToNot(HaveOccurred())
+
+		bulk, err := client.Profile().GetBulk(ctx, []string{first.ID, "profile-99999", second.ID})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bulk.Results).To(HaveLen(2))
+	})
+
+	t.Run("Managed profiles are listed page by page", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		for range pageSize + 1 {
+			_, err := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		first, err := client.User().ManagedProfiles(ctx, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first.Results).To(HaveLen(pageSize))
+		Expect(first.TotalCount).To(Equal(pageSize + 1))
+
+		second, err := client.User().ManagedProfiles(ctx, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.Results).To(HaveLen(1))
+	})
+	t.Run("A profile's events are added, listed, updated and deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		profile, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		emigration, err := client.Event().Create(ctx, profile.ID, &geniprofile.EventElement{
+			Name:     "Emigration",
+			Date:     &geniprofile.DateElement{Year: new(int32(1922))},
+			Location: &geniprofile.LocationElement{Country: new("France")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(emigration.ID).To(HavePrefix("event-"))
+
+		_, err = client.Event().Create(ctx, profile.ID, &geniprofile.EventElement{Description: new("Unnamed")})
+		Expect(err).To(HaveOccurred(), "an event needs a name")
+
+		updated, err := client.Event().Update(ctx, emigration.ID, &geniprofile.EventElement{
+			Name: "Emigration",
+			Date: &geniprofile.DateElement{Year: new(int32(1923))},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*updated.Date.Year).To(Equal(int32(1923)))
+		Expect(updated.Location).To(BeNil(), "a location sent as null is cleared")
+
+		events, err := client.Event().List(ctx, profile.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].ID).To(Equal(emigration.ID))
+
+		Expect(client.Event().Delete(ctx, emigration.ID)).To(Succeed())
+		Expect(client.Event().Delete(ctx, emigration.ID)).To(MatchError(geni.ErrResourceNotFound))
+		events, err = client.Event().List(ctx, profile.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(BeEmpty())
+	})
+}
+
+func TestUnions(t *testing.T) {
+	// couple joins two existing profiles the way the union resource does:
+	// add a temporary partner to one and merge it into the other.
+	couple := func(ctx context.Context, client *geniclient.Client, a, b string) string {
+		tmp, err := client.Profile().AddPartner(ctx, a)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmp.Unions).To(HaveLen(1))
+		_, err = client.Profile().Merge(ctx, b, tmp.ID)
+		Expect(err).ToNot(HaveOccurred())
+		return tmp.Unions[0]
+	}
+
+	t.Run("Merging a temporary partner joins two existing profiles", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		husband, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		wife, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+
+		unionID := couple(ctx, client, husband.ID, wife.ID)
+
+		union, err := client.Union().Get(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Partners).To(ConsistOf(husband.ID, wife.ID))
+
+		wifeAfter, err := client.Profile().Get(ctx, wife.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(wifeAfter.Unions).To(ConsistOf(unionID))
+	})
+
+	t.Run("The merged temporary profile points at the survivor", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		parent, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		child, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+
+		tmp, err := client.Profile().AddChild(ctx, parent.ID)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.Profile().Merge(ctx, child.ID, tmp.ID)
+		Expect(err).ToNot(HaveOccurred())
+
+		merged, err := client.Profile().Get(ctx, tmp.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(merged.Deleted).To(BeTrue())
+		Expect(merged.MergedInto).To(Equal(child.ID))
+
+		union, err := client.Union().Get(ctx, tmp.Unions[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Partners).To(ConsistOf(parent.ID))
+		Expect(union.Children).To(ConsistOf(child.ID))
+	})
+
+	t.Run("Children added to a union keep their relationship modifier", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		a, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		b, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		unionID := couple(ctx, client, a.ID, b.ID)
+
+		adopted, err := client.Union().AddChild(ctx, unionID, geniprofile.WithModifier("adopt"))
+		Expect(err).ToNot(HaveOccurred())
+		fostered, err := client.Union().AddChild(ctx, unionID, geniprofile.WithModifier("foster"))
+		Expect(err).ToNot(HaveOccurred())
+
+		union, err := client.Union().Get(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Children).To(ConsistOf(adopted.ID, fostered.ID))
+		Expect(union.AdoptedChildren).To(ConsistOf(adopted.ID))
+		Expect(union.FosterChildren).To(ConsistOf(fostered.ID))
+	})
+
+	t.Run("A profile detached from a union leaves it, and the union stays", func(t *testing.T) {
+		RegisterTestingT(t)
+		server, client := newTestClient(t)
+		ctx := context.Background()
+
+		a, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		b, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		unionID := couple(ctx, client, a.ID, b.ID)
+		child, err := client.Union().AddChild(ctx, unionID, geniprofile.WithModifier("adopt"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(server.DetachFromUnion(b.Guid, unionID)).To(Succeed())
+		Expect(server.DetachFromUnion(child.Guid, unionID)).To(Succeed())
+
+		union, err := client.Union().Get(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Partners).To(ConsistOf(a.ID))
+		Expect(union.Children).To(BeEmpty())
+		Expect(union.AdoptedChildren).To(BeEmpty())
+
+		bAfter, err := client.Profile().Get(ctx, b.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bAfter.Unions).To(BeEmpty())
+	})
+
+	t.Run("A child's relationship modifier changes in place", func(t *testing.T) {
+		RegisterTestingT(t)
+		server, client := newTestClient(t)
+		ctx := context.Background()
+
+		a, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		b, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		unionID := couple(ctx, client, a.ID, b.ID)
+		child, err := client.Union().AddChild(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(server.SetParentModifier(child.Guid, unionID, "foster")).To(Succeed())
+		fostered, err := client.Union().Get(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fostered.Children).To(ConsistOf(child.ID))
+		Expect(fostered.FosterChildren).To(ConsistOf(child.ID))
+
+		Expect(server.SetParentModifier(child.Guid, unionID, "bio")).To(Succeed())
+		biological, err := client.Union().Get(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(biological.Children).To(ConsistOf(child.ID))
+		Expect(biological.FosterChildren).To(BeEmpty())
+
+		Expect(server.SetParentModifier(a.Guid, unionID, "adopt")).To(MatchError(ContainSubstring("is not a child of")))
+	})
+
+	t.Run("A second union of the same couple is auto-merged into the first", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		a, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		b, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		firstID := couple(ctx, client, a.ID, b.ID)
+		child, err := client.Union().AddChild(ctx, firstID)
+		Expect(err).ToNot(HaveOccurred())
+
+		secondID := couple(ctx, client, a.ID, b.ID)
+
+		second, err := client.Union().Get(ctx, secondID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.Partners).To(BeEmpty())
+		Expect(second.Children).To(BeEmpty())
+
+		first, err := client.Union().Get(ctx, firstID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first.Partners).To(ConsistOf(a.ID, b.ID))
+		Expect(first.Children).To(ConsistOf(child.ID))
+
+		aAfter, err := client.Profile().Get(ctx, a.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(aAfter.Unions).To(ConsistOf(firstID))
+	})
+
+	t.Run("Union events are cleared only by an empty object", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		a, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		b, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		unionID := couple(ctx, client, a.ID, b.ID)
+
+		_, err := client.Union().Update(ctx, unionID, &geniunion.Request{
+			Marriage: &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(int32(1920))}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		union, err := client.Union().Update(ctx, unionID, &geniunion.Request{
+			Marriage: &geniprofile.EventElement{Location: &geniprofile.LocationElement{City: new("Paris")}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*union.Marriage.Date.Year).To(Equal(int32(1920)))
+
+		Expect(client.Profile().WipeEvents(ctx, unionID, []string{"marriage"})).To(Succeed())
+		union, err = client.Union().Get(ctx, unionID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Marriage).To(BeNil())
+	})
+}
+
+func TestDocumentsAndPhotos(t *testing.T) {
+	t.Run("A document's content type follows its content", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		text, err := client.Document().Create(ctx, &genidocument.Request{Title: "Text", Text: new("hello")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*text.ContentType).To(Equal("text/plain"))
+
+		link, err := client.Document().Create(ctx, &genidocument.Request{Title: "Link", SourceUrl: new("https://example.com")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*link.ContentType).To(Equal("text/html"))
+	})
+
+	t.Run("Documents are tagged, linked to projects and deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		server, client := newTestClient(t)
+		server.AddProject("project-8", "Test project")
+		ctx := context.Background()
+
+		profile, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		document, err := client.Document().Create(ctx, &genidocument.Request{Title: "Doc", Text: new("hello")})
+		Expect(err).ToNot(HaveOccurred())
+
+		tagged, err := client.Document().Tag(ctx, document.ID, profile.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tagged.Results).To(HaveLen(1))
+		_, err = client.Document().AddToProject(ctx, document.ID, "project-8")
+		Expect(err).ToNot(HaveOccurred())
+
+		read, err := client.Document().Get(ctx, document.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read.Tags).To(ConsistOf(profile.ID))
+
+		Expect(client.Document().Delete(ctx, document.ID)).To(Succeed())
+		_, err = client.Document().Get(ctx, document.ID)
+		Expect(err).To(MatchError(geni.ErrResourceNotFound))
+	})
+
+	t.Run("An uploaded photo is tagged and listed", func(t *testing.T) {
+		RegisterTestingT(t)
+		_, client := newTestClient(t)
+		ctx := context.Background()
+
+		profile, _ := client.Profile().Create(ctx, &geniclient.ProfileRequest{})
+		png := []byte("\x89PNG\r\n\x1a\n")
+		photo, err := client.Photo().Create(ctx, "Reunion", "reunion.png", bytes.NewReader(png))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(photo.ContentType).To(Equal("image/png"))
+
+		tagged, err := client.Photo().Tag(ctx, photo.ID, profile.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tagged.Tags).To(ConsistOf(profile.ID))
+
+		uploaded, err := client.User().UploadedPhotos(ctx, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(uploaded.Results).To(HaveLen(1))
+
+		empty, err := client.User().UploadedPhotos(ctx, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(empty.Results).To(BeEmpty())
+	})
+}
@@ -0,0 +1,198 @@
+package genifake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+)
+
+// union returns the union with the given id. A union absorbed by an auto-merge
+// is still returned, with no partners and no children, as Geni does.
+func (s *Server) union(id string) (*geniunion.Union, error) {
+	union, found := s.unions[id]
+	if !found {
+		return nil, notFound(id)
+	}
+	return union, nil
+}
+
+// newUnion stores an empty union and returns it.
+func (s *Server) newUnion() *geniunion.Union {
+	union := &geniunion.Union{ID: s.newID("union")}
+	s.unions[union.ID] = union
+	return union
+}
+
+// actOnUnion answers POST /api/<unionId>/<action>.
+func (s *Server) actOnUnion(id, action string, r *http.Request) (any, error) {
+	union, err := s.union(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case "update":
+		var fields map[string]json.RawMessage
+		if err := decodeBody(r, &fields); err != nil {
+			return nil, err
+		}
+		for key, raw := range fields {
+			switch key {
+			case "marriage":
+				err = mergeEvent(&union.Marriage, raw, false)
+			case "divorce":
+				err = mergeEvent(&union.Divorce, raw, false)
+			}
+			if err != nil {
+				return nil, badRequest("invalid %s: %v", key, err)
+			}
+		}
+		return union, nil
+
+	case "add-partner":
+		partner := s.newProfile()
+		s.addPartner(union, partner)
+		return partner, nil
+
+	case "add-child":
+		modifier := r.URL.Query().Get("relationship_modifier")
+		if err := checkModifier(modifier); err != nil {
+			return nil, err
+		}
+		child := s.newProfile()
+		s.addChild(union, child, modifier)
+		return child, nil
+	}
+
+	return nil, badRequest("unknown union action %q", action)
+}
+
+// addPartner makes profile a partner in union.
+func (s *Server) addPartner(union *geniunion.Union, profile *geniprofile.Profile) {
+	union.Partners = appendMissing(union.Partners, profile.ID)
+	profile.Unions = appendMissing(profile.Unions, union.ID)
+}
+
+// addChild makes profile a child in union, labelled adopted or foster when
+// modifier says so.
+func (s *Server) addChild(union *geniunion.Union, profile *geniprofile.Profile, modifier string) {
+	union.Children = appendMissing(union.Children, profile.ID)
+	switch modifier {
+	case "adopt":
+		union.AdoptedChildren = appendMissing(union.AdoptedChildren, profile.ID)
+	case "foster":
+		union.FosterChildren = appendMissing(union.FosterChildren, profile.ID)
+	}
+	profile.Unions = appendMissing(profile.Unions, union.ID)
+}
+
+// mergeDuplicateUnions auto-merges unions of profile that share the same two
+// partners, which is what Geni does once a merge makes two couples identical.
+// The older union survives and takes over the members and events of the
+// younger one, which is left empty.
+func (s *Server) mergeDuplicateUnions(profile *geniprofile.Profile) {
+	for merged := true; merged; {
+		merged = false
+		for i, firstID := range profile.Unions {
+			for _, secondID := range profile.Unions[i+1:] {
+				first, second := s.unions[firstID], s.unions[secondID]
+				if !samePartners(first, second) {
+					continue
+				}
+				if unionNumber(second.ID) < unionNumber(first.ID) {
+					first, second = second, first
+				}
+				s.absorbUnion(first, second)
+				merged = true
+				break
+			}
+			if merged {
+				break
+			}
+		}
+	}
+}
+
+// absorbUnion moves every member and event of from onto into, leaving from
+// with no partners and no children.
+func (s *Server) absorbUnion(into, from *geniunion.Union) {
+	for _, id := range from.Partners {
+		s.addPartner(into, s.profiles[id])
+	}
+	for _, id := range from.Children {
+		modifier := ""
+		switch {
+		case slices.Contains(from.AdoptedChildren, id):
+			modifier = "adopt"
+		case slices.Contains(from.FosterChildren, id):
+			modifier = "foster"
+		}
+		s.addChild(into, s.profiles[id], modifier)
+	}
+	for _, id := range slices.Concat(from.Partners, from.Children) {
+		profile := s.profiles[id]
+		profile.Unions = slices.DeleteFunc(profile.Unions, func(unionID string) bool { return unionID == from.ID })
+	}
+	if into.Marriage == nil {
+		into.Marriage = from.Marriage
+	}
+	if into.Divorce == nil {
+		into.Divorce = from.Divorce
+	}
+
+	from.Partners = nil
+	from.Children = nil
+	from.AdoptedChildren = nil
+	from.FosterChildren = nil
+	from.Marriage = nil
+	from.Divorce = nil
+}
+
+// samePartners reports whether two unions are couples of the same two people.
+func samePartners(a, b *geniunion.Union) bool {
+	if len(a.Partners) != 2 || len(b.Partners) != 2 {
+		return false
+	}
+	return slices.Contains(b.Partners, a.Partners[0]) && slices.Contains(b.Partners, a.Partners[1])
+}
+
+// replaceMember swaps profile from for profile to everywhere in union. If to is
+// already there the two entries collapse into one.
+func replaceMember(union *geniunion.Union, from, to string) {
+	replace := func(ids []string) []string {
+		if slices.Contains(ids, to) {
+			return slices.DeleteFunc(ids, func(id string) bool { return id == from })
+		}
+		for i, id := range ids {
+			if id == from {
+				ids[i] = to
+			}
+		}
+		return ids
+	}
+	union.Partners = replace(union.Partners)
+	union.Children = replace(union.Children)
+	union.AdoptedChildren = replace(union.AdoptedChildren)
+	union.FosterChildren = replace(union.FosterChildren)
+}
+
+// removeMember drops profile id from every list in union.
+func removeMember(union *geniunion.Union, id string) {
+	is := func(member string) bool { return member == id }
+	union.Partners = slices.DeleteFunc(union.Partners, is)
+	union.Children = slices.DeleteFunc(union.Children, is)
+	union.AdoptedChildren = slices.DeleteFunc(union.AdoptedChildren, is)
+	union.FosterChildren = slices.DeleteFunc(union.FosterChildren, is)
+}
+
+// unionNumber returns the numeric part of a union id, which orders unions by
+// age.
+func unionNumber(id string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(id, "union-"))
+	return n
+}
@@ -0,0 +1,128 @@
+// Package genitest holds the fixtures the resource tests share: Geni clients
+// talking to an in-process genifake server, or answered from a genicassette
+// cassette, and helpers for the diagnostics the resources report.
+//
+// It registers the -record test flag, which has Cassette record its cassette
+// afresh instead of replaying it, so a package whose tests use it must not
+// define that flag itself.
+package genitest
+
+import (
+	"flag"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genicassette"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genifake"
+)
+
+var record = flag.Bool("record", false, "re-record the testdata cassettes, against the Geni sandbox when GENI_ACCESS_TOKEN is set and against an in-process fake Geni server otherwise")
+
+// Clients are a client and a batch client that share one transport, as the
+// provider hands them to its resources.
+type Clients struct {
+	Client      *geniclient.Client
+	BatchClient *genibatch.Client
+}
+
+// FakeServer returns clients talking to a new fake Geni server that holds the
+// given number of profiles, profile-1 onwards, and the server. A non-nil
+// transport carries the clients' requests to the server in place of
+// http.DefaultTransport, to tamper with them, say. The server and the batch
+// client are shut down when the test ends.
+func FakeServer(t *testing.T, profiles int, transport http.RoundTripper) (Clients, *genifake.Server) {
+	t.Helper()
+	server := genifake.NewServer()
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL())
+	Expect(err).ToNot(HaveOccurred())
+
+	clients := newClients(t, token(), geniclient.Options{RequestsPerSecond: 1000, Burst: 1000, BaseURL: baseURL, Transport: transport})
+	seed(t, clients.Client, profiles)
+	return clients, server
+}
+
+// Cassette returns clients whose requests are answered from
+// testdata/<name>.jsonl, and a func reporting how many recorded responses the
+// test left unused.
+//
+// With -record the cassette is recorded afresh: against the Geni sandbox when
+// GENI_ACCESS_TOKEN is set and the test needs no profiles, and otherwise
+// against a fake Geni server holding the given number of profiles, profile-1
+// onwards, since the sandbox has no profiles of those ids.
+func Cassette(t *testing.T, name string, profiles int) (Clients, func() int) {
+	t.Helper()
+	cassette := filepath.Join("testdata", name+".jsonl")
+	tokenSource := token()
+	opts := geniclient.Options{RequestsPerSecond: 1000, Burst: 1000}
+	remaining := func() int { return 0 }
+
+	if *record {
+		if sandboxToken := os.Getenv("GENI_ACCESS_TOKEN"); sandboxToken != "" && profiles == 0 {
+			tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: sandboxToken})
+			opts = geniclient.Options{}
+		} else {
+			fake := genifake.NewServer()
+			t.Cleanup(fake.Close)
+			baseURL, err := url.Parse(fake.URL())
+			Expect(err).ToNot(HaveOccurred())
+			opts.BaseURL = baseURL
+			seed(t, geniclient.New(tokenSource, true, opts), profiles)
+		}
+
+		recorder, err := genicassette.NewRecorder(cassette, http.DefaultTransport)
+		Expect(err).ToNot(HaveOccurred())
+		t.Cleanup(func() { _ = recorder.Close() })
+		opts.Transport = recorder
+	} else {
+		replayer, err := genicassette.Load(cassette)
+		Expect(err).ToNot(HaveOccurred())
+		opts.Transport = replayer
+		remaining = replayer.Remaining
+	}
+
+	return newClients(t, tokenSource, opts), remaining
+}
+
+// PathOf returns the attribute path d is reported at, failing the test when
+// it has none.
+func PathOf(d diag.Diagnostic) path.Path {
+	withPath, ok := d.(diag.DiagnosticWithPath)
+	Expect(ok).To(BeTrue())
+	return withPath.Path()
+}
+
+func token() oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+}
+
+// seed creates the given number of empty profiles through client.
+func seed(t *testing.T, client *geniclient.Client, profiles int) {
+	t.Helper()
+	for range profiles {
+		_, err := client.Profile().Create(t.Context(), &geniclient.ProfileRequest{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+}
+
+// newClients returns clients for opts, whose batch client is closed when the
+// test ends.
+func newClients(t *testing.T, tokenSource oauth2.TokenSource, opts geniclient.Options) Clients {
+	t.Helper()
+	client := geniclient.New(tokenSource, true, opts)
+	batchClient := genibatch.NewClient(client, genibatch.Config{FlushInterval: time.Millisecond})
+	batchClient.Start(t.Context())
+	t.Cleanup(func() { _ = batchClient.Close() })
+	return Clients{Client: client, BatchClient: batchClient}
+}
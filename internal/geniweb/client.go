@@ -0,0 +1,155 @@
+// Package geniweb makes the edits the Geni API has no endpoint for, such as
+// removing a profile from a union or changing a child's relationship to one,
+// through the Geni website instead.
+//
+// The website is driven with the session cookies of a browser the user logged
+// in to Geni with. Its endpoints are undocumented and may change without
+// notice, and using them may go against Geni's terms of service, so the
+// provider only does so when a session is configured.
+package geniweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dmalch/go-geni/web"
+	"github.com/dmalch/go-geni/web/relationships"
+	"github.com/dmalch/go-geni/web/treeconflicts"
+	"github.com/dmalch/go-geni/web/unions"
+)
+
+// Client edits the family tree through the Geni website.
+type Client struct {
+	trees         *treeconflicts.Client
+	unions        *unions.Client
+	relationships *relationships.Client
+}
+
+// New returns a Client signed in with cookieHeader, a "name=value; name=value"
+// Cookie header copied from a logged-in browser. baseURL is the website, such
+// as https://www.geni.com/. httpClient may be nil.
+func New(cookieHeader, baseURL string, httpClient *http.Client) (*Client, error) {
+	cookies := web.CookiesFromHeader(cookieHeader)
+	if len(cookies) == 0 {
+		return nil, errors.New("the session holds no cookies; copy the Cookie header of a request to geni.com from a logged-in browser")
+	}
+
+	w, err := web.NewClient(web.Options{
+		Cookies:    cookies,
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		trees:         treeconflicts.NewClient(w),
+		unions:        unions.NewClient(w),
+		relationships: relationships.NewClient(w),
+	}, nil
+}
+
+// UnionWebID returns the website's id of the union with the given partners and
+// children that the profile memberGuid belongs to. All profiles are given by
+// guid. The API does not expose the website's union ids, so the union is found
+// among memberGuid's unions on the website by its members.
+func (c *Client) UnionWebID(ctx context.Context, memberGuid string, partners, children []string) (string, error) {
+	webUnions, err := c.trees.UnionsFor(ctx, memberGuid)
+	if err != nil {
+		return "", fmt.Errorf("error listing the unions of profile %s on the Geni website: %w", memberGuid, err)
+	}
+
+	matches := matchUnions(webUnions, partners, children)
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("none of the unions of profile %s on the Geni website has the union's members", memberGuid)
+	default:
+		return "", fmt.Errorf("%d unions of profile %s on the Geni website (%s) have the union's members",
+			len(matches), memberGuid, strings.Join(matches, ", "))
+	}
+}
+
+// Detach removes the profile profileGuid from the union with the website id
+// unionWebID. The union itself is left in place, even when it ends up empty.
+func (c *Client) Detach(ctx context.Context, profileGuid, unionWebID string) error {
+	if err := c.unions.Detach(ctx, profileGuid, []string{unionWebID}); err != nil {
+		return fmt.Errorf("error removing profile %s from union %s on the Geni website: %w", profileGuid, unionWebID, err)
+	}
+	return nil
+}
+
+// SetParentModifier makes the child childGuid a biological ("bio"), adopted
+// ("adopt") or foster ("foster") child of the union with the website id
+// unionWebID.
+func (c *Client) SetParentModifier(ctx context.Context, childGuid, unionWebID, modifier string) error {
+	if _, err := c.relationships.SetParentModifier(ctx, childGuid, unionWebID, modifier); err != nil {
+		return fmt.Errorf("error changing the relationship of profile %s to union %s on the Geni website: %w", childGuid, unionWebID, err)
+	}
+	return nil
+}
+
+// matchUnions returns the distinct web ids of the unions that can be the one
+// with the given partners and children. Children must match exactly. Partners
+// need only share one profile, because the website lists partner slots the
+// API leaves out; a side with no partners at all leaves the children to
+// decide.
+func matchUnions(webUnions []treeconflicts.WebUnion, partners, children []string) []string {
+	var matches []string
+	seen := make(map[string]struct{}, len(webUnions))
+	for _, u := range webUnions {
+		if _, ok := seen[u.WebID]; ok {
+			continue
+		}
+		if !sameMembers(u.Children, children) || !sharesMember(u.Partners, partners) {
+			continue
+		}
+		seen[u.WebID] = struct{}{}
+		matches = append(matches, u.WebID)
+	}
+	return matches
+}
+
+func sameMembers(a, b []string) bool {
+	x, y := memberSet(a), memberSet(b)
+	if len(x) != len(y) {
+		return false
+	}
+	for id := range x {
+		if _, ok := y[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sharesMember(a, b []string) bool {
+	x, y := memberSet(a), memberSet(b)
+	if len(x) == 0 || len(y) == 0 {
+		return true
+	}
+	for id := range x {
+		if _, ok := y[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// memberSet indexes profile guids, with any "profile-g" or "profile-" prefix
+// removed.
+func memberSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		id = strings.TrimPrefix(strings.TrimPrefix(id, "profile-g"), "profile-")
+		if id != "" {
+			set[id] = struct{}{}
+		}
+	}
+	return set
+}
@@ -0,0 +1,64 @@
+package geniweb
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/go-geni/web/treeconflicts"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("A session without cookies is rejected", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		_, err := New("  ", "https://www.geni.com/", nil)
+
+		Expect(err).To(MatchError(ContainSubstring("no cookies")))
+	})
+
+	t.Run("A session with cookies makes a client", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		client, err := New("_geni_session=abc; logged_in=1", "https://www.geni.com/", nil)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client).ToNot(BeNil())
+	})
+}
+
+func TestMatchUnions(t *testing.T) {
+	webUnions := []treeconflicts.WebUnion{
+		{WebID: "6000000001", Partners: []string{"101", "102"}, Children: []string{"103"}},
+		// The same pair again, as parent and child rather than as partners.
+		{WebID: "6000000002", Partners: []string{"101", "-1234f"}, Children: []string{"102"}},
+		{WebID: "6000000003", Partners: []string{"101", "104"}, Children: nil},
+		// The website repeats a union now and then.
+		{WebID: "6000000001", Partners: []string{"101", "102"}, Children: []string{"103"}},
+	}
+
+	t.Run("The union with the same children and a shared partner matches once", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(matchUnions(webUnions, []string{"101", "102"}, []string{"103"})).To(Equal([]string{"6000000001"}))
+	})
+
+	t.Run("Partner slots the API does not list do not prevent a match", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(matchUnions(webUnions, []string{"profile-g101"}, []string{"profile-g102"})).To(Equal([]string{"6000000002"}))
+	})
+
+	t.Run("Children must match exactly", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(matchUnions(webUnions, []string{"101", "102"}, []string{"103", "105"})).To(BeEmpty())
+	})
+
+	t.Run("A union without partners is told apart by its children alone", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(matchUnions(webUnions, nil, []string{"103"})).To(Equal([]string{"6000000001"}))
+		Expect(matchUnions(webUnions, nil, nil)).To(Equal([]string{"6000000003"}))
+	})
+}
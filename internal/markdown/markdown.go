@@ -0,0 +1,427 @@
+// Package markdown converts a profile's about text between Markdown and the
+// subset of HTML that Geni displays.
+//
+// The Markdown understood is a subset of CommonMark that maps onto that HTML:
+//
+//	# Heading          <h1>Heading</h1>, down to ###### for <h6>
+//	paragraphs         <p>…</p>, separated by blank lines
+//	- item, * item     <ul><li>…</li></ul>, one item per line; indented lines
+//	                   continue the item
+//	1. item            <ol><li>…</li></ol>
+//	> quote            <blockquote>…</blockquote>, holding Markdown itself
+//	---                <hr>
+//	**strong**         <strong>strong</strong>, or __strong__
+//	*emphasis*         <em>emphasis</em>, or _emphasis_
+//	[text](url)        <a href="url">text</a>
+//	line\              <br>, a hard line break
+//
+// A backslash before punctuation keeps it literal. Anything else, HTML
+// included, is text.
+//
+// FromHTML reads Geni's HTML back into Markdown in the canonical form of the
+// list above, so that ToHTML(FromHTML(h)) displays as h does.
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	headingLine     = regexp.MustCompile(`^(#{1,6}) +(.*)$`)
+	ruleLine        = regexp.MustCompile(`^ {0,3}(-{3,}|\*{3,}|_{3,}) *$`)
+	bulletLine      = regexp.MustCompile(`^[-*+] +(.*)$`)
+	numberLine      = regexp.MustCompile(`^\d{1,9}\. +(.*)$`)
+	quoteLine       = regexp.MustCompile(`^> ?(.*)$`)
+	continuedLine   = regexp.MustCompile(`^ +(.*)$`)
+	lineStartEscape = regexp.MustCompile(`^(#|>|[-+] |\d+\.( |$)|-{3,} *$)`)
+)
+
+// ToHTML returns the HTML Geni displays for the Markdown text md.
+func ToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	return strings.Join(blocksToHTML(lines), "\n")
+}
+
+// blocksToHTML returns the HTML of each block of Markdown in lines.
+func blocksToHTML(lines []string) []string {
+	var blocks []string
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case ruleLine.MatchString(line):
+			blocks = append(blocks, "<hr>")
+			i++
+		case headingLine.MatchString(line):
+			m := headingLine.FindStringSubmatch(line)
+			tag := "h" + strconv.Itoa(len(m[1]))
+			blocks = append(blocks, "<"+tag+">"+inlineToHTML(strings.TrimSpace(m[2]))+"</"+tag+">")
+			i++
+		case quoteLine.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quoteLine.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quoteLine.FindStringSubmatch(lines[i])[1])
+			}
+			blocks = append(blocks, "<blockquote>\n"+strings.Join(blocksToHTML(quoted), "\n")+"\n</blockquote>")
+		case bulletLine.MatchString(line), numberLine.MatchString(line):
+			item, tag := bulletLine, "ul"
+			if !bulletLine.MatchString(line) {
+				item, tag = numberLine, "ol"
+			}
+			list := []string{"<" + tag + ">"}
+			for i < len(lines) && item.MatchString(lines[i]) {
+				text := []string{item.FindStringSubmatch(lines[i])[1]}
+				for i++; i < len(lines) && continuedLine.MatchString(lines[i]) && strings.TrimSpace(lines[i]) != ""; i++ {
+					text = append(text, continuedLine.FindStringSubmatch(lines[i])[1])
+				}
+				list = append(list, "<li>"+inlineToHTML(strings.Join(text, "\n"))+"</li>")
+			}
+			blocks = append(blocks, strings.Join(append(list, "</"+tag+">"), "\n"))
+		default:
+			text := []string{strings.TrimLeft(line, " ")}
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]); i++ {
+				text = append(text, strings.TrimLeft(lines[i], " "))
+			}
+			blocks = append(blocks, "<p>"+inlineToHTML(strings.Join(text, "\n"))+"</p>")
+		}
+	}
+	return blocks
+}
+
+// startsBlock reports whether line begins a block other than a paragraph,
+// ending the paragraph before it.
+func startsBlock(line string) bool {
+	return ruleLine.MatchString(line) || headingLine.MatchString(line) || quoteLine.MatchString(line) ||
+		bulletLine.MatchString(line) || numberLine.MatchString(line)
+}
+
+// inlineToHTML returns the HTML of the Markdown text of a block.
+func inlineToHTML(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			b.WriteString("<br>")
+			i++
+		case c == '\\' && i+1 < len(text) && isPunctuation(text[i+1]):
+			b.WriteString(escapeText(text[i+1 : i+2]))
+			i += 2
+		case (c == '*' || c == '_') && strings.HasPrefix(text[i:], strings.Repeat(string(c), 2)):
+			if end := closing(text, i, text[i:i+2]); end > 0 {
+				b.WriteString("<strong>" + inlineToHTML(text[i+2:end]) + "</strong>")
+				i = end + 2
+				continue
+			}
+			b.WriteString(text[i : i+2])
+			i += 2
+		case c == '*' || c == '_':
+			if end := closing(text, i, text[i:i+1]); end > 0 {
+				b.WriteString("<em>" + inlineToHTML(text[i+1:end]) + "</em>")
+				i = end + 1
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		case c == '[':
+			if label, url, end, ok := link(text, i); ok {
+				b.WriteString(`<a href="` + strings.NewReplacer("&", "&amp;", `"`, "&quot;").Replace(url) + `">` + inlineToHTML(label) + "</a>")
+				i = end
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		default:
+			b.WriteString(escapeText(text[i : i+1]))
+			i++
+		}
+	}
+	return b.String()
+}
+
+// closing returns the index of the delimiter delim that closes the one at
+// open in text, or -1 when there is none. Underscores do not open or close
+// within a word, so snake_case stays as written.
+func closing(text string, open int, delim string) int {
+	start := open + len(delim)
+	if start >= len(text) || text[start] == ' ' || text[start] == '\n' {
+		return -1
+	}
+	if delim[0] == '_' && open > 0 && isWordByte(text[open-1]) {
+		return -1
+	}
+	for i := start; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if len(delim) == 1 && strings.HasPrefix(text[i:], delim+delim) {
+			// A strong span inside emphasis, such as *a **b** c*.
+			if end := closing(text, i, delim+delim); end > 0 {
+				i = end + 1
+				continue
+			}
+		}
+		if !strings.HasPrefix(text[i:], delim) || i == start || text[i-1] == ' ' || text[i-1] == '\n' {
+			continue
+		}
+		if delim[0] == '_' && i+len(delim) < len(text) && isWordByte(text[i+len(delim)]) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// link parses the link [label](url) at open in text, returning the index
+// just after it.
+func link(text string, open int) (label, url string, end int, ok bool) {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if !strings.HasPrefix(text[i+1:], "(") {
+				return "", "", 0, false
+			}
+			rest := text[i+2:]
+			paren := strings.IndexByte(rest, ')')
+			if paren <= 0 || strings.ContainsAny(rest[:paren], " \n") {
+				return "", "", 0, false
+			}
+			return text[open+1 : i], rest[:paren], i + 2 + paren + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+func isPunctuation(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// escapeText escapes the characters HTML gives a meaning to in text.
+func escapeText(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// FromHTML returns the Markdown for the HTML h, as Geni stores it.
+func FromHTML(h string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(h), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		// The parser only fails on reader errors, which a string reader has
+		// none of.
+		return h
+	}
+	return strings.Join(blocksFromHTML(nodes), "\n\n")
+}
+
+// blocksFromHTML returns the Markdown of each block of nodes. Inline nodes
+// between blocks make up a paragraph of their own.
+func blocksFromHTML(nodes []*html.Node) []string {
+	var blocks []string
+	var run []*html.Node
+	flush := func() {
+		if text := inlineFromHTML(run); text != "" {
+			blocks = append(blocks, escapeLineStarts(text))
+		}
+		run = nil
+	}
+
+	for _, n := range nodes {
+		if n.Type != html.ElementNode {
+			run = append(run, n)
+			continue
+		}
+		switch n.DataAtom {
+		case atom.P:
+			flush()
+			run = children(n)
+			flush()
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			flush()
+			if text := inlineFromHTML(children(n)); text != "" {
+				level := int(n.Data[1] - '0')
+				blocks = append(blocks, strings.Repeat("#", level)+" "+strings.ReplaceAll(text, "\\\n", " "))
+			}
+		case atom.Ul, atom.Ol:
+			flush()
+			if list := listFromHTML(n); list != "" {
+				blocks = append(blocks, list)
+			}
+		case atom.Blockquote:
+			flush()
+			if inner := blocksFromHTML(children(n)); len(inner) > 0 {
+				lines := strings.Split(strings.Join(inner, "\n\n"), "\n")
+				for i, line := range lines {
+					lines[i] = strings.TrimRight("> "+line, " ")
+				}
+				blocks = append(blocks, strings.Join(lines, "\n"))
+			}
+		case atom.Hr:
+			flush()
+			blocks = append(blocks, "---")
+		case atom.Div, atom.Section, atom.Article, atom.Table, atom.Tbody, atom.Tr, atom.Td, atom.Th, atom.Pre, atom.Li:
+			flush()
+			blocks = append(blocks, blocksFromHTML(children(n))...)
+		default:
+			run = append(run, n)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// listFromHTML returns the Markdown of the list n, with an item per line.
+func listFromHTML(n *html.Node) string {
+	var items []string
+	for _, item := range children(n) {
+		if item.DataAtom != atom.Li {
+			continue
+		}
+		text := strings.Join(blocksFromHTML(children(item)), "\n")
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(len(items)+1) + ". "
+		}
+		items = append(items, marker+strings.ReplaceAll(text, "\n", "\n"+strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// inlineFromHTML returns the Markdown of the inline nodes of a block, trimmed.
+func inlineFromHTML(nodes []*html.Node) string {
+	var w inlineWriter
+	for _, n := range nodes {
+		w.write(n)
+	}
+	return strings.TrimSpace(w.String())
+}
+
+// inlineWriter writes the Markdown of inline nodes.
+type inlineWriter struct {
+	strings.Builder
+	// afterBreak is set after a hard line break, whose newline the text after
+	// it may repeat.
+	afterBreak bool
+}
+
+func (w *inlineWriter) write(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		text := n.Data
+		if w.afterBreak {
+			text = strings.TrimPrefix(text, "\n")
+		}
+		w.afterBreak = false
+		w.WriteString(escapeMarkdown(text))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		w.WriteString("\\\n")
+		w.afterBreak = true
+	case atom.Strong, atom.B:
+		w.wrap(n, "**", "**")
+	case atom.Em, atom.I:
+		w.wrap(n, "*", "*")
+	case atom.A:
+		href := attribute(n, "href")
+		if href == "" {
+			w.writeChildren(n)
+			return
+		}
+		w.wrap(n, "[", "]("+strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(href)+")")
+	default:
+		w.writeChildren(n)
+	}
+}
+
+// wrap writes the children of n between before and after, or nothing when they
+// have no text.
+func (w *inlineWriter) wrap(n *html.Node, before, after string) {
+	inner := inlineFromHTML(children(n))
+	if inner == "" {
+		return
+	}
+	w.WriteString(before + inner + after)
+	w.afterBreak = false
+}
+
+func (w *inlineWriter) writeChildren(n *html.Node) {
+	for _, c := range children(n) {
+		w.write(c)
+	}
+}
+
+func children(n *html.Node) []*html.Node {
+	var nodes []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		nodes = append(nodes, c)
+	}
+	return nodes
+}
+
+func attribute(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// escapeMarkdown escapes the characters of text that Markdown gives a meaning
+// to anywhere in a line. An underscore within a word, as in snake_case, has
+// none.
+func escapeMarkdown(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '_' && i > 0 && i+1 < len(text) && isWordByte(text[i-1]) && isWordByte(text[i+1]):
+		case strings.IndexByte(`\*_[]`, c) >= 0:
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// escapeLineStarts escapes the characters that would start a block at the
+// beginning of a line of text, a paragraph.
+func escapeLineStarts(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if !lineStartEscape.MatchString(trimmed) {
+			continue
+		}
+		if dot := strings.IndexByte(trimmed, '.'); dot > 0 && trimmed[0] >= '0' && trimmed[0] <= '9' {
+			lines[i] = trimmed[:dot] + `\` + trimmed[dot:]
+		} else {
+			lines[i] = `\` + trimmed
+		}
+	}
+	return strings.Join(lines, "\n")
+}
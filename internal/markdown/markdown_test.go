@@ -0,0 +1,93 @@
+package markdown
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata from the converter's output")
+
+// golden returns the content of the golden file name in testdata, first
+// writing got to it with -update.
+func golden(t *testing.T, name, got string) string {
+	t.Helper()
+	file := filepath.Join("testdata", name)
+	if *updateGolden {
+		Expect(os.WriteFile(file, []byte(got+"\n"), 0o600)).To(Succeed())
+	}
+	return read(t, name)
+}
+
+func read(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	Expect(err).ToNot(HaveOccurred())
+	return strings.TrimSuffix(string(content), "\n")
+}
+
+func TestToHTML(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, source := range sources {
+		name := strings.TrimSuffix(filepath.Base(source), ".md")
+		if strings.HasPrefix(name, "from_") {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			RegisterTestingT(t)
+			md := read(t, name+".md")
+
+			h := ToHTML(md)
+
+			Expect(h).To(Equal(golden(t, name+".html", h)))
+			Expect(FromHTML(h)).To(Equal(md), "Markdown in canonical form reads back as written")
+		})
+	}
+}
+
+func TestFromHTML(t *testing.T) {
+	sources, err := filepath.Glob(filepath.Join("testdata", "from_*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, source := range sources {
+		name := strings.TrimSuffix(filepath.Base(source), ".html")
+		t.Run(name, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			md := FromHTML(read(t, name+".html"))
+
+			Expect(md).To(Equal(golden(t, name+".md", md)))
+			Expect(FromHTML(ToHTML(md))).To(Equal(md), "the Markdown of Geni's HTML is in canonical form")
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	RegisterTestingT(t)
+
+	for md, h := range map[string]string{
+		"plain":                 "<p>plain</p>",
+		"**unclosed":            "<p>**unclosed</p>",
+		"2 * 3 * 4":             "<p>2 * 3 * 4</p>",
+		"a_b_c":                 "<p>a_b_c</p>",
+		"_a_b_":                 "<p><em>a_b</em></p>",
+		"[no url]":              "<p>[no url]</p>",
+		"[spaced](a b)":         "<p>[spaced](a b)</p>",
+		`[quoted](a"b)`:         `<p><a href="a&quot;b">quoted</a></p>`,
+		"line\\\nbreak":         "<p>line<br>\nbreak</p>",
+		"<b>not html</b>":       "<p>&lt;b&gt;not html&lt;/b&gt;</p>",
+		"### heading with *em*": "<h3>heading with <em>em</em></h3>",
+	} {
+		Expect(ToHTML(md)).To(Equal(h), md)
+	}
+}
@@ -2,32 +2,55 @@ package internal
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"golang.org/x/oauth2"
 
 	"github.com/dmalch/go-geni"
 	"github.com/dmalch/go-geni/auth"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/datasource/orphanprofiles"
 	profiledatasource "github.com/dmalch/terraform-provider-genealogy/internal/datasource/profile"
 	"github.com/dmalch/terraform-provider-genealogy/internal/datasource/project"
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
 	"github.com/dmalch/terraform-provider-genealogy/internal/geniapp"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genicassette"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniweb"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/document"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/family"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/photo"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/profile"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
 )
 
-var _ provider.ProviderWithListResources = (*GeniProvider)(nil)
+var (
+	_ provider.ProviderWithListResources = (*GeniProvider)(nil)
+	_ io.Closer                          = (*GeniProvider)(nil)
+)
 
 // GeniProvider holds the configured API clients. State lives on the instance
 // (not in package globals) so each provider is self-contained: every
@@ -35,13 +58,56 @@ var _ provider.ProviderWithListResources = (*GeniProvider)(nil)
 type GeniProvider struct {
 	// once guards one-time creation of the clients and batch-processor
 	// goroutines; Configure may be invoked more than once on an instance.
-	once        sync.Once
-	client      *geni.Client
+	once sync.Once
+	// setupDiags are the errors once ran into. Every Configure reports them,
+	// not only the one that ran it.
+	setupDiags  diag.Diagnostics
+	client      *geniclient.Client
 	batchClient *genibatch.Client
+	// cassette is the recorder or replayer requests go through, if any.
+	cassette http.RoundTripper
+	// web is nil unless a Geni website session is configured.
+	web *geniweb.Client
+	// places is nil unless a gazetteer is configured.
+	places *gazetteer.Gazetteer
+
+	// apiBaseURL, when set, replaces Geni as the server every request goes to
+	// unless the configuration names one itself.
+	apiBaseURL string
+}
+
+// Option customises a GeniProvider built by New.
+type Option func(*GeniProvider)
+
+// WithAPIBaseURL sends every API request to baseURL instead of Geni — the
+// in-process fake server the acceptance tests run against, for instance. The
+// api_base_url attribute and GENI_API_BASE_URL take precedence over it.
+func WithAPIBaseURL(baseURL string) Option {
+	return func(p *GeniProvider) {
+		p.apiBaseURL = baseURL
+	}
+}
+
+func New(opts ...Option) provider.Provider {
+	p := &GeniProvider{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
-func New() provider.Provider {
-	return &GeniProvider{}
+// Close stops the batch processors started by Configure and waits for them to
+// exit, then closes the cassette being recorded, if any. The framework has no
+// shutdown hook, so main calls it once the server has stopped serving.
+func (p *GeniProvider) Close() error {
+	var errs []error
+	if p.batchClient != nil {
+		errs = append(errs, p.batchClient.Close())
+	}
+	if closer, ok := p.cassette.(io.Closer); ok {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
 }
 
 func (p *GeniProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -69,10 +135,81 @@ func (p *GeniProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Description: "Whether to use the Geni Sandbox environment. Can also be set with the GENI_USE_SANDBOX environment variable.",
 			},
+			"api_base_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The base URL of the Geni API, such as \"http://127.0.0.1:8080/\", to send requests to a recording proxy or a local stand-in instead of Geni. Request paths (\"api/...\") are appended to it. Can also be set with the GENI_API_BASE_URL environment variable. Defaults to https://www.geni.com/, or https://sandbox.geni.com/ under the sandbox environment.",
+			},
+			"oauth_base_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The base URL of the OAuth server the browser login uses (\"platform/oauth/...\" is appended to it). Can also be set with the GENI_OAUTH_BASE_URL environment variable. Defaults to `api_base_url` when that is set, and to Geni otherwise. Tokens are cached separately for every pair of base URLs, so a token issued by a stand-in server is never sent to Geni.",
+			},
 			"auto_update_merged_profiles": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Whether to automatically update merged profiles in the state",
 			},
+			"batch_size": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 50)},
+				Description: fmt.Sprintf("The maximum number of reads of one resource type combined into a single bulk request to Geni. Defaults to %d.", genibatch.DefaultBatchSize),
+			},
+			"batch_flush_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How long a partially filled batch of reads waits for more reads before it is sent anyway, as a Go duration string (e.g. \"200ms\"). Shorter intervals speed up small plans; longer ones send fewer requests during large refreshes. Defaults to %q.", genibatch.DefaultFlushInterval),
+			},
+			"batch_max_in_flight": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: fmt.Sprintf("The maximum number of bulk read requests outstanding at once, across all resource types. Further batches wait for one to finish. Defaults to %d.", genibatch.DefaultMaxInFlight),
+			},
+			"rate_limit": schema.Float64Attribute{
+				Optional:    true,
+				Validators:  []validator.Float64{float64validator.AtLeast(0.1)},
+				Description: fmt.Sprintf("The most requests per second the provider sends to Geni, across all resources. When Geni answers with 429 Too Many Requests or an Incapsula block, the provider halves its rate and raises it back gradually once the push-back stops. Unset, requests are not limited until Geni first pushes back; the provider then drops to %v requests per second, halves from there on further push-back, and lifts the limit again once it has recovered.", geniclient.PushBackRequestsPerSecond),
+			},
+			"rate_limit_burst": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: fmt.Sprintf("How many requests may be sent back to back, above `rate_limit`, after a quiet spell. Defaults to %d.", geniclient.DefaultBurst),
+			},
+			"read_cache_ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How long a read of a profile, union, document or photo is reused by later reads within the same Terraform operation, as a Go duration string (e.g. \"30s\"). Any write made by the provider clears the cache. \"0s\" disables it. Defaults to %q.", genibatch.DefaultCacheTTL),
+			},
+			"cassette": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a cassette file to record every Geni request and response to, or to replay them from, as set by `cassette_mode`. The access token is never written to the file. A recorded cassette captures exactly what the provider and Geni said to each other, which makes a bug report reproducible. Can also be set with the GENI_CASSETTE environment variable.",
+			},
+			"cassette_mode": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(cassetteRecord, cassetteReplay)},
+				Description: "Either \"record\", to send requests to Geni and write them with their responses to `cassette`, replacing the file, or \"replay\", to answer requests from `cassette` without contacting Geni, and without needing an access token. Can also be set with the GENI_CASSETTE_MODE environment variable. Defaults to \"record\" when `cassette` is set.",
+			},
+			"web_session": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Cookie header of a request to the Geni website from a browser you are logged in with, as copied from the browser's developer tools. It lets the provider make the changes the Geni API has no endpoint for, such as removing partners and children from a `geni_union` or changing a child's relationship to one, through the website instead. The website's endpoints are undocumented and may change without notice, and using them may go against Geni's terms of service. Can also be set with the GENI_WEB_SESSION environment variable.",
+			},
+			"strict_validation": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the consistency checks of resources, such as a `geni_profile` whose death is dated before its birth, fail the plan instead of only warning. Defaults to false. The checks run when planning, since `terraform validate` does not configure the provider.",
+			},
+			"max_lifespan": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: "The most years a `geni_profile` may live, from birth to death or, while alive, to today. A profile whose dates make it older is reported like the other consistency checks, when planning. Unset by default, which checks no lifespan.",
+			},
+			"gazetteer": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of a gazetteer file: a tab-separated list of places, such as a GeoNames extract, whose header names the columns id, place_name, city, county, state, country, latitude, longitude and alternate_names (other columns are ignored). A location's `place_id` references a place by id, whose names and coordinates then fill in the location. Can also be set with the GENI_GAZETTEER environment variable.",
+			},
+			"check_partner_genders": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether a `geni_union` or `geni_family` whose two partners have the same gender is reported like the other consistency checks. Defaults to false.",
+			},
+			"experimental_events": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether `geni_profile` and the `geni_profile` data source read and write the profile's own events in `events`. The Geni API documentation does not list the endpoints they use, which may change or go away without notice. Defaults to false, which leaves `events` null and fails a plan that sets it.",
+			},
 		},
 		Description: "This provider enables managing data on Geni.com through Terraform. It exposes configuration and resources that help automate genealogical information. This application uses the Geni API but is not endorsed, operated, or sponsored by Geni.com.",
 	}
@@ -97,7 +234,40 @@ func (p *GeniProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		useSandboxEnv = os.Getenv("GENI_USE_SANDBOX") == "true"
 	}
 
-	cacheFilePath, err := tokenCacheFilePath(useSandboxEnv)
+	batchConfig, diags := batchConfigFrom(cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoints, diags := p.endpointsFrom(cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cassette, diags := cassetteConfigFrom(cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// A replayed conversation needs no login: the replayer answers every
+	// request, and any token stands in for the redacted one.
+	if cassette.mode == cassetteReplay && accessToken == "" {
+		accessToken = "replay"
+	}
+
+	gazetteerPath := cfg.Gazetteer.ValueString()
+	if gazetteerPath == "" {
+		gazetteerPath = os.Getenv("GENI_GAZETTEER")
+	}
+
+	webSession := cfg.WebSession.ValueString()
+	if webSession == "" {
+		webSession = os.Getenv("GENI_WEB_SESSION")
+	}
+
+	cacheFilePath, err := tokenCacheFilePath(useSandboxEnv, endpoints)
 	if err != nil {
 		resp.Diagnostics.AddError("error getting token cache file path", err.Error())
 		return
@@ -108,31 +278,71 @@ func (p *GeniProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		ClientSecret: cfg.ClientSecret.ValueString(),
 	}, useSandboxEnv)
 
-	tokenSource := browserTokenSource(app, auth.GeniEndpoint(geni.BaseURL(useSandboxEnv)), cacheFilePath)
+	tokenSource := browserTokenSource(app, auth.GeniEndpoint(endpoints.oauthBaseURL(useSandboxEnv)), cacheFilePath)
 
 	if accessToken != "" {
 		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})
 	}
 
 	p.once.Do(func() {
-		p.client = geni.NewClient(tokenSource, useSandboxEnv)
-		p.batchClient = genibatch.NewClient(p.client)
-		go p.batchClient.UnionBulkProcessor(context.Background())
-		go p.batchClient.ProfileBulkProcessor(context.Background())
-		go p.batchClient.DocumentBulkProcessor(context.Background())
-		go p.batchClient.PhotoBulkProcessor(context.Background())
+		if cassette.mode != "" {
+			p.cassette, err = cassette.transport()
+			if err != nil {
+				p.setupDiags.AddAttributeError(tfpath.Root("cassette"), "Error opening cassette", err.Error())
+				return
+			}
+		}
+
+		p.client = geniclient.New(tokenSource, useSandboxEnv, geniclient.Options{
+			RequestsPerSecond: cfg.RateLimit.ValueFloat64(),
+			Burst:             int(cfg.RateLimitBurst.ValueInt64()),
+			BaseURL:           endpoints.api,
+			Transport:         p.cassette,
+		})
+		p.batchClient = genibatch.NewClient(p.client, batchConfig)
+		// The processors outlive this request, so they are detached from its
+		// cancellation; Close stops them when the provider shuts down. The
+		// context keeps its logger, which Close uses to report cache statistics.
+		p.batchClient.Start(context.WithoutCancel(ctx))
+
+		if webSession != "" {
+			p.web, err = geniweb.New(webSession, webBaseURL(useSandboxEnv, endpoints), webHTTPClient(p.client, p.cassette))
+			if err != nil {
+				p.setupDiags.AddAttributeError(tfpath.Root("web_session"), "Invalid Geni website session", err.Error())
+				return
+			}
+		}
+
+		if gazetteerPath != "" {
+			p.places, err = gazetteer.Load(gazetteerPath)
+			if err != nil {
+				p.setupDiags.AddAttributeError(tfpath.Root("gazetteer"), "Error loading gazetteer", err.Error())
+				return
+			}
+		}
 	})
+	resp.Diagnostics.Append(p.setupDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.ResourceData = &config.ClientData{
 		Client:                   p.client,
 		BatchClient:              p.batchClient,
 		AutoUpdateMergedProfiles: cfg.AutoUpdateMergedProfiles.ValueBool(),
+		Web:                      p.web,
+		StrictValidation:         cfg.StrictValidation.ValueBool(),
+		MaxLifespan:              cfg.MaxLifespan.ValueInt64(),
+		CheckPartnerGenders:      cfg.CheckPartnerGenders.ValueBool(),
+		Gazetteer:                p.places,
+		ExperimentalEvents:       cfg.ExperimentalEvents.ValueBool(),
 	}
 
 	resp.DataSourceData = &config.ClientData{
 		Client:                   p.client,
 		BatchClient:              p.batchClient,
 		AutoUpdateMergedProfiles: cfg.AutoUpdateMergedProfiles.ValueBool(),
+		ExperimentalEvents:       cfg.ExperimentalEvents.ValueBool(),
 	}
 
 	resp.ListResourceData = &config.ClientData{
@@ -140,6 +350,185 @@ func (p *GeniProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 }
 
+// batchConfigFrom resolves the batch client settings. Attributes left unset
+// stay zero, which genibatch replaces with its defaults.
+func batchConfigFrom(cfg config.GeniProviderConfig) (genibatch.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	batchConfig := genibatch.Config{
+		BatchSize:   int(cfg.BatchSize.ValueInt64()),
+		MaxInFlight: int(cfg.BatchMaxInFlight.ValueInt64()),
+	}
+
+	if interval := cfg.BatchFlushInterval.ValueString(); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(tfpath.Root("batch_flush_interval"),
+				"Invalid batch flush interval",
+				fmt.Sprintf("%q is not a positive Go duration such as \"500ms\" or \"2s\".", interval))
+			return batchConfig, diags
+		}
+		batchConfig.FlushInterval = d
+	}
+
+	if ttl := cfg.ReadCacheTTL.ValueString(); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d < 0 {
+			diags.AddAttributeError(tfpath.Root("read_cache_ttl"),
+				"Invalid read cache TTL",
+				fmt.Sprintf("%q is not a non-negative Go duration such as \"0s\" or \"10m\".", ttl))
+			return batchConfig, diags
+		}
+		// Zero would mean "use the default" to genibatch; here it is the
+		// documented way to turn the cache off.
+		if d == 0 {
+			d = -1
+		}
+		batchConfig.CacheTTL = d
+	}
+
+	return batchConfig, diags
+}
+
+// endpoints are the servers the provider talks to. A nil URL stands for Geni
+// itself, production or sandbox as configured.
+type endpoints struct {
+	api   *url.URL
+	oauth *url.URL
+}
+
+// oauthBaseURL returns the base URL auth.GeniEndpoint builds the OAuth
+// endpoint from.
+func (e endpoints) oauthBaseURL(useSandboxEnv bool) string {
+	if e.oauth == nil {
+		return geni.BaseURL(useSandboxEnv)
+	}
+	return e.oauth.String()
+}
+
+// endpointsFrom resolves the API and OAuth base URLs. Each comes from its
+// attribute, then its environment variable; the API falls back to the URL the
+// provider was built with, and OAuth to the API's, since a proxy or stand-in
+// usually serves both.
+func (p *GeniProvider) endpointsFrom(cfg config.GeniProviderConfig) (endpoints, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var e endpoints
+
+	apiBaseURL := cfg.APIBaseURL.ValueString()
+	if apiBaseURL == "" {
+		apiBaseURL = os.Getenv("GENI_API_BASE_URL")
+	}
+	if apiBaseURL == "" {
+		apiBaseURL = p.apiBaseURL
+	}
+	if apiBaseURL != "" {
+		e.api = parseBaseURL(tfpath.Root("api_base_url"), apiBaseURL, &diags)
+	}
+
+	oauthBaseURL := cfg.OAuthBaseURL.ValueString()
+	if oauthBaseURL == "" {
+		oauthBaseURL = os.Getenv("GENI_OAUTH_BASE_URL")
+	}
+	if oauthBaseURL != "" {
+		e.oauth = parseBaseURL(tfpath.Root("oauth_base_url"), oauthBaseURL, &diags)
+	} else {
+		e.oauth = e.api
+	}
+
+	return e, diags
+}
+
+// parseBaseURL parses an absolute http(s) URL and gives it the trailing slash
+// the Geni paths are appended to. A malformed URL is reported against attr.
+func parseBaseURL(attr tfpath.Path, raw string, diags *diag.Diagnostics) *url.URL {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		diags.AddAttributeError(attr, "Invalid base URL",
+			fmt.Sprintf("%q is not an absolute http or https URL such as \"http://127.0.0.1:8080/\".", raw))
+		return nil
+	}
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+	return parsed
+}
+
+// Cassette modes.
+const (
+	cassetteRecord = "record"
+	cassetteReplay = "replay"
+)
+
+// cassetteConfig says whether, and how, requests go through a cassette.
+type cassetteConfig struct {
+	path string
+	mode string
+}
+
+// cassetteConfigFrom resolves the cassette settings from their attributes and
+// environment variables. The mode defaults to recording once a path is given.
+func cassetteConfigFrom(cfg config.GeniProviderConfig) (cassetteConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	c := cassetteConfig{
+		path: cfg.Cassette.ValueString(),
+		mode: cfg.CassetteMode.ValueString(),
+	}
+	if c.path == "" {
+		c.path = os.Getenv("GENI_CASSETTE")
+	}
+	if c.mode == "" {
+		c.mode = os.Getenv("GENI_CASSETTE_MODE")
+	}
+
+	switch {
+	case c.mode != "" && c.mode != cassetteRecord && c.mode != cassetteReplay:
+		diags.AddAttributeError(tfpath.Root("cassette_mode"), "Invalid cassette mode",
+			fmt.Sprintf("%q is neither %q nor %q.", c.mode, cassetteRecord, cassetteReplay))
+	case c.mode != "" && c.path == "":
+		diags.AddAttributeError(tfpath.Root("cassette"), "Missing cassette",
+			fmt.Sprintf("cassette_mode is %q but no cassette file is set.", c.mode))
+	case c.path != "" && c.mode == "":
+		c.mode = cassetteRecord
+	}
+
+	return c, diags
+}
+
+// transport opens the cassette's recorder or replayer.
+func (c cassetteConfig) transport() (http.RoundTripper, error) {
+	if c.mode == cassetteReplay {
+		replayer, err := genicassette.Load(c.path)
+		if err != nil {
+			return nil, err
+		}
+		return replayer, nil
+	}
+
+	recorder, err := genicassette.NewRecorder(c.path, http.DefaultTransport)
+	if err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// webBaseURL returns the Geni website the web session belongs to: the API's
+// server, which serves both.
+func webBaseURL(useSandboxEnv bool, e endpoints) string {
+	if e.api == nil {
+		return geni.BaseURL(useSandboxEnv)
+	}
+	return e.api.String()
+}
+
+// webHTTPClient returns the HTTP client website requests are sent with. They
+// wait for the API client's rate limiter, so both share one budget, are
+// bounded by the same timeout, and go through the cassette as well when there
+// is one; the cassette redacts the session cookies and form tokens.
+func webHTTPClient(client *geniclient.Client, cassette http.RoundTripper) *http.Client {
+	return &http.Client{Timeout: geniclient.RequestTimeout, Transport: client.Limit(cassette)}
+}
+
 // browserTokenSource builds the token source behind an interactive
 // login.
 //
@@ -172,18 +561,31 @@ func browserTokenSource(app geniapp.Credentials, endpoint oauth2.Endpoint, cache
 		auth.NewRefreshingCachingTokenSource(cacheFilePath, codeSource, codeSource))
 }
 
-func tokenCacheFilePath(useSandboxEnv bool) (string, error) {
+// tokenCacheFilePath returns the file the token for the given environment and
+// endpoints is cached in. Geni's own endpoints keep the file names they always
+// had; overridden ones get a file named after a hash of their base URLs, so a
+// token is only ever reused against the servers that issued it.
+func tokenCacheFilePath(useSandboxEnv bool, e endpoints) (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("error getting user home directory: %w", err)
 	}
 
-	cacheFilePath := path.Join(homeDir, ".genealogy", "geni_token.json")
+	name := "geni_token"
 	if useSandboxEnv {
-		cacheFilePath = path.Join(homeDir, ".genealogy", "geni_sandbox_token.json")
+		name = "geni_sandbox_token"
+	}
+
+	if e.api != nil || e.oauth != nil {
+		var apiBaseURL string
+		if e.api != nil {
+			apiBaseURL = e.api.String()
+		}
+		sum := sha256.Sum256([]byte(apiBaseURL + "\n" + e.oauthBaseURL(useSandboxEnv)))
+		name += "_" + hex.EncodeToString(sum[:8])
 	}
 
-	return cacheFilePath, nil
+	return path.Join(homeDir, ".genealogy", name+".json"), nil
 }
 
 func (p *GeniProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -192,6 +594,7 @@ func (p *GeniProvider) Resources(_ context.Context) []func() resource.Resource {
 		union.NewUnionResource,
 		document.NewResource,
 		photo.NewResource,
+		family.NewFamilyResource,
 	}
 }
 
@@ -199,6 +602,7 @@ func (p *GeniProvider) DataSources(_ context.Context) []func() datasource.DataSo
 	return []func() datasource.DataSource{
 		project.NewDataSource,
 		profiledatasource.NewDataSource,
+		orphanprofiles.NewDataSource,
 	}
 }
 
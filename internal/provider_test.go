@@ -2,6 +2,7 @@ package internal
 
 import (
 	"encoding/json"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -12,12 +13,17 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	. "github.com/onsi/gomega"
 	"golang.org/x/oauth2"
 
+	geniprofile "github.com/dmalch/go-geni/profile"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
 	"github.com/dmalch/terraform-provider-genealogy/internal/geniapp"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genifake"
 )
 
 func TestTokenCacheFilePath(t *testing.T) {
@@ -29,7 +35,7 @@ func TestTokenCacheFilePath(t *testing.T) {
 	t.Run("production environment", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		result, err := tokenCacheFilePath(false)
+		result, err := tokenCacheFilePath(false, endpoints{})
 
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(Equal(path.Join(homeDir, ".genealogy", "geni_token.json")))
@@ -38,11 +44,110 @@ func TestTokenCacheFilePath(t *testing.T) {
 	t.Run("sandbox environment", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		result, err := tokenCacheFilePath(true)
+		result, err := tokenCacheFilePath(true, endpoints{})
 
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(Equal(path.Join(homeDir, ".genealogy", "geni_sandbox_token.json")))
 	})
+
+	t.Run("overridden endpoints get a cache file of their own", func(t *testing.T) {
+		RegisterTestingT(t)
+		proxy := endpoints{api: mustParseURL(t, "http://127.0.0.1:8080/"), oauth: mustParseURL(t, "http://127.0.0.1:8080/")}
+		other := endpoints{api: mustParseURL(t, "http://127.0.0.1:9090/"), oauth: mustParseURL(t, "http://127.0.0.1:9090/")}
+		apiOnly := endpoints{api: mustParseURL(t, "http://127.0.0.1:8080/")}
+
+		proxyPath, err := tokenCacheFilePath(true, proxy)
+		Expect(err).ToNot(HaveOccurred())
+		otherPath, err := tokenCacheFilePath(true, other)
+		Expect(err).ToNot(HaveOccurred())
+		apiOnlyPath, err := tokenCacheFilePath(true, apiOnly)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(proxyPath).To(HavePrefix(path.Join(homeDir, ".genealogy", "geni_sandbox_token_")))
+		Expect(proxyPath).To(HaveSuffix(".json"))
+		Expect([]string{proxyPath, otherPath, apiOnlyPath}).To(HaveEach(Not(HaveSuffix("geni_sandbox_token.json"))))
+		Expect(proxyPath).ToNot(Equal(otherPath))
+		Expect(proxyPath).ToNot(Equal(apiOnlyPath))
+	})
+}
+
+func TestEndpointsFrom(t *testing.T) {
+	t.Run("Geni is used when nothing is overridden", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "")
+		t.Setenv("GENI_OAUTH_BASE_URL", "")
+
+		e, diags := (&GeniProvider{}).endpointsFrom(config.GeniProviderConfig{})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(e.api).To(BeNil())
+		Expect(e.oauthBaseURL(true)).To(Equal("https://sandbox.geni.com/"))
+		Expect(e.oauthBaseURL(false)).To(Equal("https://www.geni.com/"))
+	})
+
+	t.Run("the attribute wins over the environment and the provider option", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "http://env.example/")
+		t.Setenv("GENI_OAUTH_BASE_URL", "")
+
+		e, diags := (&GeniProvider{apiBaseURL: "http://option.example/"}).endpointsFrom(config.GeniProviderConfig{
+			APIBaseURL: types.StringValue("http://attribute.example/"),
+		})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(e.api.String()).To(Equal("http://attribute.example/"))
+	})
+
+	t.Run("the environment wins over the provider option", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "http://env.example/")
+		t.Setenv("GENI_OAUTH_BASE_URL", "")
+
+		e, diags := (&GeniProvider{apiBaseURL: "http://option.example/"}).endpointsFrom(config.GeniProviderConfig{})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(e.api.String()).To(Equal("http://env.example/"))
+	})
+
+	t.Run("OAuth follows the API base URL unless set itself", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "")
+		t.Setenv("GENI_OAUTH_BASE_URL", "")
+
+		following, diags := (&GeniProvider{}).endpointsFrom(config.GeniProviderConfig{
+			APIBaseURL: types.StringValue("http://127.0.0.1:8080/geni"),
+		})
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(following.oauthBaseURL(false)).To(Equal("http://127.0.0.1:8080/geni/"))
+
+		t.Setenv("GENI_OAUTH_BASE_URL", "https://login.example/")
+		separate, diags := (&GeniProvider{}).endpointsFrom(config.GeniProviderConfig{
+			APIBaseURL: types.StringValue("http://127.0.0.1:8080/"),
+		})
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(separate.oauthBaseURL(false)).To(Equal("https://login.example/"))
+	})
+
+	t.Run("a URL that is not absolute http(s) is an attribute error", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "")
+		t.Setenv("GENI_OAUTH_BASE_URL", "")
+
+		for _, raw := range []string{"127.0.0.1:8080", "/api", "ftp://example.com/", "not a url"} {
+			_, diags := (&GeniProvider{}).endpointsFrom(config.GeniProviderConfig{OAuthBaseURL: types.StringValue(raw)})
+
+			Expect(diags.HasError()).To(BeTrue(), raw)
+		}
+	})
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", raw, err)
+	}
+	return parsed
 }
 
 func TestConfigure(t *testing.T) {
@@ -74,16 +179,329 @@ func TestConfigure(t *testing.T) {
 		Expect(p2.client).ToNot(BeNil())
 		Expect(p1.client).ToNot(BeIdenticalTo(p2.client))
 	})
+
+	t.Run("Close stops the batch client started by Configure", func(t *testing.T) {
+		RegisterTestingT(t)
+		p := newProvider(t)
+		Expect(configureProvider(t, p, "test-token").Diagnostics.HasError()).To(BeFalse())
+
+		Expect(p.Close()).To(Succeed())
+
+		_, err := p.batchClient.GetProfile(t.Context(), "profile-1")
+		Expect(err).To(MatchError(genibatch.ErrClosed))
+	})
+
+	t.Run("WithAPIBaseURL sends requests to the given server", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "")
+		fake := genifake.NewServer()
+		t.Cleanup(fake.Close)
+		p := newProvider(t, WithAPIBaseURL(fake.URL()))
+		Expect(configureProvider(t, p, "test-token").Diagnostics.HasError()).To(BeFalse())
+
+		created, err := p.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{Gender: new("female")}})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created.ID).To(HavePrefix("profile-"))
+	})
+
+	t.Run("api_base_url sends requests to the given server", func(t *testing.T) {
+		RegisterTestingT(t)
+		fake := genifake.NewServer()
+		t.Cleanup(fake.Close)
+		p := newProvider(t)
+		Expect(configureProviderWith(t, p, map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+			"api_base_url": tftypes.NewValue(tftypes.String, fake.URL()),
+		}).Diagnostics.HasError()).To(BeFalse())
+
+		created, err := p.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{Gender: new("male")}})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created.ID).To(HavePrefix("profile-"))
+	})
+
+	t.Run("an invalid API base URL is reported", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "")
+		p := newProvider(t, WithAPIBaseURL("not a url"))
+
+		resp := configureProvider(t, p, "test-token")
+
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		Expect(p.client).To(BeNil())
+	})
+
+	t.Run("a cassette recorded through one provider replays through another without a token", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_API_BASE_URL", "")
+		t.Setenv("GENI_ACCESS_TOKEN", "")
+		fake := genifake.NewServer()
+		t.Cleanup(fake.Close)
+		cassette := path.Join(t.TempDir(), "cassette.jsonl")
+
+		recording := newProvider(t, WithAPIBaseURL(fake.URL()))
+		Expect(configureProviderWith(t, recording, map[string]tftypes.Value{
+			"access_token":  tftypes.NewValue(tftypes.String, "test-token"),
+			"cassette":      tftypes.NewValue(tftypes.String, cassette),
+			"cassette_mode": tftypes.NewValue(tftypes.String, "record"),
+		}).Diagnostics.HasError()).To(BeFalse())
+		created, err := recording.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{Gender: new("female")}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recording.Close()).To(Succeed())
+		fake.Close()
+
+		replaying := newProvider(t)
+		Expect(configureProviderWith(t, replaying, map[string]tftypes.Value{
+			"cassette":      tftypes.NewValue(tftypes.String, cassette),
+			"cassette_mode": tftypes.NewValue(tftypes.String, "replay"),
+		}).Diagnostics.HasError()).To(BeFalse())
+		replayed, err := replaying.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{Gender: new("female")}})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(replayed.ID).To(Equal(created.ID))
+	})
+
+	t.Run("a website session is passed to the resources only when set", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_WEB_SESSION", "")
+
+		without := configureProvider(t, newProvider(t), "test-token")
+		Expect(without.Diagnostics.HasError()).To(BeFalse())
+		data, ok := without.ResourceData.(*config.ClientData)
+		Expect(ok).To(BeTrue())
+		Expect(data.Web).To(BeNil())
+
+		p := newProvider(t)
+		with := configureProviderWith(t, p, map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+			"web_session":  tftypes.NewValue(tftypes.String, "_geni_session=abc"),
+		})
+		Expect(with.Diagnostics.HasError()).To(BeFalse())
+		data, ok = with.ResourceData.(*config.ClientData)
+		Expect(ok).To(BeTrue())
+		Expect(data.Web).To(BeIdenticalTo(p.web))
+		Expect(p.web).ToNot(BeNil())
+	})
+
+	t.Run("the validation settings are passed to the resources", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := configureProviderWith(t, newProvider(t), map[string]tftypes.Value{
+			"access_token":          tftypes.NewValue(tftypes.String, "test-token"),
+			"strict_validation":     tftypes.NewValue(tftypes.Bool, true),
+			"max_lifespan":          tftypes.NewValue(tftypes.Number, 120),
+			"check_partner_genders": tftypes.NewValue(tftypes.Bool, true),
+		})
+		Expect(resp.Diagnostics.HasError()).To(BeFalse())
+		data, ok := resp.ResourceData.(*config.ClientData)
+		Expect(ok).To(BeTrue())
+		Expect(data.StrictValidation).To(BeTrue())
+		Expect(data.MaxLifespan).To(Equal(int64(120)))
+		Expect(data.CheckPartnerGenders).To(BeTrue())
+	})
+
+	t.Run("a gazetteer is loaded and passed to the resources", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_GAZETTEER", "")
+		places := path.Join(t.TempDir(), "places.tsv")
+		Expect(os.WriteFile(places, []byte("id\tcity\n524901\tMoscow\n"), 0o600)).To(Succeed())
+
+		resp := configureProviderWith(t, newProvider(t), map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+			"gazetteer":    tftypes.NewValue(tftypes.String, places),
+		})
+		Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+		data, ok := resp.ResourceData.(*config.ClientData)
+		Expect(ok).To(BeTrue())
+		Expect(data.Gazetteer).ToNot(BeNil())
+		Expect(data.Gazetteer.Len()).To(Equal(1))
+
+		missing := configureProviderWith(t, newProvider(t), map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+			"gazetteer":    tftypes.NewValue(tftypes.String, path.Join(t.TempDir(), "missing.tsv")),
+		})
+		Expect(missing.Diagnostics.HasError()).To(BeTrue())
+		Expect(missing.Diagnostics.Errors()[0].Summary()).To(Equal("Error loading gazetteer"))
+	})
+
+	t.Run("a website session without cookies is reported", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := configureProviderWith(t, newProvider(t), map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+			"web_session":  tftypes.NewValue(tftypes.String, "not a cookie"),
+		})
+
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		Expect(resp.Diagnostics.Errors()[0].Summary()).To(Equal("Invalid Geni website session"))
+	})
+
+	t.Run("every Configure reports an error the first one ran into", func(t *testing.T) {
+		RegisterTestingT(t)
+		p := newProvider(t)
+		attrs := map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+			"web_session":  tftypes.NewValue(tftypes.String, "not a cookie"),
+		}
+
+		first := configureProviderWith(t, p, attrs)
+		second := configureProviderWith(t, p, attrs)
+
+		Expect(first.Diagnostics.HasError()).To(BeTrue())
+		Expect(second.Diagnostics.HasError()).To(BeTrue())
+		Expect(second.Diagnostics.Errors()[0].Summary()).To(Equal("Invalid Geni website session"))
+		Expect(second.ResourceData).To(BeNil())
+	})
+
+	t.Run("Close before Configure is a no-op", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(newProvider(t).Close()).To(Succeed())
+	})
+}
+
+func TestBatchConfigFrom(t *testing.T) {
+	t.Run("unset attributes leave genibatch defaults in charge", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		cfg, diags := batchConfigFrom(config.GeniProviderConfig{})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(cfg).To(Equal(genibatch.Config{}))
+	})
+
+	t.Run("set attributes are passed through", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		cfg, diags := batchConfigFrom(config.GeniProviderConfig{
+			BatchSize:          types.Int64Value(20),
+			BatchFlushInterval: types.StringValue("250ms"),
+			BatchMaxInFlight:   types.Int64Value(2),
+		})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(cfg).To(Equal(genibatch.Config{BatchSize: 20, FlushInterval: 250 * time.Millisecond, MaxInFlight: 2}))
+	})
+
+	t.Run("an unparsable flush interval is an attribute error", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		_, diags := batchConfigFrom(config.GeniProviderConfig{BatchFlushInterval: types.StringValue("soon")})
+
+		Expect(diags.HasError()).To(BeTrue())
+	})
+
+	t.Run("a non-positive flush interval is an attribute error", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		_, diags := batchConfigFrom(config.GeniProviderConfig{BatchFlushInterval: types.StringValue("0s")})
+
+		Expect(diags.HasError()).To(BeTrue())
+	})
+
+	t.Run("a read cache TTL is passed through", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		cfg, diags := batchConfigFrom(config.GeniProviderConfig{ReadCacheTTL: types.StringValue("10m")})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(cfg.CacheTTL).To(Equal(10 * time.Minute))
+	})
+
+	t.Run("a zero read cache TTL disables the cache", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		cfg, diags := batchConfigFrom(config.GeniProviderConfig{ReadCacheTTL: types.StringValue("0s")})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(cfg.CacheTTL).To(BeNumerically("<", 0))
+	})
+
+	t.Run("a negative or unparsable read cache TTL is an attribute error", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for _, ttl := range []string{"-1s", "forever"} {
+			_, diags := batchConfigFrom(config.GeniProviderConfig{ReadCacheTTL: types.StringValue(ttl)})
+
+			Expect(diags.HasError()).To(BeTrue(), ttl)
+		}
+	})
+
+	t.Run("Configure reports an invalid flush interval", func(t *testing.T) {
+		RegisterTestingT(t)
+		p := newProvider(t)
+
+		resp := configureProviderWith(t, p, map[string]tftypes.Value{
+			"access_token":         tftypes.NewValue(tftypes.String, "test-token"),
+			"batch_flush_interval": tftypes.NewValue(tftypes.String, "soon"),
+		})
+
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		Expect(p.batchClient).To(BeNil())
+	})
+}
+
+func TestCassetteConfigFrom(t *testing.T) {
+	t.Run("no cassette by default", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_CASSETTE", "")
+		t.Setenv("GENI_CASSETTE_MODE", "")
+
+		c, diags := cassetteConfigFrom(config.GeniProviderConfig{})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(c).To(Equal(cassetteConfig{}))
+	})
+
+	t.Run("a cassette path alone records", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_CASSETTE", "bug.jsonl")
+		t.Setenv("GENI_CASSETTE_MODE", "")
+
+		c, diags := cassetteConfigFrom(config.GeniProviderConfig{})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(c).To(Equal(cassetteConfig{path: "bug.jsonl", mode: cassetteRecord}))
+	})
+
+	t.Run("attributes win over the environment", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_CASSETTE", "env.jsonl")
+		t.Setenv("GENI_CASSETTE_MODE", "record")
+
+		c, diags := cassetteConfigFrom(config.GeniProviderConfig{
+			Cassette:     types.StringValue("attribute.jsonl"),
+			CassetteMode: types.StringValue("replay"),
+		})
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(c).To(Equal(cassetteConfig{path: "attribute.jsonl", mode: cassetteReplay}))
+	})
+
+	t.Run("a mode without a cassette, or an unknown mode, is an attribute error", func(t *testing.T) {
+		RegisterTestingT(t)
+		t.Setenv("GENI_CASSETTE", "")
+
+		t.Setenv("GENI_CASSETTE_MODE", "replay")
+		_, diags := cassetteConfigFrom(config.GeniProviderConfig{})
+		Expect(diags.HasError()).To(BeTrue())
+
+		t.Setenv("GENI_CASSETTE_MODE", "rewind")
+		_, diags = cassetteConfigFrom(config.GeniProviderConfig{Cassette: types.StringValue("bug.jsonl")})
+		Expect(diags.HasError()).To(BeTrue())
+	})
 }
 
 // newProvider returns a fresh *GeniProvider, failing the test if New ever
 // returns a different concrete type.
-func newProvider(t *testing.T) *GeniProvider {
+func newProvider(t *testing.T, opts ...Option) *GeniProvider {
 	t.Helper()
-	p, ok := New().(*GeniProvider)
+	p, ok := New(opts...).(*GeniProvider)
 	if !ok {
 		t.Fatal("New() did not return a *GeniProvider")
 	}
+	t.Cleanup(func() { _ = p.Close() })
 	return p
 }
 
@@ -91,19 +509,32 @@ func newProvider(t *testing.T) *GeniProvider {
 // given access token (every other attribute null), so the run stays offline:
 // a static token skips the OAuth flow entirely.
 func configureProvider(t *testing.T, p *GeniProvider, accessToken string) *provider.ConfigureResponse {
+	t.Helper()
+	return configureProviderWith(t, p, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, accessToken),
+	})
+}
+
+// configureProviderWith drives p.Configure with the given attributes set and
+// every other attribute null.
+func configureProviderWith(t *testing.T, p *GeniProvider, attrs map[string]tftypes.Value) *provider.ConfigureResponse {
 	t.Helper()
 	ctx := t.Context()
 
 	var schemaResp provider.SchemaResponse
 	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
 
-	raw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
-		"access_token":                tftypes.NewValue(tftypes.String, accessToken),
-		"client_id":                   tftypes.NewValue(tftypes.String, nil),
-		"client_secret":               tftypes.NewValue(tftypes.String, nil),
-		"use_sandbox_env":             tftypes.NewValue(tftypes.Bool, nil),
-		"auto_update_merged_profiles": tftypes.NewValue(tftypes.Bool, nil),
-	})
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("provider schema is not an object")
+	}
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	maps.Copy(values, attrs)
+
+	raw := tftypes.NewValue(objectType, values)
 
 	resp := &provider.ConfigureResponse{}
 	p.Configure(ctx, provider.ConfigureRequest{
@@ -18,14 +18,17 @@ func ValueFrom(ctx context.Context, response *genidocument.Document, model *Reso
 	model.ID = types.StringValue(response.ID)
 	model.Title = types.StringValue(response.Title)
 	model.SourceUrl = types.StringPointerValue(response.SourceUrl)
-	model.Description = types.StringPointerValue(response.Description)
+	description, historical := event.SplitHistorical(response.Description)
+	model.Description = types.StringPointerValue(description)
 	model.ContentType = types.StringPointerValue(response.ContentType)
 
-	dateObjectValue, diags := event.DateValueFrom(ctx, response.Date)
+	dateObjectValue, diags := event.DateValueFrom(ctx, response.Date, model.Date)
 	d.Append(diags...)
 	model.Date = dateObjectValue
 
-	locationObjectValue, diags := event.LocationValueFrom(ctx, response.Location)
+	locationObjectValue, diags := event.LocationValueFrom(ctx, response.Location, model.Location)
+	d.Append(diags...)
+	locationObjectValue, diags = event.WithHistorical(ctx, locationObjectValue, historical)
 	d.Append(diags...)
 	model.Location = locationObjectValue
 
@@ -63,7 +66,7 @@ func RequestFrom(ctx context.Context, resourceModel ResourceModel) (*genidocumen
 
 	documentRequest := &genidocument.Request{
 		Title:       resourceModel.Title.ValueString(),
-		Description: resourceModel.Description.ValueStringPointer(),
+		Description: event.DescriptionWithHistorical(resourceModel.Description.ValueStringPointer(), resourceModel.Location),
 		ContentType: resourceModel.ContentType.ValueStringPointer(),
 		Text:        resourceModel.Text.ValueStringPointer(),
 		File:        resourceModel.File.ValueStringPointer(),
@@ -99,6 +102,10 @@ func UpdateComputedFields(ctx context.Context, response *genidocument.Document,
 	d.Append(diags...)
 	resourceModel.Labels = labels
 
+	date, diags := event.UpdateComputedFieldsInDate(ctx, resourceModel.Date)
+	d.Append(diags...)
+	resourceModel.Date = date
+
 	location, diags := event.UpdateComputedFieldsInLocationObject(ctx, resourceModel.Location, response.Location)
 	d.Append(diags...)
 	resourceModel.Location = location
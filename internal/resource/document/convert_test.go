@@ -74,10 +74,13 @@ func TestRequestFrom(t *testing.T) {
 			Text:        types.StringValue("This is the text content of the document"),
 			Date: types.ObjectValueMust(event.DateModel{}.AttributeTypes(),
 				map[string]attr.Value{
-					"circa": types.BoolValue(true),
-					"day":   types.Int32Value(19),
-					"month": types.Int32Value(8),
-					"year":  types.Int32Value(1922),
+					"circa":     types.BoolValue(true),
+					"day":       types.Int32Value(19),
+					"month":     types.Int32Value(8),
+					"year":      types.Int32Value(1922),
+					"calendar":  types.StringNull(),
+					"dual_year": types.Int32Null(),
+					"gregorian": types.StringValue("1922-08-19"),
 				}),
 			Location: types.ObjectValueMust(event.LocationModel{}.AttributeTypes(),
 				map[string]attr.Value{
@@ -87,10 +90,12 @@ func TestRequestFrom(t *testing.T) {
 					"latitude":        types.Float64Value(1.0),
 					"longitude":       types.Float64Value(2.0),
 					"place_name":      types.StringValue("Place Name"),
+					"place_id":        types.StringNull(),
 					"state":           types.StringValue("State"),
 					"street_address1": types.StringValue("Street Address 1"),
 					"street_address2": types.StringValue("Street Address 2"),
 					"street_address3": types.StringValue("Street Address 3"),
+					"historical":      types.ObjectNull(event.HistoricalModelAttributeTypes()),
 				}),
 			Labels: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("label1"), types.StringValue("label2")}),
 		}
@@ -0,0 +1,32 @@
+package document
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+// ModifyPlan fills in the document's location when it references a place in
+// the gazetteer (see event.PlanPlace).
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is planned on destroy. Before the provider is configured, it is
+	// not known whether it will have a gazetteer.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var location types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("location"), &location)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned, diags := event.PlanPlace(ctx, location, r.places, path.Root("location"))
+	resp.Diagnostics.Append(diags...)
+	if !diags.HasError() && !planned.Equal(location) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("location"), planned)...)
+	}
+}
@@ -6,18 +6,22 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
-	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithIdentity = &Resource{} // new interface
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 type Resource struct {
 	resource.ResourceWithConfigure
-	client      *geni.Client
+	client      *geniclient.Client
 	batchClient *genibatch.Client
+	// places is nil unless a gazetteer is configured.
+	places *gazetteer.Gazetteer
 }
 
 func NewResource() resource.Resource {
@@ -51,4 +55,5 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 
 	r.client = cfg.Client
 	r.batchClient = cfg.BatchClient
+	r.places = cfg.Gazetteer
 }
@@ -0,0 +1,308 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Calendars a date can be written in. Geni only knows the Gregorian calendar,
+// so a Julian date is converted before it is sent.
+const (
+	CalendarGregorian = "gregorian"
+	CalendarJulian    = "julian"
+)
+
+// calendarDate is a date whose parts may be unset (zero).
+type calendarDate struct {
+	day, month, year int32
+}
+
+// calendarDateFrom returns the parts of the date in attrs under the given names. It
+// reports false while any of them is unknown.
+func calendarDateFrom(attrs map[string]attr.Value, day, month, year string) (calendarDate, bool) {
+	var d calendarDate
+	for name, part := range map[string]*int32{day: &d.day, month: &d.month, year: &d.year} {
+		value, ok := attrs[name].(types.Int32)
+		if !ok || value.IsNull() {
+			continue
+		}
+		if value.IsUnknown() {
+			return d, false
+		}
+		*part = value.ValueInt32()
+	}
+	return d, true
+}
+
+func calendarDateOf(day, month, year *int32) calendarDate {
+	var d calendarDate
+	for _, part := range []struct {
+		value *int32
+		to    *int32
+	}{{day, &d.day}, {month, &d.month}, {year, &d.year}} {
+		if part.value != nil {
+			*part.to = *part.value
+		}
+	}
+	return d
+}
+
+// pointers returns the parts of the date, nil when unset.
+func (d calendarDate) pointers() (*int32, *int32, *int32) {
+	pointer := func(part int32) *int32 {
+		if part == 0 {
+			return nil
+		}
+		return new(part)
+	}
+	return pointer(d.day), pointer(d.month), pointer(d.year)
+}
+
+// values returns the parts of the date, null when unset.
+func (d calendarDate) values() (types.Int32, types.Int32, types.Int32) {
+	value := func(part int32) types.Int32 {
+		if part == 0 {
+			return types.Int32Null()
+		}
+		return types.Int32Value(part)
+	}
+	return value(d.day), value(d.month), value(d.year)
+}
+
+func (d calendarDate) empty() bool {
+	return d.day == 0 && d.month == 0 && d.year == 0
+}
+
+func (d calendarDate) complete() bool {
+	return d.day != 0 && d.month != 0 && d.year != 0
+}
+
+// daysInMonth returns the number of days in the date's month, or the most any
+// month has when the month is unset. February has 29 days unless the year is
+// set and is not a leap year of the calendar.
+func (d calendarDate) daysInMonth(julian bool) int32 {
+	if d.month == 0 {
+		return 31
+	}
+	if d.month == 2 && d.year != 0 && julian {
+		if d.year%4 == 0 {
+			return 29
+		}
+		return 28
+	}
+	year := d.year
+	if year == 0 {
+		year = 2000 // a leap year
+	}
+	return int32(time.Date(int(year), time.Month(d.month)+1, 0, 0, 0, 0, 0, time.UTC).Day())
+}
+
+// before reports whether d is earlier than other, comparing only the parts
+// both dates have, from the year down.
+func (d calendarDate) before(other calendarDate) bool {
+	for _, parts := range [][2]int32{{d.year, other.year}, {d.month, other.month}, {d.day, other.day}} {
+		if parts[0] == 0 || parts[1] == 0 {
+			return false
+		}
+		if parts[0] != parts[1] {
+			return parts[0] < parts[1]
+		}
+	}
+	return false
+}
+
+func (d calendarDate) String() string {
+	var parts []string
+	if d.day != 0 {
+		parts = append(parts, fmt.Sprint(d.day))
+	}
+	if d.month != 0 {
+		parts = append(parts, time.Month(d.month).String())
+	}
+	if d.year != 0 {
+		parts = append(parts, fmt.Sprint(d.year))
+	}
+	return strings.Join(parts, " ")
+}
+
+// iso returns the date as YYYY-MM-DD, YYYY-MM or YYYY, depending on the parts
+// it has, and false when it has no year. A day without a month is left out.
+func (d calendarDate) iso() (string, bool) {
+	switch {
+	case d.year == 0:
+		return "", false
+	case d.month == 0:
+		return fmt.Sprintf("%04d", d.year), true
+	case d.day == 0:
+		return fmt.Sprintf("%04d-%02d", d.year, d.month), true
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day), true
+	}
+}
+
+// julianDay returns the Julian Day Number of a complete date in the Julian or
+// the Gregorian calendar.
+func (d calendarDate) julianDay(julian bool) int64 {
+	a := (14 - int64(d.month)) / 12
+	y := int64(d.year) + 4800 - a
+	m := int64(d.month) + 12*a - 3
+	jdn := int64(d.day) + (153*m+2)/5 + 365*y + y/4
+	if julian {
+		return jdn - 32083
+	}
+	return jdn - y/100 + y/400 - 32045
+}
+
+// dateOfJulianDay returns the date of a Julian Day Number in the Julian or the
+// Gregorian calendar.
+func dateOfJulianDay(jdn int64, julian bool) calendarDate {
+	var b, c int64
+	if julian {
+		c = jdn + 32082
+	} else {
+		a := jdn + 32044
+		b = (4*a + 3) / 146097
+		c = a - 146097*b/4
+	}
+	d := (4*c + 3) / 1461
+	e := c - 1461*d/4
+	m := (5*e + 2) / 153
+	return calendarDate{
+		day:   int32(e - (153*m+2)/5 + 1),
+		month: int32(m + 3 - 12*(m/10)),
+		year:  int32(100*b + d - 4800 + m/10),
+	}
+}
+
+// dualDated reports whether a date falls in the part of the year, 1 January to
+// 24 March, that England dated with two years before 1752, when the legal year
+// still began on Lady Day.
+func (d calendarDate) dualDated() bool {
+	return d.month == 0 || d.month < 3 || d.month == 3 && (d.day == 0 || d.day <= 24)
+}
+
+// dating is how a configured date is written: its calendar and whether it is
+// dual-dated, as "11 February 1731/32" is. A dual-dated date's year is the
+// first (Old Style) year, and dual_year the second (New Style) one.
+type dating struct {
+	julian bool
+	dual   bool
+}
+
+// datingFrom returns the dating of the date in attrs, and false while it is
+// unknown.
+func datingFrom(attrs map[string]attr.Value) (dating, bool) {
+	calendar, _ := attrs["calendar"].(types.String)
+	dualYear, _ := attrs["dual_year"].(types.Int32)
+	if calendar.IsUnknown() || dualYear.IsUnknown() {
+		return dating{}, false
+	}
+	return dating{
+		julian: calendar.ValueString() == CalendarJulian,
+		dual:   !dualYear.IsNull(),
+	}, true
+}
+
+// toGeni returns date d, written with dual year dualYear (zero when unset), as
+// Geni stores it: in the year that began on 1 January and, when d is complete,
+// in the Gregorian calendar. A partial Julian date is stored as written, since
+// the ten to thirteen days between the calendars cannot be applied to it.
+func (w dating) toGeni(d calendarDate, dualYear int32) calendarDate {
+	if dualYear != 0 {
+		d.year = dualYear
+	}
+	if w.julian && d.complete() {
+		return dateOfJulianDay(d.julianDay(true), false)
+	}
+	return d
+}
+
+// fromGeni is the inverse of toGeni: it returns the date Geni stores as d
+// written in w, with its dual year, or zero when it has none.
+func (w dating) fromGeni(d calendarDate) (calendarDate, int32) {
+	if w.julian && d.complete() {
+		d = dateOfJulianDay(d.julianDay(false), true)
+	}
+	if w.dual && d.year != 0 && d.dualDated() {
+		dualYear := d.year
+		d.year--
+		return d, dualYear
+	}
+	return d, 0
+}
+
+// gregorianFrom returns the gregorian attribute of the date in attrs: its
+// start date as Geni stores it, in ISO 8601 form. It reports false while the
+// date is unknown.
+func gregorianFrom(attrs map[string]attr.Value) (types.String, bool) {
+	w, ok := datingFrom(attrs)
+	if !ok {
+		return types.String{}, false
+	}
+	start, ok := calendarDateFrom(attrs, "day", "month", "year")
+	if !ok {
+		return types.String{}, false
+	}
+	dualYear, _ := attrs["dual_year"].(types.Int32)
+	return gregorianValue(w.toGeni(start, dualYear.ValueInt32())), true
+}
+
+func gregorianValue(d calendarDate) types.String {
+	if iso, ok := d.iso(); ok {
+		return types.StringValue(iso)
+	}
+	return types.StringNull()
+}
+
+// UpdateComputedFieldsInDate returns date, from DateSchema or DateRangeSchema,
+// with its gregorian attribute computed when it is still unknown after apply,
+// which happens when the date was not known at plan time.
+func UpdateComputedFieldsInDate(ctx context.Context, date types.Object) (types.Object, diag.Diagnostics) {
+	if date.IsNull() || date.IsUnknown() {
+		return date, nil
+	}
+	attrs := date.Attributes()
+	if gregorian, ok := attrs["gregorian"].(types.String); !ok || !gregorian.IsUnknown() {
+		return date, nil
+	}
+	gregorian, ok := gregorianFrom(attrs)
+	if !ok {
+		return date, nil
+	}
+	updated := maps.Clone(attrs)
+	updated["gregorian"] = gregorian
+	return types.ObjectValue(date.AttributeTypes(ctx), updated)
+}
+
+var _ planmodifier.String = gregorianModifier{}
+
+// gregorianModifier plans the computed gregorian attribute of a date from the
+// configured one, so that it is known before apply.
+type gregorianModifier struct{}
+
+func (m gregorianModifier) Description(_ context.Context) string {
+	return "plans the date in the Gregorian calendar from the configured date"
+}
+
+func (m gregorianModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m gregorianModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var date types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath(), &date)...)
+	if resp.Diagnostics.HasError() || date.IsNull() || date.IsUnknown() {
+		return
+	}
+	if gregorian, ok := gregorianFrom(date.Attributes()); ok {
+		resp.PlanValue = gregorian
+	}
+}
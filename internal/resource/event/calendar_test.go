@@ -0,0 +1,171 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+func TestDating(t *testing.T) {
+	julian := dating{julian: true}
+	julianDual := dating{julian: true, dual: true}
+
+	t.Run("A Julian date is stored in the Gregorian calendar", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(julian.toGeni(calendarDate{day: 1, month: 1, year: 1700}, 0)).To(Equal(calendarDate{day: 11, month: 1, year: 1700}))
+		Expect(julian.toGeni(calendarDate{day: 29, month: 2, year: 1700}, 0)).To(Equal(calendarDate{day: 11, month: 3, year: 1700}))
+		Expect(julian.toGeni(calendarDate{day: 2, month: 9, year: 1752}, 0)).To(Equal(calendarDate{day: 13, month: 9, year: 1752}))
+	})
+
+	t.Run("A dual date is stored in its second year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(julianDual.toGeni(calendarDate{day: 11, month: 2, year: 1731}, 1732)).To(Equal(calendarDate{day: 22, month: 2, year: 1732}))
+		Expect(dating{dual: true}.toGeni(calendarDate{month: 3, year: 1731}, 1732)).To(Equal(calendarDate{month: 3, year: 1732}))
+	})
+
+	t.Run("A partial Julian date is stored as written", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(julian.toGeni(calendarDate{month: 12, year: 1700}, 0)).To(Equal(calendarDate{month: 12, year: 1700}))
+	})
+
+	t.Run("A stored date reads back as written", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		date, dualYear := julianDual.fromGeni(calendarDate{day: 22, month: 2, year: 1732})
+		Expect(date).To(Equal(calendarDate{day: 11, month: 2, year: 1731}))
+		Expect(dualYear).To(Equal(int32(1732)))
+
+		date, dualYear = julianDual.fromGeni(calendarDate{day: 22, month: 6, year: 1732})
+		Expect(date).To(Equal(calendarDate{day: 11, month: 6, year: 1732}))
+		Expect(dualYear).To(BeZero())
+	})
+
+	t.Run("Every day round-trips between the calendars", func(t *testing.T) {
+		RegisterTestingT(t)
+		first := calendarDate{day: 1, month: 1, year: 1000}.julianDay(true)
+
+		for jdn := first; jdn < first+365*1100; jdn += 7 {
+			date := dateOfJulianDay(jdn, true)
+			Expect(date.julianDay(true)).To(Equal(jdn))
+			stored := julian.toGeni(date, 0)
+			Expect(stored.julianDay(false)).To(Equal(jdn))
+			Expect(julian.fromGeni(stored)).To(Equal(date))
+		}
+	})
+
+	t.Run("The Julian Day Number of a Gregorian date", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(calendarDate{day: 1, month: 1, year: 2000}.julianDay(false)).To(Equal(int64(2451545)))
+		Expect(dateOfJulianDay(2451545, false)).To(Equal(calendarDate{day: 1, month: 1, year: 2000}))
+	})
+}
+
+func TestDualDatedConversion(t *testing.T) {
+	t.Run("A Julian dual date is sent to Geni in the Gregorian calendar", func(t *testing.T) {
+		RegisterTestingT(t)
+		date := dateRange(map[string]attr.Value{
+			"range": types.StringValue("between"), "day": int32Value(11), "month": int32Value(2), "year": int32Value(1731),
+			"dual_year": int32Value(1732), "calendar": types.StringValue(CalendarJulian),
+			"end_day": int32Value(1), "end_month": int32Value(3), "end_year": int32Value(1732),
+		})
+		model, diags := DateRangeObjectValueFrom(t.Context(), date)
+		Expect(diags).To(BeEmpty())
+
+		element := DateRangeElementFrom(model)
+
+		Expect(element.Day).To(HaveValue(Equal(int32(22))))
+		Expect(element.Month).To(HaveValue(Equal(int32(2))))
+		Expect(element.Year).To(HaveValue(Equal(int32(1732))))
+		Expect(element.EndDay).To(HaveValue(Equal(int32(12))))
+		Expect(element.EndMonth).To(HaveValue(Equal(int32(3))))
+		Expect(element.EndYear).To(HaveValue(Equal(int32(1732))))
+	})
+
+	t.Run("A Gregorian date is sent as written", func(t *testing.T) {
+		RegisterTestingT(t)
+		model := &DateModel{Day: int32Value(11), Month: int32Value(2), Year: int32Value(1731)}
+
+		element := DateElementFrom(model)
+
+		Expect(element.Day).To(HaveValue(Equal(int32(11))))
+		Expect(element.Month).To(HaveValue(Equal(int32(2))))
+		Expect(element.Year).To(HaveValue(Equal(int32(1731))))
+	})
+
+	t.Run("A date is read back in the calendar of its previous value", func(t *testing.T) {
+		RegisterTestingT(t)
+		prior := dateRange(map[string]attr.Value{
+			"range": types.StringValue("between"), "day": int32Value(11), "month": int32Value(2), "year": int32Value(1731),
+			"dual_year": int32Value(1732), "calendar": types.StringValue(CalendarJulian),
+			"end_day": int32Value(1), "end_month": int32Value(3), "end_year": int32Value(1732),
+		})
+		stored := &geniprofile.DateElement{
+			Range: new("between"), Day: new(int32(22)), Month: new(int32(2)), Year: new(int32(1732)),
+			EndDay: new(int32(12)), EndMonth: new(int32(3)), EndYear: new(int32(1732)),
+		}
+
+		result, diags := DateRangeValueFrom(t.Context(), stored, prior)
+
+		Expect(diags).To(BeEmpty())
+		var model DateRangeModel
+		Expect(result.As(t.Context(), &model, basetypes.ObjectAsOptions{})).To(BeEmpty())
+		Expect(model.Calendar.ValueString()).To(Equal(CalendarJulian))
+		Expect(model.Day.ValueInt32()).To(Equal(int32(11)))
+		Expect(model.Month.ValueInt32()).To(Equal(int32(2)))
+		Expect(model.Year.ValueInt32()).To(Equal(int32(1731)))
+		Expect(model.DualYear.ValueInt32()).To(Equal(int32(1732)))
+		Expect(model.EndDay.ValueInt32()).To(Equal(int32(1)))
+		Expect(model.EndMonth.ValueInt32()).To(Equal(int32(3)))
+		Expect(model.EndYear.ValueInt32()).To(Equal(int32(1732)))
+		Expect(model.Gregorian.ValueString()).To(Equal("1732-02-22"))
+	})
+
+	t.Run("A date without a previous value is read as Gregorian", func(t *testing.T) {
+		RegisterTestingT(t)
+		stored := &geniprofile.DateElement{Month: new(int32(2)), Year: new(int32(1732))}
+
+		result, diags := DateValueFrom(t.Context(), stored, types.ObjectNull(DateModelAttributeTypes()))
+
+		Expect(diags).To(BeEmpty())
+		var model DateModel
+		Expect(result.As(t.Context(), &model, basetypes.ObjectAsOptions{})).To(BeEmpty())
+		Expect(model.Calendar.IsNull()).To(BeTrue())
+		Expect(model.DualYear.IsNull()).To(BeTrue())
+		Expect(model.Gregorian.ValueString()).To(Equal("1732-02"))
+	})
+}
+
+func TestUpdateComputedFieldsInDate(t *testing.T) {
+	t.Run("An unknown gregorian date is computed", func(t *testing.T) {
+		RegisterTestingT(t)
+		date := dateRange(map[string]attr.Value{
+			"day": int32Value(11), "month": int32Value(2), "year": int32Value(1731),
+			"dual_year": int32Value(1732), "calendar": types.StringValue(CalendarJulian),
+			"gregorian": types.StringUnknown(),
+		})
+
+		result, diags := UpdateComputedFieldsInDate(t.Context(), date)
+
+		Expect(diags).To(BeEmpty())
+		Expect(result.Attributes()["gregorian"]).To(Equal(types.StringValue("1732-02-22")))
+	})
+
+	t.Run("A date without a year has no gregorian date", func(t *testing.T) {
+		RegisterTestingT(t)
+		date := dateRange(map[string]attr.Value{"day": int32Value(11), "month": int32Value(2), "gregorian": types.StringUnknown()})
+
+		result, diags := UpdateComputedFieldsInDate(t.Context(), date)
+
+		Expect(diags).To(BeEmpty())
+		Expect(result.Attributes()["gregorian"].IsNull()).To(BeTrue())
+	})
+}
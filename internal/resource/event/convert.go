@@ -28,7 +28,7 @@ func ElementFrom(ctx context.Context, eventObject types.Object) (*geniprofile.Ev
 
 	return &geniprofile.EventElement{
 		Name:        eventModel.Name.ValueString(),
-		Description: eventModel.Description.ValueStringPointer(),
+		Description: DescriptionWithHistorical(eventModel.Description.ValueStringPointer(), eventModel.Location),
 		Date:        DateRangeElementFrom(dateModel),
 		Location:    LocationElementFrom(locationModel),
 	}, d
@@ -82,11 +82,12 @@ func DateElementFrom(model *DateModel) *geniprofile.DateElement {
 		return nil
 	}
 
+	day, month, year := model.geniDate()
 	return &geniprofile.DateElement{
 		Circa: model.Circa.ValueBoolPointer(),
-		Day:   model.Day.ValueInt32Pointer(),
-		Month: model.Month.ValueInt32Pointer(),
-		Year:  model.Year.ValueInt32Pointer(),
+		Day:   day,
+		Month: month,
+		Year:  year,
 	}
 }
 
@@ -95,16 +96,45 @@ func DateRangeElementFrom(model *DateRangeModel) *geniprofile.DateElement {
 		return nil
 	}
 
+	day, month, year := model.geniDate()
+	endDay, endMonth, endYear := model.geniEndDate()
 	return &geniprofile.DateElement{
 		Range:    model.Range.ValueStringPointer(),
 		Circa:    model.Circa.ValueBoolPointer(),
-		Day:      model.Day.ValueInt32Pointer(),
-		Month:    model.Month.ValueInt32Pointer(),
-		Year:     model.Year.ValueInt32Pointer(),
+		Day:      day,
+		Month:    month,
+		Year:     year,
 		EndCirca: model.EndCirca.ValueBoolPointer(),
-		EndDay:   model.EndDay.ValueInt32Pointer(),
-		EndMonth: model.EndMonth.ValueInt32Pointer(),
-		EndYear:  model.EndYear.ValueInt32Pointer(),
+		EndDay:   endDay,
+		EndMonth: endMonth,
+		EndYear:  endYear,
+	}
+}
+
+// geniDate returns the day, month and year of the date as Geni stores them.
+func (m DateModel) geniDate() (*int32, *int32, *int32) {
+	w := m.dating()
+	if !w.julian && !w.dual {
+		return m.Day.ValueInt32Pointer(), m.Month.ValueInt32Pointer(), m.Year.ValueInt32Pointer()
+	}
+	d := calendarDateOf(m.Day.ValueInt32Pointer(), m.Month.ValueInt32Pointer(), m.Year.ValueInt32Pointer())
+	return w.toGeni(d, m.DualYear.ValueInt32()).pointers()
+}
+
+// geniEndDate returns the end day, month and year of the range as Geni stores
+// them. An end date is never dual-dated.
+func (m DateRangeModel) geniEndDate() (*int32, *int32, *int32) {
+	if !m.dating().julian {
+		return m.EndDay.ValueInt32Pointer(), m.EndMonth.ValueInt32Pointer(), m.EndYear.ValueInt32Pointer()
+	}
+	d := calendarDateOf(m.EndDay.ValueInt32Pointer(), m.EndMonth.ValueInt32Pointer(), m.EndYear.ValueInt32Pointer())
+	return dating{julian: true}.toGeni(d, 0).pointers()
+}
+
+func (m DateModel) dating() dating {
+	return dating{
+		julian: m.Calendar.ValueString() == CalendarJulian,
+		dual:   !m.DualYear.IsNull() && !m.DualYear.IsUnknown(),
 	}
 }
 
@@ -132,7 +162,11 @@ func DateRangeObjectValueFrom(ctx context.Context, dateObject types.Object) (*Da
 	return &dateModel, diags
 }
 
-func ValueFrom(ctx context.Context, eventElement *geniprofile.EventElement) (basetypes.ObjectValue, diag.Diagnostics) {
+// ValueFrom returns the event Geni stores as eventElement. Its date is written
+// in the calendar of prior's date, prior being the event's previous value, so
+// that a Julian or dual-dated date reads back as configured, and its location
+// keeps prior's place_id and the historical names kept in its description.
+func ValueFrom(ctx context.Context, eventElement *geniprofile.EventElement, prior types.Object) (basetypes.ObjectValue, diag.Diagnostics) {
 	var d diag.Diagnostics
 
 	if eventElement == nil {
@@ -148,14 +182,20 @@ func ValueFrom(ctx context.Context, eventElement *geniprofile.EventElement) (bas
 		return types.ObjectNull(EventModelAttributeTypes()), d
 	}
 
-	dateObjectValue, diags := DateRangeValueFrom(ctx, eventElement.Date)
+	priorDate, _ := dateOf(prior)
+	dateObjectValue, diags := DateRangeValueFrom(ctx, eventElement.Date, priorDate)
+	d.Append(diags...)
+
+	priorLocation, _ := prior.Attributes()["location"].(types.Object)
+	locationObjectValue, diags := LocationValueFrom(ctx, eventElement.Location, priorLocation)
 	d.Append(diags...)
 
-	locationObjectValue, diags := LocationValueFrom(ctx, eventElement.Location)
+	description, historical := SplitHistorical(eventElement.Description)
+	locationObjectValue, diags = WithHistorical(ctx, locationObjectValue, historical)
 	d.Append(diags...)
 
 	eventModel := Model{
-		Description: types.StringPointerValue(eventElement.Description),
+		Description: types.StringPointerValue(description),
 		Name:        types.StringValue(eventElement.Name),
 		Date:        dateObjectValue,
 		Location:    locationObjectValue,
@@ -167,14 +207,11 @@ func ValueFrom(ctx context.Context, eventElement *geniprofile.EventElement) (bas
 	return eventObjectValue, d
 }
 
-func DateValueFrom(ctx context.Context, dateElement *geniprofile.DateElement) (basetypes.ObjectValue, diag.Diagnostics) {
+// DateValueFrom returns the date Geni stores as dateElement, written in the
+// calendar of prior, the date's previous value.
+func DateValueFrom(ctx context.Context, dateElement *geniprofile.DateElement, prior types.Object) (basetypes.ObjectValue, diag.Diagnostics) {
 	if dateElement != nil {
-		dateModel := DateModel{
-			Circa: types.BoolPointerValue(dateElement.Circa),
-			Day:   types.Int32PointerValue(dateElement.Day),
-			Month: types.Int32PointerValue(dateElement.Month),
-			Year:  types.Int32PointerValue(dateElement.Year),
-		}
+		dateModel := dateModelFrom(dateElement, prior)
 
 		return types.ObjectValueFrom(ctx, dateModel.AttributeTypes(), dateModel)
 	}
@@ -182,20 +219,21 @@ func DateValueFrom(ctx context.Context, dateElement *geniprofile.DateElement) (b
 	return types.ObjectNull(DateModelAttributeTypes()), diag.Diagnostics{}
 }
 
-func DateRangeValueFrom(ctx context.Context, dateElement *geniprofile.DateElement) (basetypes.ObjectValue, diag.Diagnostics) {
+// DateRangeValueFrom returns the date range Geni stores as dateElement, written
+// in the calendar of prior, the range's previous value.
+func DateRangeValueFrom(ctx context.Context, dateElement *geniprofile.DateElement, prior types.Object) (basetypes.ObjectValue, diag.Diagnostics) {
 	if dateElement != nil {
 		dateModel := DateRangeModel{
-			DateModel: DateModel{
-				Circa: types.BoolPointerValue(dateElement.Circa),
-				Day:   types.Int32PointerValue(dateElement.Day),
-				Month: types.Int32PointerValue(dateElement.Month),
-				Year:  types.Int32PointerValue(dateElement.Year),
-			},
-			Range:    types.StringPointerValue(dateElement.Range),
-			EndCirca: types.BoolPointerValue(dateElement.EndCirca),
-			EndDay:   types.Int32PointerValue(dateElement.EndDay),
-			EndMonth: types.Int32PointerValue(dateElement.EndMonth),
-			EndYear:  types.Int32PointerValue(dateElement.EndYear),
+			DateModel: dateModelFrom(dateElement, prior),
+			Range:     types.StringPointerValue(dateElement.Range),
+			EndCirca:  types.BoolPointerValue(dateElement.EndCirca),
+			EndDay:    types.Int32PointerValue(dateElement.EndDay),
+			EndMonth:  types.Int32PointerValue(dateElement.EndMonth),
+			EndYear:   types.Int32PointerValue(dateElement.EndYear),
+		}
+		if dateModel.dating().julian {
+			end, _ := dating{julian: true}.fromGeni(calendarDateOf(dateElement.EndDay, dateElement.EndMonth, dateElement.EndYear))
+			dateModel.EndDay, dateModel.EndMonth, dateModel.EndYear = end.values()
 		}
 
 		return types.ObjectValueFrom(ctx, dateModel.AttributeTypes(), dateModel)
@@ -204,7 +242,44 @@ func DateRangeValueFrom(ctx context.Context, dateElement *geniprofile.DateElemen
 	return types.ObjectNull(DateRangeModelAttributeTypes()), diag.Diagnostics{}
 }
 
-func LocationValueFrom(ctx context.Context, location *geniprofile.LocationElement) (basetypes.ObjectValue, diag.Diagnostics) {
+// dateModelFrom returns the start date Geni stores in dateElement, written in
+// the calendar of prior and dual-dated when prior was.
+func dateModelFrom(dateElement *geniprofile.DateElement, prior types.Object) DateModel {
+	stored := calendarDateOf(dateElement.Day, dateElement.Month, dateElement.Year)
+	dateModel := DateModel{
+		Circa:     types.BoolPointerValue(dateElement.Circa),
+		Day:       types.Int32PointerValue(dateElement.Day),
+		Month:     types.Int32PointerValue(dateElement.Month),
+		Year:      types.Int32PointerValue(dateElement.Year),
+		Calendar:  types.StringNull(),
+		DualYear:  types.Int32Null(),
+		Gregorian: gregorianValue(stored),
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return dateModel
+	}
+
+	w, ok := datingFrom(prior.Attributes())
+	if !ok {
+		return dateModel
+	}
+	if calendar, isString := prior.Attributes()["calendar"].(types.String); isString {
+		dateModel.Calendar = calendar
+	}
+	if !w.julian && !w.dual {
+		return dateModel
+	}
+	start, dualYear := w.fromGeni(stored)
+	dateModel.Day, dateModel.Month, dateModel.Year = start.values()
+	if dualYear != 0 {
+		dateModel.DualYear = types.Int32Value(dualYear)
+	}
+	return dateModel
+}
+
+// LocationValueFrom returns the location Geni stores as location, with the
+// place_id of prior, the location's previous value, which Geni does not record.
+func LocationValueFrom(ctx context.Context, location *geniprofile.LocationElement, prior types.Object) (basetypes.ObjectValue, diag.Diagnostics) {
 	if location != nil {
 		locationModel := LocationModel{
 			City:           types.StringPointerValue(location.City),
@@ -213,10 +288,12 @@ func LocationValueFrom(ctx context.Context, location *geniprofile.LocationElemen
 			Latitude:       types.Float64PointerValue(location.Latitude),
 			Longitude:      types.Float64PointerValue(location.Longitude),
 			PlaceName:      types.StringPointerValue(location.PlaceName),
+			PlaceID:        placeIDFrom(prior),
 			State:          types.StringPointerValue(location.State),
 			StreetAddress1: types.StringPointerValue(location.StreetAddress1),
 			StreetAddress2: types.StringPointerValue(location.StreetAddress2),
 			StreetAddress3: types.StringPointerValue(location.StreetAddress3),
+			Historical:     types.ObjectNull(HistoricalModelAttributeTypes()),
 		}
 
 		return types.ObjectValueFrom(ctx, locationModel.AttributeTypes(), locationModel)
@@ -260,10 +337,15 @@ func updateComputedFieldsInEventObject(ctx context.Context, eventObject *Model,
 		if eventElement == nil {
 			eventObject.Description = types.StringNull()
 		} else {
-			eventObject.Description = types.StringPointerValue(eventElement.Description)
+			description, _ := SplitHistorical(eventElement.Description)
+			eventObject.Description = types.StringPointerValue(description)
 		}
 	}
 
+	date, diags := UpdateComputedFieldsInDate(ctx, eventObject.Date)
+	d.Append(diags...)
+	eventObject.Date = date
+
 	if eventElement == nil {
 		location, diags := UpdateComputedFieldsInLocationObject(ctx, eventObject.Location, nil)
 		d.Append(diags...)
@@ -304,6 +386,26 @@ func UpdateComputedFieldsInLocationObject(ctx context.Context, locationObject ty
 		}
 	}
 
+	// Components left to a gazetteer that was not configured at plan time.
+	var stored geniprofile.LocationElement
+	if locationElement != nil {
+		stored = *locationElement
+	}
+	for _, component := range []struct {
+		value  *types.String
+		stored *string
+	}{
+		{&locationModel.City, stored.City},
+		{&locationModel.Country, stored.Country},
+		{&locationModel.County, stored.County},
+		{&locationModel.PlaceName, stored.PlaceName},
+		{&locationModel.State, stored.State},
+	} {
+		if component.value.IsUnknown() {
+			*component.value = types.StringPointerValue(component.stored)
+		}
+	}
+
 	locationObject, diags = types.ObjectValueFrom(ctx, locationModel.AttributeTypes(), locationModel)
 	d.Append(diags...)
 
@@ -29,6 +29,9 @@ func TestElementFrom(t *testing.T) {
 						"end_day":   types.Int32Value(20),
 						"end_month": types.Int32Value(8),
 						"end_year":  types.Int32Value(1922),
+						"calendar":  types.StringNull(),
+						"dual_year": types.Int32Null(),
+						"gregorian": types.StringValue("1922-08-19"),
 					}),
 				"location": types.ObjectValueMust(LocationModel{}.AttributeTypes(),
 					map[string]attr.Value{
@@ -38,10 +41,12 @@ func TestElementFrom(t *testing.T) {
 						"latitude":        types.Float64Value(1.0),
 						"longitude":       types.Float64Value(2.0),
 						"place_name":      types.StringValue("Place Name"),
+						"place_id":        types.StringNull(),
 						"state":           types.StringValue("State"),
 						"street_address1": types.StringValue("Street Address 1"),
 						"street_address2": types.StringValue("Street Address 2"),
 						"street_address3": types.StringValue("Street Address 3"),
+						"historical":      types.ObjectNull(HistoricalModelAttributeTypes()),
 					}),
 			},
 		)
@@ -122,7 +127,7 @@ func TestValueFrom(t *testing.T) {
 			},
 		}
 
-		result, diags := ValueFrom(t.Context(), eventElement)
+		result, diags := ValueFrom(t.Context(), eventElement, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeFalse())
@@ -139,7 +144,7 @@ func TestValueFrom(t *testing.T) {
 	t.Run("nil event returns null object", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		result, diags := ValueFrom(t.Context(), nil)
+		result, diags := ValueFrom(t.Context(), nil, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeTrue())
@@ -153,7 +158,7 @@ func TestValueFrom(t *testing.T) {
 		// from auto-creation and must not flap the refresh plan.
 		eventElement := &geniprofile.EventElement{Name: "Death of John Doe"}
 
-		result, diags := ValueFrom(t.Context(), eventElement)
+		result, diags := ValueFrom(t.Context(), eventElement, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeTrue())
@@ -166,7 +171,7 @@ func TestValueFrom(t *testing.T) {
 			Date: &geniprofile.DateElement{Year: new(int32(1980))},
 		}
 
-		result, diags := ValueFrom(t.Context(), eventElement)
+		result, diags := ValueFrom(t.Context(), eventElement, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeFalse())
@@ -179,7 +184,7 @@ func TestValueFrom(t *testing.T) {
 			Location: &geniprofile.LocationElement{City: new("Springfield")},
 		}
 
-		result, diags := ValueFrom(t.Context(), eventElement)
+		result, diags := ValueFrom(t.Context(), eventElement, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeFalse())
@@ -196,7 +201,7 @@ func TestDateValueFrom(t *testing.T) {
 			Year:  new(int32(1900)),
 		}
 
-		result, diags := DateValueFrom(t.Context(), dateElement)
+		result, diags := DateValueFrom(t.Context(), dateElement, types.ObjectNull(DateModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeFalse())
@@ -213,7 +218,7 @@ func TestDateValueFrom(t *testing.T) {
 	t.Run("nil returns null", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		result, diags := DateValueFrom(t.Context(), nil)
+		result, diags := DateValueFrom(t.Context(), nil, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeTrue())
@@ -235,7 +240,7 @@ func TestDateRangeValueFrom(t *testing.T) {
 			EndYear:  new(int32(1850)),
 		}
 
-		result, diags := DateRangeValueFrom(t.Context(), dateElement)
+		result, diags := DateRangeValueFrom(t.Context(), dateElement, types.ObjectNull(DateRangeModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeFalse())
@@ -257,7 +262,7 @@ func TestDateRangeValueFrom(t *testing.T) {
 	t.Run("nil returns null", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		result, diags := DateRangeValueFrom(t.Context(), nil)
+		result, diags := DateRangeValueFrom(t.Context(), nil, types.ObjectNull(EventModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeTrue())
@@ -280,7 +285,7 @@ func TestLocationValueFrom(t *testing.T) {
 			StreetAddress3: new("Floor 2"),
 		}
 
-		result, diags := LocationValueFrom(t.Context(), locationElement)
+		result, diags := LocationValueFrom(t.Context(), locationElement, types.ObjectNull(LocationModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeFalse())
@@ -300,10 +305,20 @@ func TestLocationValueFrom(t *testing.T) {
 		Expect(model.StreetAddress3.ValueString()).To(Equal("Floor 2"))
 	})
 
+	t.Run("the place_id of the prior location is kept", func(t *testing.T) {
+		RegisterTestingT(t)
+		prior := locationWith(map[string]attr.Value{"place_id": types.StringValue("524901"), "city": types.StringValue("Moscow")})
+
+		result, diags := LocationValueFrom(t.Context(), &geniprofile.LocationElement{City: new("Moscow")}, prior)
+
+		Expect(diags).To(BeEmpty())
+		Expect(result).To(Equal(prior))
+	})
+
 	t.Run("nil returns null", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		result, diags := LocationValueFrom(t.Context(), nil)
+		result, diags := LocationValueFrom(t.Context(), nil, types.ObjectNull(LocationModelAttributeTypes()))
 
 		Expect(diags).To(BeEmpty())
 		Expect(result.IsNull()).To(BeTrue())
@@ -490,10 +505,12 @@ func TestUpdateComputedFieldsInLocationObject(t *testing.T) {
 				"latitude":        types.Float64Value(1.0),
 				"longitude":       types.Float64Value(2.0),
 				"place_name":      types.StringValue("Place Name"),
+				"place_id":        types.StringNull(),
 				"state":           types.StringValue("State"),
 				"street_address1": types.StringValue("Street Address 1"),
 				"street_address2": types.StringValue("Street Address 2"),
 				"street_address3": types.StringValue("Street Address 3"),
+				"historical":      types.ObjectNull(HistoricalModelAttributeTypes()),
 			})
 		givenLocationResponse := &geniprofile.LocationElement{
 			City:           new("City Response"),
@@ -524,10 +541,12 @@ func TestUpdateComputedFieldsInLocationObject(t *testing.T) {
 				"latitude":        types.Float64Unknown(),
 				"longitude":       types.Float64Unknown(),
 				"place_name":      types.StringNull(),
+				"place_id":        types.StringNull(),
 				"state":           types.StringNull(),
 				"street_address1": types.StringNull(),
 				"street_address2": types.StringNull(),
 				"street_address3": types.StringNull(),
+				"historical":      types.ObjectNull(HistoricalModelAttributeTypes()),
 			})
 		givenLocationResponse := &geniprofile.LocationElement{
 			City:           new("City Response"),
@@ -563,10 +582,12 @@ func TestUpdateComputedFieldsInLocationObject(t *testing.T) {
 				"latitude":        types.Float64Unknown(),
 				"longitude":       types.Float64Unknown(),
 				"place_name":      types.StringNull(),
+				"place_id":        types.StringNull(),
 				"state":           types.StringNull(),
 				"street_address1": types.StringNull(),
 				"street_address2": types.StringNull(),
 				"street_address3": types.StringNull(),
+				"historical":      types.ObjectNull(HistoricalModelAttributeTypes()),
 			})
 		givenLocationResponse := &geniprofile.LocationElement{
 			City:           new("City Response"),
@@ -602,10 +623,12 @@ func TestUpdateComputedFieldsInLocationObject(t *testing.T) {
 				"latitude":        types.Float64Unknown(),
 				"longitude":       types.Float64Unknown(),
 				"place_name":      types.StringNull(),
+				"place_id":        types.StringNull(),
 				"state":           types.StringNull(),
 				"street_address1": types.StringNull(),
 				"street_address2": types.StringNull(),
 				"street_address3": types.StringNull(),
+				"historical":      types.ObjectNull(HistoricalModelAttributeTypes()),
 			})
 		updatedLocationObject, diags := UpdateComputedFieldsInLocationObject(t.Context(), givenLocationObject, nil)
 		Expect(diags).To(BeEmpty())
@@ -0,0 +1,142 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.Object = dateValidator{}
+
+// dateValidator checks that a date from DateSchema or DateRangeSchema is a real
+// date of its calendar: month and day are in range, the day exists in its month
+// (29 February only in leap years), a dual year is the year after year and the
+// date falls where dual dating was used, and the end date of a "between" range
+// is set, valid and not before the start. Geni rejects such dates at apply
+// time, or stores them as they are.
+type dateValidator struct{}
+
+// DateValidator returns a validator for the dates of DateSchema and
+// DateRangeSchema.
+func DateValidator() validator.Object {
+	return dateValidator{}
+}
+
+func (v dateValidator) Description(_ context.Context) string {
+	return "date must be a valid calendar date, and a between range must end on a valid date after it starts"
+}
+
+func (v dateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dateValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	attrs := req.ConfigValue.Attributes()
+
+	w, ok := datingFrom(attrs)
+	if !ok {
+		return
+	}
+	start, ok := calendarDateFrom(attrs, "day", "month", "year")
+	if ok {
+		start, ok = validateDualYear(req.Path, start, attrs, resp)
+	}
+	if ok {
+		validateCalendarDate(req.Path, start, w, "day", "month", resp)
+	}
+
+	// Only DateRangeSchema has a range and an end date. An end date without a
+	// range is already reported by the end date attributes' own validators.
+	rangeValue, hasRange := attrs["range"].(types.String)
+	if !hasRange || rangeValue.IsNull() || rangeValue.IsUnknown() {
+		return
+	}
+	end, endOk := calendarDateFrom(attrs, "end_day", "end_month", "end_year")
+	if !endOk {
+		return
+	}
+
+	if rangeValue.ValueString() != "between" {
+		if !end.empty() {
+			resp.Diagnostics.AddAttributeError(req.Path.AtName("range"), "Invalid Date Range",
+				"An end date is only valid when range is \"between\".")
+		}
+		return
+	}
+	if end.empty() {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("range"), "Invalid Date Range",
+			"A \"between\" range needs an end date: set end_year, end_month or end_day.")
+		return
+	}
+	validateCalendarDate(req.Path, end, w, "end_day", "end_month", resp)
+
+	if ok && !resp.Diagnostics.HasError() && end.before(start) {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("end_year"), "Invalid Date Range",
+			fmt.Sprintf("The range ends on %s, before it starts on %s.", end, start))
+	}
+}
+
+// validateDualYear reports a dual_year that does not belong to start, and
+// returns start in its New Style year, which its day and its order in a range
+// are checked in. It reports false while dual_year is unknown.
+func validateDualYear(p path.Path, start calendarDate, attrs map[string]attr.Value, resp *validator.ObjectResponse) (calendarDate, bool) {
+	dualYear, _ := attrs["dual_year"].(types.Int32)
+	if dualYear.IsNull() {
+		return start, true
+	}
+	switch {
+	case start.year == 0:
+		resp.Diagnostics.AddAttributeError(p.AtName("dual_year"), "Invalid Date",
+			"A dual year needs year, the first (Old Style) year of the date.")
+	case dualYear.ValueInt32() != start.year+1:
+		resp.Diagnostics.AddAttributeError(p.AtName("dual_year"), "Invalid Date",
+			fmt.Sprintf("A dual year must be the year after year (%d), got %d.", start.year, dualYear.ValueInt32()))
+	case !start.dualDated():
+		resp.Diagnostics.AddAttributeError(p.AtName("dual_year"), "Invalid Date",
+			fmt.Sprintf("Only dates from 1 January to 24 March are dual-dated, got %s.", start))
+	default:
+		start.year = dualYear.ValueInt32()
+		return start, true
+	}
+	return start, false
+}
+
+// validateCalendarDate reports a month or day of d that is out of range, at the
+// given attributes of the date at p.
+func validateCalendarDate(p path.Path, d calendarDate, w dating, day, month string, resp *validator.ObjectResponse) {
+	if d.month != 0 && (d.month < 1 || d.month > 12) {
+		resp.Diagnostics.AddAttributeError(p.AtName(month), "Invalid Date",
+			fmt.Sprintf("Month must be between 1 and 12, got %d.", d.month))
+		return
+	}
+	if d.day == 0 {
+		return
+	}
+	if maxDay := d.daysInMonth(w.julian); d.day < 1 || d.day > maxDay {
+		resp.Diagnostics.AddAttributeError(p.AtName(day), "Invalid Date",
+			fmt.Sprintf("Day must be between 1 and %d for %s, got %d.", maxDay, monthName(d, w), d.day))
+	}
+}
+
+func monthName(d calendarDate, w dating) string {
+	name := "any month"
+	switch {
+	case d.month == 0:
+	case d.year == 0:
+		name = time.Month(d.month).String()
+	default:
+		name = fmt.Sprintf("%s %d", time.Month(d.month), d.year)
+	}
+	if w.julian {
+		name += " (Julian)"
+	}
+	return name
+}
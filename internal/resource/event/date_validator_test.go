@@ -0,0 +1,238 @@
+package event
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestDateValidator(t *testing.T) {
+	t.Run("A valid date passes", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"day": int32Value(31), "month": int32Value(12), "year": int32Value(1899)}))
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("29 February passes in a leap year and without a year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(validateDate(t, dateRange(map[string]attr.Value{"day": int32Value(29), "month": int32Value(2), "year": int32Value(2000)})).Diagnostics).To(BeEmpty())
+		Expect(validateDate(t, dateRange(map[string]attr.Value{"day": int32Value(29), "month": int32Value(2)})).Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("29 February fails outside a leap year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"day": int32Value(29), "month": int32Value(2), "year": int32Value(1900)}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(resp.Diagnostics.Errors()[0].Detail()).To(Equal("Day must be between 1 and 28 for February 1900, got 29."))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("day")))
+	})
+
+	t.Run("A day past the end of its month fails", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"day": int32Value(31), "month": int32Value(4)}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("day")))
+	})
+
+	t.Run("A month out of range fails", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"month": int32Value(13), "year": int32Value(1900)}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("month")))
+	})
+
+	t.Run("An invalid end date fails at the end attributes", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"range": types.StringValue("between"), "year": int32Value(1900),
+			"end_day": int32Value(31), "end_month": int32Value(6), "end_year": int32Value(1901),
+		}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("end_day")))
+	})
+
+	t.Run("A between range without an end date fails", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"range": types.StringValue("between"), "year": int32Value(1900)}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("range")))
+	})
+
+	t.Run("An end date with a before range fails", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"range": types.StringValue("before"), "year": int32Value(1900), "end_year": int32Value(1910),
+		}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("range")))
+	})
+
+	t.Run("A range that ends before it starts fails", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"range": types.StringValue("between"), "day": int32Value(2), "month": int32Value(3), "year": int32Value(1900),
+			"end_day": int32Value(1), "end_month": int32Value(3), "end_year": int32Value(1900),
+		}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(resp.Diagnostics.Errors()[0].Detail()).To(Equal("The range ends on 1 March 1900, before it starts on 2 March 1900."))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("end_year")))
+	})
+
+	t.Run("A range is only compared on the parts both ends have", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"range": types.StringValue("between"), "day": int32Value(20), "month": int32Value(3), "year": int32Value(1900),
+			"end_year": int32Value(1900),
+		}))
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("29 February follows the leap years of the calendar", func(t *testing.T) {
+		RegisterTestingT(t)
+		julian := map[string]attr.Value{
+			"day": int32Value(29), "month": int32Value(2), "year": int32Value(1700), "calendar": types.StringValue(CalendarJulian),
+		}
+
+		Expect(validateDate(t, dateRange(julian)).Diagnostics).To(BeEmpty())
+		julian["calendar"] = types.StringValue(CalendarGregorian)
+		Expect(validateDate(t, dateRange(julian)).Diagnostics.Errors()).To(HaveLen(1))
+	})
+
+	t.Run("A dual-dated day is checked in its second year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"day": int32Value(29), "month": int32Value(2), "year": int32Value(1731), "dual_year": int32Value(1732),
+			"calendar": types.StringValue(CalendarJulian),
+		}))
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("A dual year must follow year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"day": int32Value(11), "month": int32Value(2), "year": int32Value(1731), "dual_year": int32Value(1733),
+		}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("dual_year")))
+	})
+
+	t.Run("A dual year needs year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"month": int32Value(2), "dual_year": int32Value(1732)}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("dual_year")))
+	})
+
+	t.Run("Only dates before Lady Day are dual-dated", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"day": int32Value(25), "month": int32Value(3), "year": int32Value(1731), "dual_year": int32Value(1732),
+		}))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(resp.Diagnostics.Errors()[0].Detail()).To(Equal("Only dates from 1 January to 24 March are dual-dated, got 25 March 1731."))
+	})
+
+	t.Run("A dual-dated range is ordered by its second year", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{
+			"range": types.StringValue("between"), "day": int32Value(11), "month": int32Value(2), "year": int32Value(1731),
+			"dual_year": int32Value(1732), "end_day": int32Value(1), "end_month": int32Value(3), "end_year": int32Value(1732),
+		}))
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("Unknown parts are not checked", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		resp := validateDate(t, dateRange(map[string]attr.Value{"day": int32Value(31), "month": types.Int32Unknown()}))
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("A date without a range is checked too", func(t *testing.T) {
+		RegisterTestingT(t)
+		date := types.ObjectValueMust(DateModelAttributeTypes(), map[string]attr.Value{
+			"circa": types.BoolNull(), "day": int32Value(30), "month": int32Value(2), "year": types.Int32Null(),
+			"calendar": types.StringNull(), "dual_year": types.Int32Null(), "gregorian": types.StringNull(),
+		})
+
+		resp := validateDate(t, date)
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(errorPath(resp)).To(Equal(datePath.AtName("day")))
+	})
+}
+
+var datePath = path.Root("birth").AtName("date")
+
+func int32Value(v int32) types.Int32 {
+	return types.Int32Value(v)
+}
+
+// dateRange returns a DateRangeSchema value with the given attributes set and
+// the others null.
+func dateRange(set map[string]attr.Value) types.Object {
+	attrs := map[string]attr.Value{
+		"range":     types.StringNull(),
+		"circa":     types.BoolNull(),
+		"day":       types.Int32Null(),
+		"month":     types.Int32Null(),
+		"year":      types.Int32Null(),
+		"end_circa": types.BoolNull(),
+		"end_day":   types.Int32Null(),
+		"end_month": types.Int32Null(),
+		"end_year":  types.Int32Null(),
+		"calendar":  types.StringNull(),
+		"dual_year": types.Int32Null(),
+		"gregorian": types.StringNull(),
+	}
+	maps.Copy(attrs, set)
+	return types.ObjectValueMust(DateRangeModelAttributeTypes(), attrs)
+}
+
+func validateDate(t *testing.T, date types.Object) *validator.ObjectResponse {
+	t.Helper()
+	resp := &validator.ObjectResponse{}
+	DateValidator().ValidateObject(t.Context(), validator.ObjectRequest{Path: datePath, ConfigValue: date}, resp)
+	return resp
+}
+
+func errorPath(resp *validator.ObjectResponse) path.Path {
+	withPath, ok := resp.Diagnostics.Errors()[0].(interface{ Path() path.Path })
+	Expect(ok).To(BeTrue())
+	return withPath.Path()
+}
@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// historicalMarker begins the line of a description that records the
+// historical names of a location, which Geni has no field for. The line reads
+// like "[Historical place] city: Нижняя Верея; state: Nizhny Novgorod
+// Governorate; country: Russian Empire".
+const historicalMarker = "[Historical place] "
+
+// historicalAttributes are the attributes of a location's historical names, in
+// the order they are written to a description.
+var historicalAttributes = []string{"place_name", "city", "county", "state", "country"}
+
+func HistoricalModelAttributeTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(historicalAttributes))
+	for _, name := range historicalAttributes {
+		attrTypes[name] = types.StringType
+	}
+	return attrTypes
+}
+
+// DescriptionWithHistorical returns description, that of the event or
+// document location belongs to, with the historical names of location
+// appended on a line of their own.
+func DescriptionWithHistorical(description *string, location types.Object) *string {
+	if location.IsNull() || location.IsUnknown() {
+		return description
+	}
+	historical, _ := location.Attributes()["historical"].(types.Object)
+	if historical.IsNull() || historical.IsUnknown() {
+		return description
+	}
+
+	var parts []string
+	for _, name := range historicalAttributes {
+		value, _ := historical.Attributes()[name].(types.String)
+		if value.ValueString() != "" {
+			parts = append(parts, name+": "+value.ValueString())
+		}
+	}
+	if len(parts) == 0 {
+		return description
+	}
+
+	line := historicalMarker + strings.Join(parts, "; ")
+	if description == nil {
+		return &line
+	}
+	return new(*description + "\n" + line)
+}
+
+// SplitHistorical is the inverse of DescriptionWithHistorical: it returns
+// description without its line of historical names, nil when nothing else is
+// left, and the historical names, null when the line is missing.
+func SplitHistorical(description *string) (*string, types.Object) {
+	none := types.ObjectNull(HistoricalModelAttributeTypes())
+	if description == nil {
+		return nil, none
+	}
+
+	rest, line := "", *description
+	if i := strings.LastIndex(*description, "\n"+historicalMarker); i >= 0 {
+		rest, line = (*description)[:i], (*description)[i+1:]
+	}
+	if !strings.HasPrefix(line, historicalMarker) || strings.Contains(line, "\n") {
+		return description, none
+	}
+
+	attrs := make(map[string]attr.Value, len(historicalAttributes))
+	for _, name := range historicalAttributes {
+		attrs[name] = types.StringNull()
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(line, historicalMarker), "; ") {
+		name, value, ok := strings.Cut(part, ": ")
+		if _, known := attrs[name]; !ok || !known {
+			return description, none
+		}
+		attrs[name] = types.StringValue(value)
+	}
+
+	historical := types.ObjectValueMust(HistoricalModelAttributeTypes(), attrs)
+	if rest == "" && !strings.HasPrefix(*description, "\n") {
+		return nil, historical
+	}
+	return &rest, historical
+}
+
+// WithHistorical returns location, an object of LocationSchema read from
+// Geni, with historical names historical. A location Geni does not have keeps
+// none, since a configured location always has a modern name too.
+func WithHistorical(ctx context.Context, location types.Object, historical types.Object) (types.Object, diag.Diagnostics) {
+	if location.IsNull() || location.IsUnknown() {
+		return location, nil
+	}
+	attrs := maps.Clone(location.Attributes())
+	attrs["historical"] = historical
+	return types.ObjectValue(location.AttributeTypes(ctx), attrs)
+}
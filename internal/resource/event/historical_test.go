@@ -0,0 +1,82 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+func TestHistorical(t *testing.T) {
+	historical := types.ObjectValueMust(HistoricalModelAttributeTypes(), map[string]attr.Value{
+		"place_name": types.StringNull(),
+		"city":       types.StringValue("Нижняя Верея"),
+		"county":     types.StringNull(),
+		"state":      types.StringValue("Nizhny Novgorod Governorate"),
+		"country":    types.StringValue("Russian Empire"),
+	})
+	location := locationWith(map[string]attr.Value{
+		"city":       types.StringValue("Nizhnyaya Vereya"),
+		"country":    types.StringValue("Russia"),
+		"historical": historical,
+	})
+	const line = "[Historical place] city: Нижняя Верея; state: Nizhny Novgorod Governorate; country: Russian Empire"
+
+	t.Run("Historical names are appended to the description and split back off", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for description, want := range map[*string]string{
+			nil:                       line,
+			new("Born at home"):       "Born at home\n" + line,
+			new(""):                   "\n" + line,
+			new("Line one\nLine two"): "Line one\nLine two\n" + line,
+		} {
+			stored := DescriptionWithHistorical(description, location)
+			Expect(stored).To(HaveValue(Equal(want)))
+
+			rest, read := SplitHistorical(stored)
+			Expect(rest).To(Equal(description))
+			Expect(read).To(Equal(historical))
+		}
+	})
+
+	t.Run("A description without historical names is left alone", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(DescriptionWithHistorical(new("Born at home"), locationWith(nil))).To(HaveValue(Equal("Born at home")))
+		Expect(DescriptionWithHistorical(nil, types.ObjectNull(LocationModelAttributeTypes()))).To(BeNil())
+
+		for _, description := range []*string{
+			nil,
+			new("Born at home"),
+			new("[Historical place] city: Vereya\nand more"),
+			new("[Historical place] parish: St. Nicholas"),
+		} {
+			rest, read := SplitHistorical(description)
+			Expect(rest).To(Equal(description))
+			Expect(read.IsNull()).To(BeTrue())
+		}
+	})
+
+	t.Run("An event reads its historical names from its description", func(t *testing.T) {
+		RegisterTestingT(t)
+		element := &geniprofile.EventElement{
+			Name:        "Birth",
+			Description: new("Born at home\n" + line),
+			Location:    &geniprofile.LocationElement{City: new("Nizhnyaya Vereya"), Country: new("Russia")},
+		}
+
+		value, diags := ValueFrom(t.Context(), element, types.ObjectNull(EventModelAttributeTypes()))
+
+		Expect(diags).To(BeEmpty())
+		Expect(value.Attributes()["description"]).To(Equal(types.StringValue("Born at home")))
+		Expect(value.Attributes()["location"]).To(Equal(location))
+
+		written, diags := ElementFrom(t.Context(), value)
+		Expect(diags).To(BeEmpty())
+		Expect(written.Description).To(Equal(element.Description))
+	})
+}
@@ -30,10 +30,13 @@ func EventModelAttributeTypes() map[string]attr.Type {
 }
 
 type DateModel struct {
-	Circa types.Bool  `tfsdk:"circa"`
-	Day   types.Int32 `tfsdk:"day"`
-	Month types.Int32 `tfsdk:"month"`
-	Year  types.Int32 `tfsdk:"year"`
+	Circa     types.Bool   `tfsdk:"circa"`
+	Day       types.Int32  `tfsdk:"day"`
+	Month     types.Int32  `tfsdk:"month"`
+	Year      types.Int32  `tfsdk:"year"`
+	Calendar  types.String `tfsdk:"calendar"`
+	DualYear  types.Int32  `tfsdk:"dual_year"`
+	Gregorian types.String `tfsdk:"gregorian"`
 }
 
 func (m DateModel) AttributeTypes() map[string]attr.Type {
@@ -42,10 +45,13 @@ func (m DateModel) AttributeTypes() map[string]attr.Type {
 
 func DateModelAttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"circa": types.BoolType,
-		"day":   types.Int32Type,
-		"month": types.Int32Type,
-		"year":  types.Int32Type,
+		"circa":     types.BoolType,
+		"day":       types.Int32Type,
+		"month":     types.Int32Type,
+		"year":      types.Int32Type,
+		"calendar":  types.StringType,
+		"dual_year": types.Int32Type,
+		"gregorian": types.StringType,
 	}
 }
 
@@ -73,6 +79,9 @@ func DateRangeModelAttributeTypes() map[string]attr.Type {
 		"end_day":   types.Int32Type,
 		"end_month": types.Int32Type,
 		"end_year":  types.Int32Type,
+		"calendar":  types.StringType,
+		"dual_year": types.Int32Type,
+		"gregorian": types.StringType,
 	}
 }
 
@@ -83,10 +92,12 @@ type LocationModel struct {
 	Latitude       types.Float64 `tfsdk:"latitude"`
 	Longitude      types.Float64 `tfsdk:"longitude"`
 	PlaceName      types.String  `tfsdk:"place_name"`
+	PlaceID        types.String  `tfsdk:"place_id"`
 	State          types.String  `tfsdk:"state"`
 	StreetAddress1 types.String  `tfsdk:"street_address1"`
 	StreetAddress2 types.String  `tfsdk:"street_address2"`
 	StreetAddress3 types.String  `tfsdk:"street_address3"`
+	Historical     types.Object  `tfsdk:"historical"`
 }
 
 func (m LocationModel) AttributeTypes() map[string]attr.Type {
@@ -101,9 +112,13 @@ func LocationModelAttributeTypes() map[string]attr.Type {
 		"latitude":        types.Float64Type,
 		"longitude":       types.Float64Type,
 		"place_name":      types.StringType,
+		"place_id":        types.StringType,
 		"state":           types.StringType,
 		"street_address1": types.StringType,
 		"street_address2": types.StringType,
 		"street_address3": types.StringType,
+		"historical": types.ObjectType{
+			AttrTypes: HistoricalModelAttributeTypes(),
+		},
 	}
 }
@@ -27,7 +27,7 @@ func EventNeedsDatePreWipe(state, plan types.Object) bool {
 	planAttrs := planDate.Attributes()
 
 	for name, stateVal := range stateAttrs {
-		if stateVal.IsNull() {
+		if stateVal.IsNull() || !sentToGeni(name) {
 			continue
 		}
 		if planVal, present := planAttrs[name]; present && planVal.IsNull() {
@@ -53,3 +53,10 @@ func dateOf(event types.Object) (types.Object, bool) {
 	}
 	return dateObj, true
 }
+
+// sentToGeni reports whether a date attribute is one of Geni's date fields.
+// calendar and dual_year only change how the others are converted, and
+// gregorian is computed, so clearing them clears nothing on Geni.
+func sentToGeni(name string) bool {
+	return name != "calendar" && name != "dual_year" && name != "gregorian"
+}
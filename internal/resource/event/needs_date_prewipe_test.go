@@ -124,6 +124,9 @@ func eventWithDate(fields map[string]attr.Value) types.Object {
 		"end_day":   types.Int32Null(),
 		"end_month": types.Int32Null(),
 		"end_year":  types.Int32Null(),
+		"calendar":  types.StringNull(),
+		"dual_year": types.Int32Null(),
+		"gregorian": types.StringNull(),
 	}
 	maps.Copy(full, fields)
 	dateObj := types.ObjectValueMust(DateRangeModelAttributeTypes(), full)
@@ -0,0 +1,170 @@
+package event
+
+import (
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+// circaMargin is how far, in days, a circa date is taken to be off at most.
+const circaMargin = 5 * 366
+
+// Period is the span of days an event may have happened on, as Gregorian
+// Julian Day Numbers, both ends included. An open end, as a "before" or
+// "after" range has, is math.MinInt64 or math.MaxInt64.
+type Period struct {
+	From, To int64
+}
+
+// PeriodOf returns the period of the date of event, an object of Schema, and
+// false when the event has no date with a year, or its date is not known yet.
+func PeriodOf(event types.Object) (Period, bool) {
+	date, ok := dateOf(event)
+	if !ok {
+		return Period{}, false
+	}
+	attrs := date.Attributes()
+
+	w, ok := datingFrom(attrs)
+	if !ok {
+		return Period{}, false
+	}
+	start, ok := calendarDateFrom(attrs, "day", "month", "year")
+	if !ok {
+		return Period{}, false
+	}
+	end, ok := calendarDateFrom(attrs, "end_day", "end_month", "end_year")
+	if !ok {
+		return Period{}, false
+	}
+	rangeValue, _ := attrs["range"].(types.String)
+	circa, _ := attrs["circa"].(types.Bool)
+	endCirca, _ := attrs["end_circa"].(types.Bool)
+	dualYear, _ := attrs["dual_year"].(types.Int32)
+	if rangeValue.IsUnknown() || circa.IsUnknown() || endCirca.IsUnknown() {
+		return Period{}, false
+	}
+
+	return periodFrom(rangeValue.ValueString(),
+		dayBounds{w.toGeni(start, dualYear.ValueInt32()), w.julian, circa.ValueBool()},
+		dayBounds{w.toGeni(end, 0), w.julian, endCirca.ValueBool()})
+}
+
+// PeriodOfElement returns the period of the date of an event as Geni stores
+// it, and false when it has no date with a year.
+func PeriodOfElement(event *geniprofile.EventElement) (Period, bool) {
+	if event == nil || event.Date == nil {
+		return Period{}, false
+	}
+	date := event.Date
+	rangeValue := ""
+	if date.Range != nil {
+		rangeValue = *date.Range
+	}
+	return periodFrom(rangeValue,
+		dayBounds{calendarDateOf(date.Day, date.Month, date.Year), false, date.Circa != nil && *date.Circa},
+		dayBounds{calendarDateOf(date.EndDay, date.EndMonth, date.EndYear), false, date.EndCirca != nil && *date.EndCirca})
+}
+
+// dayBounds is a date, as Geni stores it, to take the first and last day of.
+type dayBounds struct {
+	date   calendarDate
+	julian bool
+	circa  bool
+}
+
+// bounds returns the first and last day d may be: the whole year or month
+// when its day or month is unset, widened by circaMargin when it is circa.
+// A partial Julian date is stored as written, so its last day is moved by
+// the most the calendars differ. It reports false when d has no year.
+func (b dayBounds) bounds() (int64, int64, bool) {
+	d := b.date
+	if d.year == 0 {
+		return 0, 0, false
+	}
+	first, last := d, d
+	switch {
+	case d.month < 1 || d.month > 12:
+		first.month, first.day = 1, 1
+		last.month, last.day = 12, 31
+	case d.day < 1 || d.day > d.daysInMonth(false):
+		first.day = 1
+		last.day = d.daysInMonth(false)
+	}
+	from, to := first.julianDay(false), last.julianDay(false)
+	if b.julian && !d.complete() {
+		to += 13
+	}
+	if b.circa {
+		from -= circaMargin
+		to += circaMargin
+	}
+	return from, to, true
+}
+
+func periodFrom(rangeValue string, start, end dayBounds) (Period, bool) {
+	from, to, ok := start.bounds()
+	if !ok {
+		return Period{}, false
+	}
+	switch rangeValue {
+	case "before":
+		return Period{From: math.MinInt64, To: to}, true
+	case "after":
+		return Period{From: from, To: math.MaxInt64}, true
+	case "between":
+		if _, endTo, ok := end.bounds(); ok {
+			return Period{From: from, To: endTo}, true
+		}
+		return Period{From: from, To: math.MaxInt64}, true
+	}
+	return Period{From: from, To: to}, true
+}
+
+// After reports whether p is certainly after other: even its first day is
+// after the last day of other.
+func (p Period) After(other Period) bool {
+	return p.From > other.To
+}
+
+// Extended returns p with its last day moved days later. An open end stays
+// open.
+func (p Period) Extended(days int64) Period {
+	if p.To != math.MaxInt64 {
+		p.To += days
+	}
+	return p
+}
+
+// Earliest returns the first day of p, as "1 March 1900", or "" when p has no
+// first day.
+func (p Period) Earliest() string {
+	if p.From == math.MinInt64 {
+		return ""
+	}
+	return dateOfJulianDay(p.From, false).String()
+}
+
+// Latest returns the last day of p, as "1 March 1900", or "" when p has no
+// last day.
+func (p Period) Latest() string {
+	if p.To == math.MaxInt64 {
+		return ""
+	}
+	return dateOfJulianDay(p.To, false).String()
+}
+
+// YearsBetween returns the whole years from day from to day to, as Julian Day
+// Numbers.
+func YearsBetween(from, to int64) int64 {
+	return int64(float64(to-from) / 365.2425)
+}
+
+// DayOf returns the Julian Day Number of the day t falls on in UTC.
+func DayOf(t time.Time) int64 {
+	t = t.UTC()
+	return calendarDate{day: int32(t.Day()), month: int32(t.Month()), year: int32(t.Year())}.julianDay(false)
+}
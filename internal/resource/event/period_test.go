@@ -0,0 +1,89 @@
+package event
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+func TestPeriodOf(t *testing.T) {
+	t.Run("A partial date spans its year or month", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		period, ok := PeriodOfElement(&geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(int32(1900))}})
+		Expect(ok).To(BeTrue())
+		Expect(period.Earliest()).To(Equal("1 January 1900"))
+		Expect(period.Latest()).To(Equal("31 December 1900"))
+
+		period, ok = PeriodOfElement(&geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(int32(1900)), Month: new(int32(2))}})
+		Expect(ok).To(BeTrue())
+		Expect(period.Earliest()).To(Equal("1 February 1900"))
+		Expect(period.Latest()).To(Equal("28 February 1900"))
+	})
+
+	t.Run("A date without a year has no period", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		_, ok := PeriodOfElement(&geniprofile.EventElement{Date: &geniprofile.DateElement{Month: new(int32(2))}})
+		Expect(ok).To(BeFalse())
+		_, ok = PeriodOfElement(nil)
+		Expect(ok).To(BeFalse())
+	})
+
+	t.Run("Ranges are open or closed", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		before, _ := PeriodOfElement(&geniprofile.EventElement{Date: &geniprofile.DateElement{Range: new("before"), Year: new(int32(1900))}})
+		Expect(before.From).To(Equal(int64(math.MinInt64)))
+		Expect(before.Latest()).To(Equal("31 December 1900"))
+
+		after, _ := PeriodOfElement(&geniprofile.EventElement{Date: &geniprofile.DateElement{Range: new("after"), Year: new(int32(1900))}})
+		Expect(after.Earliest()).To(Equal("1 January 1900"))
+		Expect(after.To).To(Equal(int64(math.MaxInt64)))
+
+		between, _ := PeriodOfElement(&geniprofile.EventElement{Date: &geniprofile.DateElement{
+			Range: new("between"), Year: new(int32(1900)), EndYear: new(int32(1910)), EndMonth: new(int32(6)),
+		}})
+		Expect(between.Earliest()).To(Equal("1 January 1900"))
+		Expect(between.Latest()).To(Equal("30 June 1910"))
+	})
+
+	t.Run("A configured Julian date is compared in the Gregorian calendar", func(t *testing.T) {
+		RegisterTestingT(t)
+		e := types.ObjectValueMust(EventModelAttributeTypes(), map[string]attr.Value{
+			"name":        types.StringNull(),
+			"description": types.StringNull(),
+			"date": dateRange(map[string]attr.Value{
+				"day": int32Value(11), "month": int32Value(2), "year": int32Value(1731),
+				"dual_year": int32Value(1732), "calendar": types.StringValue(CalendarJulian),
+			}),
+			"location": types.ObjectNull(LocationModelAttributeTypes()),
+		})
+
+		period, ok := PeriodOf(e)
+
+		Expect(ok).To(BeTrue())
+		Expect(period.Earliest()).To(Equal("22 February 1732"))
+		Expect(period.Latest()).To(Equal("22 February 1732"))
+	})
+
+	t.Run("A period is after another only when all of it is", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(Period{From: 10, To: 20}.After(Period{From: 0, To: 9})).To(BeTrue())
+		Expect(Period{From: 10, To: 20}.After(Period{From: 0, To: 10})).To(BeFalse())
+		Expect(Period{From: 10, To: 20}.After(Period{From: 0, To: math.MaxInt64})).To(BeFalse())
+	})
+
+	t.Run("Extending a period moves its last day unless it is open", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		Expect(Period{From: 10, To: 20}.Extended(5)).To(Equal(Period{From: 10, To: 25}))
+		Expect(Period{From: 10, To: math.MaxInt64}.Extended(5)).To(Equal(Period{From: 10, To: math.MaxInt64}))
+	})
+}
@@ -0,0 +1,177 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
+)
+
+// PlanPlace returns location, an object of LocationSchema at path at, with the
+// attributes its place_id leaves to the gazetteer — those placeComponentModifier
+// and placeCoordinateModifier planned as unknown — filled in from the place in
+// places. Set components that differ from the place's are warned about, since
+// a plan cannot change a configured value.
+func PlanPlace(ctx context.Context, location types.Object, places *gazetteer.Gazetteer, at path.Path) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if location.IsNull() || location.IsUnknown() {
+		return location, diags
+	}
+	attrs := location.Attributes()
+	placeID, _ := attrs["place_id"].(types.String)
+	if placeID.IsNull() || placeID.IsUnknown() {
+		return location, diags
+	}
+
+	if places == nil {
+		diags.AddAttributeError(at.AtName("place_id"), "Gazetteer Not Configured",
+			"place_id references a place in the gazetteer, but the provider has none. Set gazetteer in the provider configuration.")
+		return location, diags
+	}
+	place, ok := places.Place(placeID.ValueString())
+	if !ok {
+		diags.AddAttributeError(at.AtName("place_id"), "Unknown Place",
+			fmt.Sprintf("The gazetteer has no place with id %q.", placeID.ValueString()))
+		return location, diags
+	}
+
+	updated := maps.Clone(attrs)
+	for _, name := range gazetteer.Components {
+		value, _ := attrs[name].(types.String)
+		want := place.Component(name)
+		switch {
+		case value.IsUnknown():
+			updated[name] = types.StringNull()
+			if want != "" {
+				updated[name] = types.StringValue(want)
+			}
+		case value.IsNull() || value.ValueString() == want:
+		case place.Matches(name, value.ValueString()):
+			diags.AddAttributeWarning(at.AtName(name), "Location Differs From Gazetteer",
+				fmt.Sprintf("%s %q is another spelling of %q, the %s of place %s in the gazetteer. Remove %s to use the gazetteer's.",
+					name, value.ValueString(), want, name, place.ID, name))
+		default:
+			diags.AddAttributeWarning(at.AtName(name), "Location Differs From Gazetteer",
+				fmt.Sprintf("%s is %q, but place %s in the gazetteer has %s. Remove %s to use the gazetteer's, or check place_id.",
+					name, value.ValueString(), place.ID, gazetteerValue(want), name))
+		}
+	}
+	for name, coordinate := range map[string]*float64{"latitude": place.Latitude, "longitude": place.Longitude} {
+		if value, _ := attrs[name].(types.Float64); value.IsUnknown() {
+			updated[name] = types.Float64PointerValue(coordinate)
+		}
+	}
+
+	location, d := types.ObjectValue(location.AttributeTypes(ctx), updated)
+	diags.Append(d...)
+	return location, diags
+}
+
+func gazetteerValue(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+// PlanEventPlace does PlanPlace for the location of event, an object of Schema
+// at path at.
+func PlanEventPlace(ctx context.Context, event types.Object, places *gazetteer.Gazetteer, at path.Path) (types.Object, diag.Diagnostics) {
+	if event.IsNull() || event.IsUnknown() {
+		return event, nil
+	}
+	attrs := event.Attributes()
+	location, _ := attrs["location"].(types.Object)
+	planned, diags := PlanPlace(ctx, location, places, at.AtName("location"))
+	if diags.HasError() || planned.Equal(location) {
+		return event, diags
+	}
+
+	updated := maps.Clone(attrs)
+	updated["location"] = planned
+	event, d := types.ObjectValue(event.AttributeTypes(ctx), updated)
+	diags.Append(d...)
+	return event, diags
+}
+
+// configuredPlaceID returns the configured place_id of the location the
+// attribute at p belongs to.
+func configuredPlaceID(ctx context.Context, config tfsdk.Config, p path.Path) (types.String, diag.Diagnostics) {
+	var placeID types.String
+	diags := config.GetAttribute(ctx, p.ParentPath().AtName("place_id"), &placeID)
+	return placeID, diags
+}
+
+var _ planmodifier.String = placeComponentModifier{}
+
+// placeComponentModifier plans a location component that is not configured:
+// as unknown when the location has a place_id, for PlanPlace to fill in from
+// the gazetteer, and as null otherwise, as if the attribute were not computed.
+type placeComponentModifier struct{}
+
+func (m placeComponentModifier) Description(_ context.Context) string {
+	return "fills in the component from the gazetteer when place_id is set"
+}
+
+func (m placeComponentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m placeComponentModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+	placeID, diags := configuredPlaceID(ctx, req.Config, req.Path)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if placeID.IsNull() {
+		resp.PlanValue = types.StringNull()
+	} else {
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+var _ planmodifier.Float64 = placeCoordinateModifier{}
+
+// placeCoordinateModifier plans a coordinate that is not configured as unknown
+// when the location has a place_id, for PlanPlace to fill in from the
+// gazetteer.
+type placeCoordinateModifier struct{}
+
+func (m placeCoordinateModifier) Description(_ context.Context) string {
+	return "takes the coordinate from the gazetteer when place_id is set"
+}
+
+func (m placeCoordinateModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m placeCoordinateModifier) PlanModifyFloat64(ctx context.Context, req planmodifier.Float64Request, resp *planmodifier.Float64Response) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+	placeID, diags := configuredPlaceID(ctx, req.Config, req.Path)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || placeID.IsNull() {
+		return
+	}
+	resp.PlanValue = types.Float64Unknown()
+}
+
+// placeIDFrom returns the place_id of prior, a location object, to keep in a
+// location read from Geni, which does not record it.
+func placeIDFrom(prior types.Object) types.String {
+	if placeID, ok := prior.Attributes()["place_id"].(types.String); ok && !placeID.IsUnknown() {
+		return placeID
+	}
+	return types.StringNull()
+}
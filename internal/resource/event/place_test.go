@@ -0,0 +1,218 @@
+package event
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
+)
+
+const places = "id\tcity\tstate\tcountry\tlatitude\tlongitude\talternate_names\n" +
+	"524901\tMoscow\t\tRussia\t55.75222\t37.61556\tMoskva,Москва\n"
+
+func TestPlanPlace(t *testing.T) {
+	g, err := gazetteer.Read(strings.NewReader(places))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := path.Root("birth").AtName("location")
+
+	t.Run("Attributes left to the gazetteer are filled in from the place", func(t *testing.T) {
+		RegisterTestingT(t)
+		location := locationWith(map[string]attr.Value{
+			"place_id":  types.StringValue("524901"),
+			"city":      types.StringUnknown(),
+			"state":     types.StringUnknown(),
+			"country":   types.StringUnknown(),
+			"latitude":  types.Float64Unknown(),
+			"longitude": types.Float64Unknown(),
+		})
+
+		planned, diags := PlanPlace(t.Context(), location, g, at)
+
+		Expect(diags).To(BeEmpty())
+		attrs := planned.Attributes()
+		Expect(attrs["city"]).To(Equal(types.StringValue("Moscow")))
+		Expect(attrs["state"]).To(Equal(types.StringNull()))
+		Expect(attrs["country"]).To(Equal(types.StringValue("Russia")))
+		Expect(attrs["latitude"]).To(Equal(types.Float64Value(55.75222)))
+		Expect(attrs["longitude"]).To(Equal(types.Float64Value(37.61556)))
+		Expect(attrs["street_address1"]).To(Equal(types.StringNull()))
+	})
+
+	t.Run("Set attributes are kept, with a warning when they differ from the place", func(t *testing.T) {
+		RegisterTestingT(t)
+		location := locationWith(map[string]attr.Value{
+			"place_id":  types.StringValue("524901"),
+			"city":      types.StringValue("Moskva"),
+			"country":   types.StringValue("Russian Empire"),
+			"latitude":  types.Float64Value(55.7),
+			"longitude": types.Float64Value(37.6),
+		})
+
+		planned, diags := PlanPlace(t.Context(), location, g, at)
+
+		Expect(planned).To(Equal(location))
+		Expect(diags).To(HaveLen(2))
+		Expect(diags.HasError()).To(BeFalse())
+		byPath := make(map[string]string)
+		for _, d := range diags {
+			byPath[d.(diag.DiagnosticWithPath).Path().String()] = d.Detail()
+		}
+		Expect(byPath).To(Equal(map[string]string{
+			"birth.location.city": `city "Moskva" is another spelling of "Moscow", the city of place 524901 in the gazetteer. ` +
+				"Remove city to use the gazetteer's.",
+			"birth.location.country": `country is "Russian Empire", but place 524901 in the gazetteer has "Russia". ` +
+				"Remove country to use the gazetteer's, or check place_id.",
+		}))
+	})
+
+	t.Run("A place that cannot be looked up fails the plan", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		_, diags := PlanPlace(t.Context(), locationWith(map[string]attr.Value{"place_id": types.StringValue("1")}), g, at)
+		Expect(diags.Errors()).To(HaveLen(1))
+		Expect(diags.Errors()[0].Summary()).To(Equal("Unknown Place"))
+		Expect(diags.Errors()[0].(diag.DiagnosticWithPath).Path()).To(Equal(at.AtName("place_id")))
+
+		_, diags = PlanPlace(t.Context(), locationWith(map[string]attr.Value{"place_id": types.StringValue("524901")}), nil, at)
+		Expect(diags.Errors()).To(HaveLen(1))
+		Expect(diags.Errors()[0].Summary()).To(Equal("Gazetteer Not Configured"))
+	})
+
+	t.Run("A location without a place is left alone", func(t *testing.T) {
+		RegisterTestingT(t)
+		location := locationWith(map[string]attr.Value{
+			"city":     types.StringValue("Moskva"),
+			"latitude": types.Float64Unknown(),
+		})
+
+		planned, diags := PlanPlace(t.Context(), location, nil, at)
+
+		Expect(diags).To(BeEmpty())
+		Expect(planned).To(Equal(location))
+	})
+
+	t.Run("The location of an event is planned", func(t *testing.T) {
+		RegisterTestingT(t)
+		location := locationWith(map[string]attr.Value{
+			"place_id": types.StringValue("524901"),
+			"city":     types.StringUnknown(),
+		})
+		event := types.ObjectValueMust(EventModelAttributeTypes(), map[string]attr.Value{
+			"name":        types.StringValue("Birth"),
+			"description": types.StringNull(),
+			"date":        types.ObjectNull(DateRangeModelAttributeTypes()),
+			"location":    location,
+		})
+
+		planned, diags := PlanEventPlace(t.Context(), event, g, path.Root("birth"))
+
+		Expect(diags).To(BeEmpty())
+		city := planned.Attributes()["location"].(types.Object).Attributes()["city"]
+		Expect(city).To(Equal(types.StringValue("Moscow")))
+	})
+}
+
+func TestPlaceModifiers(t *testing.T) {
+	locationSchema := schema.Schema{Attributes: map[string]schema.Attribute{"location": LocationSchema("")}}
+	config := func(t *testing.T, set map[string]attr.Value) tfsdk.Config {
+		t.Helper()
+		plan := tfsdk.Plan{Schema: locationSchema}
+		Expect(plan.Set(t.Context(), struct {
+			Location types.Object `tfsdk:"location"`
+		}{locationWith(set)}).HasError()).To(BeFalse())
+		return tfsdk.Config{Schema: locationSchema, Raw: plan.Raw}
+	}
+	city := path.Root("location").AtName("city")
+	latitude := path.Root("location").AtName("latitude")
+
+	t.Run("An unset component is null without a place and unknown with one", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for placeID, want := range map[types.String]types.String{
+			types.StringNull():        types.StringNull(),
+			types.StringValue("1234"): types.StringUnknown(),
+		} {
+			req := planmodifier.StringRequest{
+				Path:        city,
+				Config:      config(t, map[string]attr.Value{"place_id": placeID, "county": types.StringValue("Kent")}),
+				ConfigValue: types.StringNull(),
+				StateValue:  types.StringValue("Canterbury"),
+				PlanValue:   types.StringUnknown(),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			placeComponentModifier{}.PlanModifyString(t.Context(), req, resp)
+
+			Expect(resp.Diagnostics).To(BeEmpty())
+			Expect(resp.PlanValue).To(Equal(want))
+		}
+	})
+
+	t.Run("A set component is left as configured", func(t *testing.T) {
+		RegisterTestingT(t)
+		req := planmodifier.StringRequest{
+			Path:        city,
+			Config:      config(t, map[string]attr.Value{"place_id": types.StringValue("1234"), "city": types.StringValue("Moskva")}),
+			ConfigValue: types.StringValue("Moskva"),
+			PlanValue:   types.StringValue("Moskva"),
+		}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+		placeComponentModifier{}.PlanModifyString(t.Context(), req, resp)
+
+		Expect(resp.PlanValue).To(Equal(types.StringValue("Moskva")))
+	})
+
+	t.Run("An unset coordinate is left to Geni without a place and unknown with one", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for placeID, want := range map[types.String]types.Float64{
+			types.StringNull():        types.Float64Value(51.28),
+			types.StringValue("1234"): types.Float64Unknown(),
+		} {
+			req := planmodifier.Float64Request{
+				Path:        latitude,
+				Config:      config(t, map[string]attr.Value{"place_id": placeID, "county": types.StringValue("Kent")}),
+				ConfigValue: types.Float64Null(),
+				PlanValue:   types.Float64Value(51.28),
+			}
+			resp := &planmodifier.Float64Response{PlanValue: req.PlanValue}
+			placeCoordinateModifier{}.PlanModifyFloat64(t.Context(), req, resp)
+
+			Expect(resp.Diagnostics).To(BeEmpty())
+			Expect(resp.PlanValue).To(Equal(want))
+		}
+	})
+}
+
+// locationWith returns a location object with the attributes in set, and the
+// others null.
+func locationWith(set map[string]attr.Value) types.Object {
+	attrs := map[string]attr.Value{
+		"city":            types.StringNull(),
+		"country":         types.StringNull(),
+		"county":          types.StringNull(),
+		"latitude":        types.Float64Null(),
+		"longitude":       types.Float64Null(),
+		"place_name":      types.StringNull(),
+		"place_id":        types.StringNull(),
+		"state":           types.StringNull(),
+		"street_address1": types.StringNull(),
+		"street_address2": types.StringNull(),
+		"street_address3": types.StringNull(),
+		"historical":      types.ObjectNull(HistoricalModelAttributeTypes()),
+	}
+	maps.Copy(attrs, set)
+	return types.ObjectValueMust(LocationModelAttributeTypes(), attrs)
+}
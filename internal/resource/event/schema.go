@@ -1,6 +1,8 @@
 package event
 
 import (
+	"regexp"
+
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -56,36 +58,50 @@ func LocationSchema(description string) schema.SingleNestedAttribute {
 		Optional: true,
 		Attributes: map[string]schema.Attribute{
 			"city": schema.StringAttribute{
-				Optional:    true,
-				Description: "City name.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{placeComponentModifier{}},
+				Description:   "City name. Filled in from the gazetteer when place_id is set.",
 			},
 			"country": schema.StringAttribute{
-				Optional:    true,
-				Description: "Country name.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{placeComponentModifier{}},
+				Description:   "Country name. Filled in from the gazetteer when place_id is set.",
 			},
 			"county": schema.StringAttribute{
-				Optional:    true,
-				Description: "County name.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{placeComponentModifier{}},
+				Description:   "County name. Filled in from the gazetteer when place_id is set.",
 			},
 			"latitude": schema.Float64Attribute{
 				Optional:      true,
 				Computed:      true,
-				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
-				Description:   "Latitude coordinate.",
+				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown(), placeCoordinateModifier{}},
+				Description:   "Latitude coordinate. Taken from the gazetteer when place_id is set, and computed by Geni otherwise.",
 			},
 			"longitude": schema.Float64Attribute{
 				Optional:      true,
 				Computed:      true,
-				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
-				Description:   "Longitude coordinate.",
+				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown(), placeCoordinateModifier{}},
+				Description:   "Longitude coordinate. Taken from the gazetteer when place_id is set, and computed by Geni otherwise.",
 			},
 			"place_name": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{placeComponentModifier{}},
+				Description:   "Place name. Filled in from the gazetteer when place_id is set.",
+			},
+			"place_id": schema.StringAttribute{
 				Optional:    true,
-				Description: "Place name.",
+				Description: "Id of the place in the provider's gazetteer, such as a GeoNames id. The place's names and coordinates fill in the location attributes that are not set, and set ones that differ from the place's are warned about. Requires the gazetteer provider attribute.",
 			},
 			"state": schema.StringAttribute{
-				Optional:    true,
-				Description: "State name.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{placeComponentModifier{}},
+				Description:   "State name. Filled in from the gazetteer when place_id is set.",
 			},
 			"street_address1": schema.StringAttribute{
 				Optional:    true,
@@ -99,6 +115,7 @@ func LocationSchema(description string) schema.SingleNestedAttribute {
 				Optional:    true,
 				Description: "Third line of the street address.",
 			},
+			"historical": historicalSchema(),
 		},
 		Validators: []validator.Object{
 			objectvalidator.Any(
@@ -106,6 +123,7 @@ func LocationSchema(description string) schema.SingleNestedAttribute {
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("country")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("county")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("place_name")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("place_id")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("state")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("street_address1")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("street_address2")),
@@ -129,15 +147,67 @@ func ComputedLocationSchema(description string) schema.SingleNestedAttribute {
 			"latitude":        schema.Float64Attribute{Computed: true, Description: "Latitude coordinate."},
 			"longitude":       schema.Float64Attribute{Computed: true, Description: "Longitude coordinate."},
 			"place_name":      schema.StringAttribute{Computed: true, Description: "Place name."},
+			"place_id":        schema.StringAttribute{Computed: true, Description: "Id of the place in the provider's gazetteer. Always null, since Geni does not record one."},
 			"state":           schema.StringAttribute{Computed: true, Description: "State name."},
 			"street_address1": schema.StringAttribute{Computed: true, Description: "First line of the street address."},
 			"street_address2": schema.StringAttribute{Computed: true, Description: "Second line of the street address."},
 			"street_address3": schema.StringAttribute{Computed: true, Description: "Third line of the street address."},
+			"historical": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"city":       schema.StringAttribute{Computed: true, Description: "City name as recorded."},
+					"country":    schema.StringAttribute{Computed: true, Description: "Country name as recorded."},
+					"county":     schema.StringAttribute{Computed: true, Description: "County name as recorded."},
+					"place_name": schema.StringAttribute{Computed: true, Description: "Place name as recorded."},
+					"state":      schema.StringAttribute{Computed: true, Description: "State name as recorded."},
+				},
+				Description: "Names of the location's jurisdictions as recorded at the time. Always null, since Geni does not record them.",
+			},
 		},
 		Description: description,
 	}
 }
 
+// historicalFormat keeps historical names to what fits a line of a description.
+var historicalFormat = regexp.MustCompile(`^[^;\n]*\S[^;\n]*$`)
+
+// historicalSchema is the schema of a location's historical names, the names
+// of its jurisdictions as recorded at the time of the event, such as a
+// governorate of the Russian Empire for a place now in a Russian oblast. Geni
+// has no field for them, so they are kept on a line of the description of the
+// event or document the location belongs to.
+func historicalSchema() schema.SingleNestedAttribute {
+	nameAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:    true,
+			Description: description,
+			Validators:  []validator.String{stringvalidator.RegexMatches(historicalFormat, "must not be blank or contain semicolons or line breaks")},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"city":       nameAttribute("City name as recorded."),
+			"country":    nameAttribute("Country name as recorded."),
+			"county":     nameAttribute("County name as recorded."),
+			"place_name": nameAttribute("Place name as recorded."),
+			"state":      nameAttribute("State name as recorded."),
+		},
+		Validators: []validator.Object{
+			objectvalidator.Any(
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("city")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("country")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("county")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("place_name")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("state")),
+			),
+		},
+		Description: "Names of the location's jurisdictions as recorded at the time of the event, kept alongside its modern names. " +
+			"Geni has no field for them, so they are stored on the last line of the description of the event or document, which reads back without it.",
+	}
+}
+
 func DateSchema(description string) schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
 		Optional: true,
@@ -158,6 +228,20 @@ func DateSchema(description string) schema.SingleNestedAttribute {
 				Optional:    true,
 				Description: "Date's year.",
 			},
+			"calendar": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(CalendarGregorian, CalendarJulian)},
+				Description: "Calendar the date is written in (gregorian or julian). Defaults to gregorian. Geni only stores Gregorian dates, so a complete Julian date is converted before it is sent and back when it is read.",
+			},
+			"dual_year": schema.Int32Attribute{
+				Optional:    true,
+				Description: "Second year of a dual date, such as 1732 for 11 February 1731/32, whose year is 1731. Only dates from 1 January to 24 March are dual-dated. Geni stores the date in this year.",
+			},
+			"gregorian": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{gregorianModifier{}},
+				Description:   "The date as Geni stores it, in the Gregorian calendar with the year beginning on 1 January: YYYY-MM-DD, YYYY-MM or YYYY. Null when the date has no year.",
+			},
 		},
 		Validators: []validator.Object{
 			objectvalidator.Any(
@@ -165,6 +249,7 @@ func DateSchema(description string) schema.SingleNestedAttribute {
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("month")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("year")),
 			),
+			DateValidator(),
 		},
 		Description: description,
 	}
@@ -214,6 +299,20 @@ func DateRangeSchema(description string) schema.SingleNestedAttribute {
 				Validators:  []validator.Int32{int32validator.AlsoRequires(rangePath)},
 				Description: "Date's end year (only valid if range is between).",
 			},
+			"calendar": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(CalendarGregorian, CalendarJulian)},
+				Description: "Calendar the date is written in (gregorian or julian). Defaults to gregorian. Geni only stores Gregorian dates, so a complete Julian date is converted before it is sent and back when it is read.",
+			},
+			"dual_year": schema.Int32Attribute{
+				Optional:    true,
+				Description: "Second year of a dual date, such as 1732 for 11 February 1731/32, whose year is 1731. Only dates from 1 January to 24 March are dual-dated. Geni stores the date in this year.",
+			},
+			"gregorian": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{gregorianModifier{}},
+				Description:   "The start date as Geni stores it, in the Gregorian calendar with the year beginning on 1 January: YYYY-MM-DD, YYYY-MM or YYYY. Null when the date has no year.",
+			},
 		},
 		Validators: []validator.Object{
 			objectvalidator.Any(
@@ -221,6 +320,7 @@ func DateRangeSchema(description string) schema.SingleNestedAttribute {
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("month")),
 				objectvalidator.AlsoRequires(path.MatchRelative().AtName("year")),
 			),
+			DateValidator(),
 		},
 		Description: description,
 	}
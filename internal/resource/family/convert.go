@@ -0,0 +1,123 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
+)
+
+// spousesFrom returns the spouse entries of list, or none while it is unknown.
+func spousesFrom(ctx context.Context, list types.List) ([]SpouseModel, diag.Diagnostics) {
+	var spouses []SpouseModel
+	if list.IsNull() || list.IsUnknown() {
+		return spouses, nil
+	}
+	diags := list.ElementsAs(ctx, &spouses, false)
+	return spouses, diags
+}
+
+// spousesByPartner returns the spouse entries of list by partner.
+func spousesByPartner(ctx context.Context, list types.List) (map[string]SpouseModel, diag.Diagnostics) {
+	spouses, diags := spousesFrom(ctx, list)
+	byPartner := make(map[string]SpouseModel, len(spouses))
+	for _, spouse := range spouses {
+		byPartner[spouse.Partner.ValueString()] = spouse
+	}
+	return byPartner, diags
+}
+
+// spousesValue returns spouses as the value of the spouses attribute.
+func spousesValue(ctx context.Context, spouses []SpouseModel) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: SpouseModelAttributeTypes()}, spouses)
+}
+
+// unionFrom returns the union of profile and spouse.
+func unionFrom(ctx context.Context, profile types.String, spouse SpouseModel) (union.ResourceModel, diag.Diagnostics) {
+	partners, diags := types.SetValueFrom(ctx, types.StringType, []types.String{profile, spouse.Partner})
+	return union.ResourceModel{
+		ID:              spouse.UnionID,
+		Partners:        partners,
+		Children:        spouse.Children,
+		FosterChildren:  spouse.FosterChildren,
+		AdoptedChildren: spouse.AdoptedChildren,
+		Marriage:        spouse.Marriage,
+		Divorce:         spouse.Divorce,
+	}, diags
+}
+
+// spouseFrom returns spouse updated from its union with profile. When the union
+// has a single partner besides profile, such as the profile a merge left the
+// spouse's in, that partner is the spouse.
+func spouseFrom(ctx context.Context, profile types.String, spouse SpouseModel, u union.ResourceModel) (SpouseModel, diag.Diagnostics) {
+	partnerIds, diags := tfset.Strings(ctx, u.Partners)
+	if diags.HasError() {
+		return spouse, diags
+	}
+	var others []string
+	for _, id := range partnerIds {
+		if id != profile.ValueString() {
+			others = append(others, id)
+		}
+	}
+	if len(others) == 1 {
+		spouse.Partner = types.StringValue(others[0])
+	}
+
+	spouse.UnionID = u.ID
+	spouse.Children = u.Children
+	spouse.FosterChildren = u.FosterChildren
+	spouse.AdoptedChildren = u.AdoptedChildren
+	spouse.Marriage = u.Marriage
+	spouse.Divorce = u.Divorce
+	return spouse, diags
+}
+
+// journalKey is the private state key the temporary profile journal of the
+// union with partner is kept under. spouseFrom can change the partner, and
+// the journal is moved to the new key when it does (see union.MoveJournal).
+func journalKey(partner types.String) string {
+	return "temp_profiles:" + partner.ValueString()
+}
+
+// spousePath returns the path of the i-th spouse entry.
+func spousePath(i int) path.Path {
+	return path.Root(fieldSpouses).AtListIndex(i)
+}
+
+// atSpouse moves diags about the union of the i-th spouse, whose paths are
+// those of a geni_union's attributes, onto the spouse entry.
+func atSpouse(diags diag.Diagnostics, i int) diag.Diagnostics {
+	moved := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok {
+			moved = append(moved, d)
+			continue
+		}
+		at := spousePath(i)
+		if steps := withPath.Path().Steps(); len(steps) > 0 {
+			if name, ok := steps[0].(path.PathStepAttributeName); ok {
+				at = at.AtName(spouseAttribute(string(name)))
+			}
+		}
+		moved = append(moved, diag.WithPath(at, d))
+	}
+	return moved
+}
+
+// spouseAttribute returns the spouse attribute for a geni_union attribute.
+func spouseAttribute(unionAttribute string) string {
+	switch unionAttribute {
+	case "id":
+		return fieldUnionID
+	case "partners":
+		return fieldPartner
+	default:
+		return unionAttribute
+	}
+}
@@ -0,0 +1,65 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+// Create creates the resource.
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spouses, diags := spousesFrom(ctx, plan.Spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Each union keeps its temporary profile journal under its own key. The
+	// framework always hands Create private state.
+	var private union.PrivateData
+	if resp.Private != nil {
+		private = resp.Private
+	}
+
+	// The unions are created in order. When one fails, it is kept if Geni
+	// created it at all, and the spouses after it are left for the next apply.
+	created := make([]SpouseModel, 0, len(spouses))
+	for i, spouse := range spouses {
+		planned, diags := unionFrom(ctx, plan.Profile, spouse)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			break
+		}
+
+		key := journalKey(spouse.Partner)
+		state, diags := r.union.CreateUnion(ctx, planned, private, key)
+		resp.Diagnostics.Append(atSpouse(diags, i)...)
+		if state.ID.IsUnknown() || state.ID.IsNull() {
+			break
+		}
+
+		spouse, diags = spouseFrom(ctx, plan.Profile, spouse, state)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(union.MoveJournal(ctx, private, key, journalKey(spouse.Partner))...)
+		created = append(created, spouse)
+		if resp.Diagnostics.HasError() {
+			break
+		}
+	}
+	if len(created) == 0 {
+		return // no union was created — nothing to track
+	}
+
+	plan.ID = plan.Profile
+	plan.Spouses, diags = spousesValue(ctx, created)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
@@ -0,0 +1,37 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+// Delete deletes the resource.
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spouses, diags := spousesFrom(ctx, state.Spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var private union.PrivateData
+	if resp.Private != nil {
+		private = resp.Private
+	}
+
+	// We can't delete a union, so we just remove the resource from the state,
+	// along with any temporary profiles failed applies left in the unions.
+	for _, spouse := range spouses {
+		resp.Diagnostics.Append(r.union.ForgetUnion(ctx, private, journalKey(spouse.Partner))...)
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,36 @@
+package family
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+type ResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Profile types.String `tfsdk:"profile"`
+	Spouses types.List   `tfsdk:"spouses"`
+}
+
+type SpouseModel struct {
+	Partner         types.String `tfsdk:"partner"`
+	UnionID         types.String `tfsdk:"union_id"`
+	Marriage        types.Object `tfsdk:"marriage"`
+	Divorce         types.Object `tfsdk:"divorce"`
+	Children        types.Set    `tfsdk:"children"`
+	FosterChildren  types.Set    `tfsdk:"foster_children"`
+	AdoptedChildren types.Set    `tfsdk:"adopted_children"`
+}
+
+func SpouseModelAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		fieldPartner:         types.StringType,
+		fieldUnionID:         types.StringType,
+		"marriage":           types.ObjectType{AttrTypes: event.EventModelAttributeTypes()},
+		"divorce":            types.ObjectType{AttrTypes: event.EventModelAttributeTypes()},
+		fieldChildren:        types.SetType{ElemType: types.StringType},
+		fieldFosterChildren:  types.SetType{ElemType: types.StringType},
+		fieldAdoptedChildren: types.SetType{ElemType: types.StringType},
+	}
+}
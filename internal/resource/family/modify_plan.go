@@ -0,0 +1,98 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ModifyPlan plans the union of every spouse entry as geni_union plans its
+// own. A spouse that was already in state keeps its union_id, wherever the
+// entry moved in the list; a new spouse adopts the union it already shares
+// with the profile on Geni, if any.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is planned on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Profile.IsUnknown() || plan.Spouses.IsUnknown() {
+		return
+	}
+
+	// A family replaced for another profile has none of the old one's unions.
+	var state ResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !state.Profile.Equal(plan.Profile) {
+			state = ResourceModel{}
+		}
+	}
+	previous, diags := spousesByPartner(ctx, state.Spouses)
+	resp.Diagnostics.Append(diags...)
+
+	spouses, diags := spousesFrom(ctx, plan.Spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, spouse := range spouses {
+		// A spouse that is not known until apply is a new profile, which
+		// shares no union yet: only its union's places are planned.
+		if spouse.Partner.IsUnknown() {
+			planned, diags := unionFrom(ctx, plan.Profile, spouse)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(atSpouse(r.union.PlanPlaces(ctx, &planned), i)...)
+			spouse.Marriage, spouse.Divorce = planned.Marriage, planned.Divorce
+			spouses[i] = spouse
+			continue
+		}
+
+		old, ok := previous[spouse.Partner.ValueString()]
+		if ok {
+			spouse.UnionID = old.UnionID
+		} else {
+			spouse.UnionID = types.StringUnknown()
+		}
+
+		planned, diags := unionFrom(ctx, plan.Profile, spouse)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if ok {
+			prior, diags := unionFrom(ctx, state.Profile, old)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(atSpouse(r.union.PlanUnion(ctx, &planned, &prior), i)...)
+		} else {
+			resp.Diagnostics.Append(atSpouse(r.union.PlanUnion(ctx, &planned, nil), i)...)
+			spouse.UnionID = planned.ID
+		}
+		spouse.Marriage, spouse.Divorce = planned.Marriage, planned.Divorce
+		spouses[i] = spouse
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := spousesValue(ctx, spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(fieldSpouses), planned)...)
+}
@@ -0,0 +1,102 @@
+package family
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+func TestModifyPlan(t *testing.T) {
+	t.Run("A spouse keeps its union_id when the spouses are reordered", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		first := spouse(t, "profile-2", nil, 1980)
+		first.UnionID = types.StringValue("union-1")
+		second := spouse(t, "profile-3", nil, 1990)
+		second.UnionID = types.StringValue("union-2")
+		state := familyPlan(t, "profile-1", first, second)
+		state.ID = types.StringValue("profile-1")
+		plan := familyPlan(t, "profile-1", spouse(t, "profile-3", nil, 1990), spouse(t, "profile-2", nil, 1980))
+		plan.ID = state.ID
+
+		planned, diags := modifyPlan(t, r, &state, plan)
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		spouses := spousesOf(t, planned)
+		Expect(spouses[0].UnionID.ValueString()).To(Equal("union-2"))
+		Expect(spouses[1].UnionID.ValueString()).To(Equal("union-1"))
+	})
+
+	t.Run("A new spouse adopts the union it already shares with the profile", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 2)
+		existing, diags := unionOf(t, familyPlan(t, "profile-1", spouse(t, "profile-2", nil, 1980)))
+		Expect(diags.HasError()).To(BeFalse())
+		existing, diags = r.union.CreateUnion(t.Context(), existing, nil, "")
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		planned, diags := modifyPlan(t, r, nil, familyPlan(t, "profile-1", spouse(t, "profile-2", nil, 1980)))
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(spousesOf(t, planned)[0].UnionID).To(Equal(existing.ID))
+		Expect(diags.Warnings()).To(HaveLen(1))
+		Expect(diags.Warnings()[0].Summary()).To(Equal("Adopting existing union"))
+		Expect(genitest.PathOf(diags.Warnings()[0])).To(Equal(path.Root(fieldSpouses).AtListIndex(0).AtName(fieldUnionID)))
+	})
+
+	t.Run("Without a website session, removing a spouse's child fails the plan at that spouse", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 4)
+		first := spouse(t, "profile-2", nil, 1980)
+		first.UnionID = types.StringValue("union-1")
+		second := spouse(t, "profile-3", []string{"profile-4"}, 1990)
+		second.UnionID = types.StringValue("union-2")
+		state := familyPlan(t, "profile-1", first, second)
+		state.ID = types.StringValue("profile-1")
+		plan := familyPlan(t, "profile-1", spouse(t, "profile-2", nil, 1980), spouse(t, "profile-3", nil, 1990))
+		plan.ID = state.ID
+
+		_, diags := modifyPlan(t, r, &state, plan)
+
+		Expect(diags.Errors()).To(HaveLen(1))
+		Expect(diags.Errors()[0].Summary()).To(Equal("Cannot remove children"))
+		Expect(genitest.PathOf(diags.Errors()[0])).To(Equal(path.Root(fieldSpouses).AtListIndex(1).AtName(fieldChildren)))
+	})
+}
+
+// unionOf returns the union of the family's profile and its first spouse.
+func unionOf(t *testing.T, family ResourceModel) (union.ResourceModel, diag.Diagnostics) {
+	t.Helper()
+	return unionFrom(t.Context(), family.Profile, spousesOf(t, family)[0])
+}
+
+// modifyPlan drives r.ModifyPlan from state, nil for a new family, to plan and
+// returns the resulting plan.
+func modifyPlan(t *testing.T, r *Resource, state *ResourceModel, plan ResourceModel) (ResourceModel, diag.Diagnostics) {
+	t.Helper()
+	familySchema := schemaOf(t, r)
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: familySchema, Raw: nullValue(t, familySchema)},
+		State: tfsdk.State{Schema: familySchema, Raw: nullValue(t, familySchema)},
+	}
+	Expect(req.Plan.Set(t.Context(), plan).HasError()).To(BeFalse())
+	if state != nil {
+		Expect(req.State.Set(t.Context(), state).HasError()).To(BeFalse())
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(t.Context(), req, resp)
+
+	var planned ResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(t.Context(), &planned)...)
+	return planned, resp.Diagnostics
+}
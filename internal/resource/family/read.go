@@ -0,0 +1,68 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+// Read reads the resource.
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spouses, diags := spousesFrom(ctx, state.Spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var private union.PrivateData
+	if resp.Private != nil {
+		private = resp.Private
+	}
+
+	// A union Geni no longer has drops its spouse entry, so the next plan
+	// creates it again.
+	found := make([]SpouseModel, 0, len(spouses))
+	for i, spouse := range spouses {
+		prior, diags := unionFrom(ctx, state.Profile, spouse)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		key := journalKey(spouse.Partner)
+		current, ok, diags := r.union.ReadUnion(ctx, prior, private, key)
+		resp.Diagnostics.Append(atSpouse(diags, i)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		// A partner Geni merged into another profile takes its union's journal
+		// along to the key of the profile that survived.
+		spouse, diags = spouseFrom(ctx, state.Profile, spouse, current)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(union.MoveJournal(ctx, private, key, journalKey(spouse.Partner))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		found = append(found, spouse)
+	}
+
+	state.ID = state.Profile
+	state.Spouses, diags = spousesValue(ctx, found)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
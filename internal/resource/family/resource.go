@@ -0,0 +1,34 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+var _ resource.Resource = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
+
+// Resource manages a profile's unions, one with each of its spouses. The
+// unions are built, planned and refreshed by the union package, as geni_union
+// resources would be.
+type Resource struct {
+	resource.ResourceWithConfigure
+	union *union.Resource
+}
+
+func NewFamilyResource() resource.Resource {
+	return &Resource{union: &union.Resource{}}
+}
+
+// Metadata provides the resource type name.
+func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "geni_family"
+}
+
+func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.union.Configure(ctx, req, resp)
+}
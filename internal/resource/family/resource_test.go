@@ -0,0 +1,237 @@
+package family
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
+)
+
+func TestFamilyLifecycle(t *testing.T) {
+	t.Run("Create builds a union with each spouse", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, client := fakeServerResource(t, 5)
+
+		state, diags := createFamily(t, r, familyPlan(t, "profile-1",
+			spouse(t, "profile-2", []string{"profile-4"}, 1980),
+			spouse(t, "profile-3", []string{"profile-5"}, 1990)))
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(state.ID.ValueString()).To(Equal("profile-1"))
+		spouses := spousesOf(t, state)
+		Expect(spouses).To(HaveLen(2))
+		Expect(spouses[0].Partner.ValueString()).To(Equal("profile-2"))
+		Expect(spouses[1].Partner.ValueString()).To(Equal("profile-3"))
+		Expect(spouses[0].UnionID).ToNot(Equal(spouses[1].UnionID))
+		first, err := client.Union().Get(t.Context(), spouses[0].UnionID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first.Partners).To(ConsistOf("profile-1", "profile-2"))
+		Expect(first.Children).To(ConsistOf("profile-4"))
+		second, err := client.Union().Get(t.Context(), spouses[1].UnionID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.Partners).To(ConsistOf("profile-1", "profile-3"))
+		Expect(second.Children).To(ConsistOf("profile-5"))
+	})
+
+	t.Run("Read refreshes every spouse's union", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, client := fakeServerResource(t, 5)
+		state, diags := createFamily(t, r, familyPlan(t, "profile-1",
+			spouse(t, "profile-2", []string{"profile-4"}, 1980),
+			spouse(t, "profile-3", nil, 1990)))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		_, err := client.Union().AddChild(t.Context(), spousesOf(t, state)[1].UnionID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+
+		read, diags := readFamily(t, r, state)
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		spouses := spousesOf(t, read)
+		Expect(spouses).To(HaveLen(2))
+		Expect(setStrings(t, spouses[0].Children)).To(ConsistOf("profile-4"))
+		Expect(setStrings(t, spouses[1].Children)).To(HaveLen(1))
+	})
+
+	t.Run("Update changes a spouse's union, adds a spouse and forgets a removed one", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, client := fakeServerResource(t, 6)
+		created, diags := createFamily(t, r, familyPlan(t, "profile-1",
+			spouse(t, "profile-2", []string{"profile-4"}, 1980),
+			spouse(t, "profile-3", nil, 1990)))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		before := spousesOf(t, created)
+
+		kept := spouse(t, "profile-3", []string{"profile-5"}, 1991)
+		kept.UnionID = before[1].UnionID
+		updated, diags := updateFamily(t, r, created, familyPlan(t, "profile-1",
+			kept,
+			spouse(t, "profile-6", nil, 2000)))
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		spouses := spousesOf(t, updated)
+		Expect(spouses).To(HaveLen(2))
+		Expect(spouses[0].UnionID).To(Equal(before[1].UnionID))
+		Expect(setStrings(t, spouses[0].Children)).To(ConsistOf("profile-5"))
+		Expect(spouses[1].Partner.ValueString()).To(Equal("profile-6"))
+		Expect(spouses[1].UnionID.ValueString()).To(MatchRegexp(`^union-\d+$`))
+		added, err := client.Union().Get(t.Context(), spouses[1].UnionID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(added.Partners).To(ConsistOf("profile-1", "profile-6"))
+		forgotten, err := client.Union().Get(t.Context(), before[0].UnionID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(forgotten.Partners).To(ConsistOf("profile-1", "profile-2"))
+	})
+}
+
+// fakeServerResource returns a family Resource backed by a fake Geni server
+// holding the given number of profiles, profile-1 onwards, and a client for
+// that server.
+func fakeServerResource(t *testing.T, profiles int) (*Resource, *geniclient.Client) {
+	t.Helper()
+	clients, _ := genitest.FakeServer(t, profiles, nil)
+
+	r, ok := NewFamilyResource().(*Resource)
+	Expect(ok).To(BeTrue())
+	var resp resource.ConfigureResponse
+	r.Configure(t.Context(), resource.ConfigureRequest{
+		ProviderData: &config.ClientData{Client: clients.Client, BatchClient: clients.BatchClient},
+	}, &resp)
+	Expect(resp.Diagnostics.HasError()).To(BeFalse())
+	return r, clients.Client
+}
+
+// familyPlan returns the plan for the family of profile with spouses.
+func familyPlan(t *testing.T, profile string, spouses ...SpouseModel) ResourceModel {
+	t.Helper()
+	list, diags := spousesValue(t.Context(), spouses)
+	Expect(diags.HasError()).To(BeFalse())
+	return ResourceModel{
+		ID:      types.StringUnknown(),
+		Profile: types.StringValue(profile),
+		Spouses: list,
+	}
+}
+
+// spouse returns a new spouse entry with the given children and a marriage in
+// the given year.
+func spouse(t *testing.T, partner string, children []string, marriedIn int32) SpouseModel {
+	t.Helper()
+	marriage, diags := event.ValueFrom(t.Context(), &geniprofile.EventElement{
+		Date: &geniprofile.DateElement{Year: new(marriedIn)},
+	}, types.ObjectNull(event.EventModelAttributeTypes()))
+	Expect(diags.HasError()).To(BeFalse())
+
+	return SpouseModel{
+		Partner:         types.StringValue(partner),
+		UnionID:         types.StringUnknown(),
+		Marriage:        marriage,
+		Divorce:         types.ObjectNull(event.EventModelAttributeTypes()),
+		Children:        stringSet(t, children),
+		FosterChildren:  types.SetNull(types.StringType),
+		AdoptedChildren: types.SetNull(types.StringType),
+	}
+}
+
+func stringSet(t *testing.T, ids []string) types.Set {
+	t.Helper()
+	if ids == nil {
+		return types.SetNull(types.StringType)
+	}
+	set, diags := types.SetValueFrom(t.Context(), types.StringType, ids)
+	Expect(diags.HasError()).To(BeFalse())
+	return set
+}
+
+func setStrings(t *testing.T, set types.Set) []string {
+	t.Helper()
+	ids, diags := tfset.Strings(t.Context(), set)
+	Expect(diags.HasError()).To(BeFalse())
+	return ids
+}
+
+func spousesOf(t *testing.T, m ResourceModel) []SpouseModel {
+	t.Helper()
+	spouses, diags := spousesFrom(t.Context(), m.Spouses)
+	Expect(diags.HasError()).To(BeFalse())
+	return spouses
+}
+
+// createFamily drives r.Create with plan and returns the resulting state.
+func createFamily(t *testing.T, r *Resource, plan ResourceModel) (ResourceModel, diag.Diagnostics) {
+	t.Helper()
+	familySchema := schemaOf(t, r)
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: familySchema, Raw: nullValue(t, familySchema)}}
+	Expect(req.Plan.Set(t.Context(), plan).HasError()).To(BeFalse())
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: familySchema, Raw: nullValue(t, familySchema)}}
+
+	r.Create(t.Context(), req, resp)
+
+	var state ResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &state)...)
+	return state, resp.Diagnostics
+}
+
+// readFamily drives r.Read from state and returns the refreshed state.
+func readFamily(t *testing.T, r *Resource, state ResourceModel) (ResourceModel, diag.Diagnostics) {
+	t.Helper()
+	familySchema := schemaOf(t, r)
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: familySchema, Raw: nullValue(t, familySchema)}}
+	Expect(req.State.Set(t.Context(), state).HasError()).To(BeFalse())
+	resp := &resource.ReadResponse{State: req.State}
+
+	r.Read(t.Context(), req, resp)
+
+	var read ResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &read)...)
+	return read, resp.Diagnostics
+}
+
+// updateFamily drives r.Update from state to plan and returns the resulting
+// state.
+func updateFamily(t *testing.T, r *Resource, state, plan ResourceModel) (ResourceModel, diag.Diagnostics) {
+	t.Helper()
+	familySchema := schemaOf(t, r)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: familySchema, Raw: nullValue(t, familySchema)},
+		State: tfsdk.State{Schema: familySchema, Raw: nullValue(t, familySchema)},
+	}
+	plan.ID = state.ID
+	Expect(req.Plan.Set(t.Context(), plan).HasError()).To(BeFalse())
+	Expect(req.State.Set(t.Context(), state).HasError()).To(BeFalse())
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: familySchema, Raw: nullValue(t, familySchema)}}
+
+	r.Update(t.Context(), req, resp)
+
+	var updated ResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &updated)...)
+	return updated, resp.Diagnostics
+}
+
+func schemaOf(t *testing.T, r *Resource) schema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	r.Schema(t.Context(), resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// nullValue returns the null value of s's type.
+func nullValue(t *testing.T, s interface{ Type() attr.Type }) tftypes.Value {
+	t.Helper()
+	return tftypes.NewValue(s.Type().TerraformType(t.Context()), nil)
+}
@@ -0,0 +1,84 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+const fieldProfile = "profile"
+const fieldSpouses = "spouses"
+const fieldPartner = "partner"
+const fieldUnionID = "union_id"
+const fieldChildren = "children"
+const fieldFosterChildren = "foster_children"
+const fieldAdoptedChildren = "adopted_children"
+
+// Schema defines the schema for the resource.
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A profile's unions with each of its spouses, such as the marriages of someone who remarried. Every spouse entry is a union of the profile and the spouse, with its own marriage, divorce and children, which the provider creates and updates the way it does a `geni_union`. Removing a spouse entry stops managing its union without deleting it from Geni, since the Geni API cannot delete a union.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Description:   "The unique identifier for the family, which is the ID of its profile.",
+			},
+			fieldProfile: schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Description:   "The ID of the profile whose spouses and children the family lists.",
+			},
+			fieldSpouses: schema.ListNestedAttribute{
+				Required:    true,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				Description: "The profile's spouses, in the order of the marriages.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						fieldPartner: schema.StringAttribute{
+							Required:    true,
+							Description: "The ID of the spouse's profile. Each spouse may be listed once.",
+						},
+						fieldUnionID: schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the union of the profile and the spouse. When the two already share a union on Geni, the plan adopts it instead of creating a duplicate, or fails if the union has members the configuration does not list.",
+						},
+						fieldChildren: schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "List of biological children IDs. Must be disjoint from foster_children and adopted_children.",
+						},
+						fieldFosterChildren: schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "List of foster children IDs. Must be disjoint from children and adopted_children.",
+						},
+						fieldAdoptedChildren: schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "List of adopted children IDs. Must be disjoint from children and foster_children.",
+						},
+						"marriage": event.Schema(event.SchemaOptions{
+							NameComputed:        true,
+							DescriptionComputed: true,
+							Description:         "Marriage event information.",
+						}),
+						"divorce": event.Schema(event.SchemaOptions{
+							NameComputed:        true,
+							DescriptionComputed: true,
+							Description:         "Divorce event information.",
+						}),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+// Update updates the resource.
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spouses, diags := spousesFrom(ctx, plan.Spouses)
+	resp.Diagnostics.Append(diags...)
+	previous, diags := spousesByPartner(ctx, state.Spouses)
+	resp.Diagnostics.Append(diags...)
+	stateSpouses, diags := spousesFrom(ctx, state.Spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var private union.PrivateData
+	if resp.Private != nil {
+		private = resp.Private
+	}
+
+	// A spouse that was in state has its union updated, and a new one has its
+	// union created. previous is left with the spouses that are not done yet.
+	updated := make([]SpouseModel, 0, len(spouses))
+	failed := -1
+	for i, spouse := range spouses {
+		planned, diags := unionFrom(ctx, plan.Profile, spouse)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			failed = i
+			break
+		}
+
+		partner := spouse.Partner.ValueString()
+		key := journalKey(spouse.Partner)
+		var current union.ResourceModel
+		if old, ok := previous[partner]; ok {
+			prior, diags := unionFrom(ctx, state.Profile, old)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				failed = i
+				break
+			}
+			current, diags = r.union.UpdateUnion(ctx, planned, prior, private, key)
+			resp.Diagnostics.Append(atSpouse(diags, i)...)
+			if resp.Diagnostics.HasError() {
+				failed = i
+				break
+			}
+			delete(previous, partner)
+		} else {
+			current, diags = r.union.CreateUnion(ctx, planned, private, key)
+			resp.Diagnostics.Append(atSpouse(diags, i)...)
+			if current.ID.IsUnknown() || current.ID.IsNull() {
+				failed = i
+				break
+			}
+		}
+
+		spouse, diags = spouseFrom(ctx, plan.Profile, spouse, current)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(union.MoveJournal(ctx, private, key, journalKey(spouse.Partner))...)
+		updated = append(updated, spouse)
+		if resp.Diagnostics.HasError() {
+			failed = i + 1
+			break
+		}
+	}
+
+	if failed >= 0 {
+		// Keep the spouses that are not done as they were, including those the
+		// plan removes, so that the next apply picks up where this one stopped.
+		for _, spouse := range spouses[failed:] {
+			if old, ok := previous[spouse.Partner.ValueString()]; ok {
+				updated = append(updated, old)
+				delete(previous, spouse.Partner.ValueString())
+			}
+		}
+		for _, old := range stateSpouses {
+			if _, ok := previous[old.Partner.ValueString()]; ok {
+				updated = append(updated, old)
+			}
+		}
+	} else {
+		// Geni cannot delete a union, so a removed spouse's union is only no
+		// longer managed, like a deleted geni_union.
+		for _, old := range stateSpouses {
+			if _, ok := previous[old.Partner.ValueString()]; ok {
+				resp.Diagnostics.Append(r.union.ForgetUnion(ctx, private, journalKey(old.Partner))...)
+			}
+		}
+	}
+
+	plan.ID = plan.Profile
+	plan.Spouses, diags = spousesValue(ctx, updated)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
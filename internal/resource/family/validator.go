@@ -0,0 +1,51 @@
+package family
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/union"
+)
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spouses, diags := spousesFrom(ctx, data.Spouses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]struct{}, len(spouses))
+	for i, spouse := range spouses {
+		if !spouse.Partner.IsNull() && !spouse.Partner.IsUnknown() {
+			partner := spouse.Partner.ValueString()
+			if partner == data.Profile.ValueString() {
+				resp.Diagnostics.AddAttributeError(spousePath(i).AtName(fieldPartner),
+					"Profile Listed As Its Own Spouse",
+					"Profile "+partner+" is the family's profile and cannot also be one of its spouses.",
+				)
+			}
+			if _, ok := seen[partner]; ok {
+				resp.Diagnostics.AddAttributeError(spousePath(i).AtName(fieldPartner),
+					"Duplicate Spouse",
+					"Profile "+partner+" is listed as a spouse more than once. "+
+						"Geni keeps a single union for two partners, so list each spouse once.",
+				)
+			}
+			seen[partner] = struct{}{}
+		}
+
+		resp.Diagnostics.Append(union.ValidateChildSets(union.ResourceModel{
+			Children:        spouse.Children,
+			FosterChildren:  spouse.FosterChildren,
+			AdoptedChildren: spouse.AdoptedChildren,
+		}, spousePath(i))...)
+	}
+}
@@ -0,0 +1,72 @@
+package family
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Run("Distinct spouses are valid", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		diags := validateConfig(t, familyPlan(t, "profile-1",
+			spouse(t, "profile-2", []string{"profile-4"}, 1980),
+			spouse(t, "profile-3", []string{"profile-5"}, 1990)))
+
+		Expect(diags).To(BeEmpty())
+	})
+
+	t.Run("A spouse listed twice is rejected", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		diags := validateConfig(t, familyPlan(t, "profile-1",
+			spouse(t, "profile-2", nil, 1980),
+			spouse(t, "profile-2", nil, 1990)))
+
+		Expect(diags.Errors()).To(HaveLen(1))
+		Expect(diags.Errors()[0].Summary()).To(Equal("Duplicate Spouse"))
+		Expect(genitest.PathOf(diags.Errors()[0])).To(Equal(path.Root(fieldSpouses).AtListIndex(1).AtName(fieldPartner)))
+	})
+
+	t.Run("The profile listed as its own spouse is rejected", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		diags := validateConfig(t, familyPlan(t, "profile-1", spouse(t, "profile-1", nil, 1980)))
+
+		Expect(diags.Errors()).To(HaveLen(1))
+		Expect(diags.Errors()[0].Summary()).To(Equal("Profile Listed As Its Own Spouse"))
+	})
+
+	t.Run("A child in two of a spouse's child sets is rejected at that spouse", func(t *testing.T) {
+		RegisterTestingT(t)
+		second := spouse(t, "profile-3", []string{"profile-5"}, 1990)
+		second.AdoptedChildren = stringSet(t, []string{"profile-5"})
+
+		diags := validateConfig(t, familyPlan(t, "profile-1", spouse(t, "profile-2", nil, 1980), second))
+
+		Expect(diags.Errors()).To(HaveLen(1))
+		Expect(diags.Errors()[0].Summary()).To(Equal("Overlapping Child Sets"))
+		Expect(genitest.PathOf(diags.Errors()[0])).To(Equal(path.Root(fieldSpouses).AtListIndex(1).AtName(fieldAdoptedChildren)))
+	})
+}
+
+func validateConfig(t *testing.T, config ResourceModel) diag.Diagnostics {
+	t.Helper()
+	r := &Resource{}
+	familySchema := schemaOf(t, r)
+
+	state := tfsdk.State{Schema: familySchema, Raw: nullValue(t, familySchema)}
+	Expect(state.Set(t.Context(), config).HasError()).To(BeFalse())
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: familySchema, Raw: state.Raw}}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(t.Context(), req, resp)
+	return resp.Diagnostics
+}
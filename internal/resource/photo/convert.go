@@ -36,7 +36,7 @@ func ValueFrom(ctx context.Context, response *geniphoto.Photo, model *ResourceMo
 	d.Append(diags...)
 	model.Sizes = sizes
 
-	location, diags := event.LocationValueFrom(ctx, response.Location)
+	location, diags := event.LocationValueFrom(ctx, response.Location, types.ObjectNull(event.LocationModelAttributeTypes()))
 	d.Append(diags...)
 	model.Location = location
 
@@ -83,7 +83,7 @@ func UpdateComputedFields(ctx context.Context, response *geniphoto.Photo, model
 	model.Sizes = sizes
 
 	// Location is read-only — always taken from the API response.
-	location, diags := event.LocationValueFrom(ctx, response.Location)
+	location, diags := event.LocationValueFrom(ctx, response.Location, types.ObjectNull(event.LocationModelAttributeTypes()))
 	d.Append(diags...)
 	model.Location = location
 
@@ -12,9 +12,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
-	"github.com/dmalch/go-geni"
 	geniphoto "github.com/dmalch/go-geni/photo"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 	"github.com/dmalch/terraform-provider-genealogy/internal/listresource"
 )
 
@@ -22,7 +22,7 @@ var _ list.ListResource = (*listResource)(nil)
 var _ list.ListResourceWithConfigure = (*listResource)(nil)
 
 type listResource struct {
-	client *geni.Client
+	client *geniclient.Client
 }
 
 func NewListResource() list.ListResource {
@@ -56,7 +56,7 @@ func (r *listResource) List(ctx context.Context, req list.ListRequest, stream *l
 	stream.Results = streamUploadedPhotos(ctx, r.client, req)
 }
 
-func streamUploadedPhotos(ctx context.Context, c *geni.Client, req list.ListRequest) iter.Seq[list.ListResult] {
+func streamUploadedPhotos(ctx context.Context, c *geniclient.Client, req list.ListRequest) iter.Seq[list.ListResult] {
 	return listresource.Paginate(ctx,
 		func(ctx context.Context, page int) ([]geniphoto.Photo, int, error) {
 			bulk, err := c.User().UploadedPhotos(ctx, page)
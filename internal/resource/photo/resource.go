@@ -6,9 +6,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
-	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 var _ resource.Resource = &Resource{}
@@ -17,7 +17,7 @@ var _ resource.ResourceWithImportState = &Resource{}
 
 type Resource struct {
 	resource.ResourceWithConfigure
-	client      *geni.Client
+	client      *geniclient.Client
 	batchClient *genibatch.Client
 }
 
@@ -0,0 +1,35 @@
+package profile
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/markdown"
+)
+
+// aboutFormatMarkdown is the about_format of about texts written in Markdown,
+// which Geni is sent as HTML.
+const aboutFormatMarkdown = "markdown"
+
+// aboutToGeni returns the about text Geni is sent for text, written in format.
+func aboutToGeni(format types.String, text string) string {
+	if format.ValueString() != aboutFormatMarkdown {
+		return text
+	}
+	return markdown.ToHTML(text)
+}
+
+// aboutFromGeni returns the about text Geni stores as stored, written in
+// format. A Markdown text keeps prior, the text's previous value, when that is
+// what Geni was sent, give or take Geni's rewrites, so that Markdown written
+// differently from the canonical form FromHTML reads does not show as a change
+// on every plan.
+func aboutFromGeni(format types.String, stored string, prior basetypes.StringValue) string {
+	if format.ValueString() != aboutFormatMarkdown {
+		return stored
+	}
+	if !prior.IsNull() && !prior.IsUnknown() && normalizeAbout(markdown.ToHTML(prior.ValueString())) == normalizeAbout(stored) {
+		return prior.ValueString()
+	}
+	return markdown.FromHTML(stored)
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+)
+
+func TestAboutFormat(t *testing.T) {
+	const written = "Born in __Moscow__.\n\n* Carpenter"
+	const stored = "<p>Born in <strong>Moscow</strong>.</p>\n<ul>\n<li>Carpenter</li>\n</ul>"
+
+	markdownModel := func(about string) ResourceModel {
+		model := NewEmptyResourceModel()
+		model.AboutFormat = types.StringValue(aboutFormatMarkdown)
+		model.About = types.MapValueMust(AboutType{}, map[string]attr.Value{"en-US": NewAboutValue(about)})
+		return model
+	}
+
+	t.Run("Markdown about texts are sent to Geni as HTML", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		request, diags := RequestFrom(t.Context(), markdownModel(written))
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(request.DetailStrings["en-US"].AboutMe).To(HaveValue(Equal(stored)))
+	})
+
+	t.Run("Markdown about texts read back as written while Geni keeps their HTML", func(t *testing.T) {
+		RegisterTestingT(t)
+		model := markdownModel(written)
+
+		diags := ValueFrom(t.Context(), &geniprofile.Profile{
+			DetailStrings: map[string]geniprofile.DetailsString{"en-US": {AboutMe: new(stored)}},
+		}, &model)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(model.About.Elements()["en-US"]).To(Equal(NewAboutValue(written)))
+
+		diags = ValueFrom(t.Context(), &geniprofile.Profile{
+			DetailStrings: map[string]geniprofile.DetailsString{"en-US": {AboutMe: new(strings.ReplaceAll(stored, "\n", "\r\n"))}},
+		}, &model)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(model.About.Elements()["en-US"]).To(Equal(NewAboutValue(written)), "Geni's rewrites are not an edit")
+	})
+
+	t.Run("About texts edited on Geni read back as canonical Markdown", func(t *testing.T) {
+		RegisterTestingT(t)
+		model := markdownModel(written)
+
+		diags := ValueFrom(t.Context(), &geniprofile.Profile{
+			DetailStrings: map[string]geniprofile.DetailsString{"en-US": {AboutMe: new("<p>Born in <b>Tver</b>.</p>")}},
+		}, &model)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(model.About.Elements()["en-US"]).To(Equal(NewAboutValue("Born in **Tver**.")))
+	})
+
+	t.Run("About texts are kept as they are without a format", func(t *testing.T) {
+		RegisterTestingT(t)
+		model := NewEmptyResourceModel()
+		model.About = types.MapValueMust(AboutType{}, map[string]attr.Value{"en-US": NewAboutValue(written)})
+
+		request, diags := RequestFrom(t.Context(), model)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(request.DetailStrings["en-US"].AboutMe).To(HaveValue(Equal(written)))
+
+		diags = ValueFrom(t.Context(), &geniprofile.Profile{
+			DetailStrings: map[string]geniprofile.DetailsString{"en-US": {AboutMe: new(stored)}},
+		}, &model)
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(model.About.Elements()["en-US"]).To(Equal(NewAboutValue(stored)))
+	})
+}
@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = AboutType{}
+
+// AboutType is the type of an about text. Its values are equal when they only
+// differ by the rewrites Geni makes to the texts it stores, so that reading a
+// text back does not show as a change.
+type AboutType struct {
+	basetypes.StringType
+}
+
+func (t AboutType) Equal(o attr.Type) bool {
+	other, ok := o.(AboutType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t AboutType) String() string {
+	return "profile.AboutType"
+}
+
+func (t AboutType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return AboutValue{StringValue: in}, nil
+}
+
+func (t AboutType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t AboutType) ValueType(_ context.Context) attr.Value {
+	return AboutValue{}
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = AboutValue{}
+
+// AboutValue is an about text, a value of AboutType.
+type AboutValue struct {
+	basetypes.StringValue
+}
+
+// NewAboutValue returns the about text text.
+func NewAboutValue(text string) AboutValue {
+	return AboutValue{StringValue: basetypes.NewStringValue(text)}
+}
+
+func (v AboutValue) Equal(o attr.Value) bool {
+	other, ok := o.(AboutValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v AboutValue) Type(_ context.Context) attr.Type {
+	return AboutType{}
+}
+
+// StringSemanticEquals reports whether the about texts v and newValuable are
+// the same once Geni's rewrites are undone in both.
+func (v AboutValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(AboutValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error",
+			fmt.Sprintf("An unexpected value type was received while performing semantic equality checks. Expected %T, got %T.", v, newValuable))
+		return false, diags
+	}
+
+	return normalizeAbout(v.ValueString()) == normalizeAbout(newValue.ValueString()), diags
+}
+
+var (
+	lineBreakTag  = regexp.MustCompile(`(?i)<br\s*/?>`)
+	trailingSpace = regexp.MustCompile(`[ \t]+\n`)
+)
+
+// normalizeAbout undoes the rewrites Geni makes to an about text it stores:
+// it encodes characters as HTML entities, writes line breaks as "\r\n",
+// closes <br> tags as <br />, writes spaces as non-breaking ones, and trims
+// spaces at the ends of lines and of the text.
+func normalizeAbout(text string) string {
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text)
+	text = strings.ReplaceAll(html.UnescapeString(text), "\u00a0", " ")
+	text = lineBreakTag.ReplaceAllString(text, "<br>")
+	text = trailingSpace.ReplaceAllString(text, "\n")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/gomega"
+)
+
+func TestAboutSemanticEquals(t *testing.T) {
+	t.Run("Texts that Geni rewrote are equal to what it was sent", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for sent, stored := range map[string]string{
+			"Fish & chips":                       "Fish &amp; chips",
+			`He said "hello" to O'Brien`:         "He said &quot;hello&quot; to O&#39;Brien",
+			"Born 1852 <in Tver>":                "Born 1852 &lt;in Tver&gt;",
+			"First line\nSecond line":            "First line\r\nSecond line",
+			"Trailing spaces  \nend\n\n":         "Trailing spaces\nend",
+			"  Leading and trailing  ":           "Leading and trailing",
+			"Line<br>break":                      "Line<br />break",
+			"Line<BR/>break":                     "Line<br>break",
+			"Non-breaking space":                 "Non-breaking&nbsp;space",
+			"<p>Born in <b>Tver</b></p>\r\n\r\n": "<p>Born in <b>Tver</b></p>",
+		} {
+			equal, diags := NewAboutValue(sent).StringSemanticEquals(t.Context(), NewAboutValue(stored))
+			Expect(diags).To(BeEmpty())
+			Expect(equal).To(BeTrue(), "%q and %q", sent, stored)
+		}
+	})
+
+	t.Run("Texts that differ in content are not equal", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		for sent, stored := range map[string]string{
+			"Born in Tver":        "Born in Moscow",
+			"Two\n\nparagraphs":   "Two\nparagraphs",
+			"Inner  spaces":       "Inner spaces",
+			"<b>Bold</b> chapter": "Bold chapter",
+		} {
+			equal, diags := NewAboutValue(sent).StringSemanticEquals(t.Context(), NewAboutValue(stored))
+			Expect(diags).To(BeEmpty())
+			Expect(equal).To(BeFalse(), "%q and %q", sent, stored)
+		}
+	})
+
+	t.Run("Other string values are an error", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		_, diags := NewAboutValue("text").StringSemanticEquals(t.Context(), types.StringValue("text"))
+
+		Expect(diags.HasError()).To(BeTrue())
+	})
+}
+
+func TestAboutType(t *testing.T) {
+	RegisterTestingT(t)
+
+	value, err := AboutType{}.ValueFromTerraform(t.Context(), tftypes.NewValue(tftypes.String, "text"))
+
+	Expect(err).ToNot(HaveOccurred())
+	Expect(value).To(Equal(NewAboutValue("text")))
+	Expect(value.Type(t.Context())).To(Equal(AboutType{}))
+	Expect(AboutType{}.Equal(types.StringType)).To(BeFalse())
+}
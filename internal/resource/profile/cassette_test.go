@@ -0,0 +1,61 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+)
+
+func TestProfileEventCassettes(t *testing.T) {
+	t.Run("An event is added to a profile, updated and deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, remaining := cassetteResource(t, "profile_events")
+		profile, err := r.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{
+			Names: map[string]geniprofile.NameElement{"en-US": {FirstName: new("Cassette")}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		created, diags := r.writeEvents(t.Context(), profile.ID, NewEmptyResourceModel().Events, eventsOf(map[string]attr.Value{
+			"census": plannedEvent(t, "Census", 1926),
+		}), nil)
+		Expect(diags).To(BeEmpty())
+		censusID := eventID(created.Elements()["census"])
+		Expect(censusID).To(MatchRegexp(`^event-\d+$`))
+
+		moved := withYear(t, created.Elements()["census"].(types.Object), 1927)
+		updated, diags := r.writeEvents(t.Context(), profile.ID, created, eventsOf(map[string]attr.Value{"census": moved}), managedIn(created))
+		Expect(diags).To(BeEmpty())
+		Expect(eventID(updated.Elements()["census"])).To(Equal(censusID))
+		Expect(yearOf(updated.Elements()["census"])).To(Equal(int32(1927)))
+
+		read, diags := r.readEvents(t.Context(), profile.ID, updated, false)
+		Expect(diags).To(BeEmpty())
+		Expect(yearOf(read.Elements()["census"])).To(Equal(int32(1927)))
+
+		deleted, diags := r.writeEvents(t.Context(), profile.ID, updated, NewEmptyResourceModel().Events, managedIn(updated))
+		Expect(diags).To(BeEmpty())
+		Expect(deleted.IsNull()).To(BeTrue())
+		events, err := r.client.Event().List(t.Context(), profile.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(BeEmpty())
+
+		Expect(r.client.Profile().Delete(t.Context(), profile.ID)).To(Succeed())
+		Expect(remaining()).To(BeZero())
+	})
+}
+
+// cassetteResource returns a profile Resource, with experimental events,
+// whose requests are answered from testdata/<name>.jsonl (see
+// genitest.Cassette), and a func reporting how many recorded responses the
+// test left unused.
+func cassetteResource(t *testing.T, name string) (*Resource, func() int) {
+	t.Helper()
+	clients, remaining := genitest.Cassette(t, name, 0)
+	return &Resource{client: clients.Client, experimentalEvents: true}, remaining
+}
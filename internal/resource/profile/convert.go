@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
 	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
 )
@@ -32,7 +33,7 @@ func ValueFrom(ctx context.Context, profile *geniprofile.Profile, profileModel *
 
 	profileModel.Gender = types.StringPointerValue(profile.Gender)
 
-	detailStrings, diags := detailStringsValueFrom(ctx, profile)
+	detailStrings, diags := detailStringsValueFrom(ctx, profile, profileModel)
 	d.Append(diags...)
 
 	if len(detailStrings.Elements()) > 0 {
@@ -41,8 +42,10 @@ func ValueFrom(ctx context.Context, profile *geniprofile.Profile, profileModel *
 
 	profileModel.Public = types.BoolValue(profile.Public)
 	profileModel.Alive = types.BoolValue(profile.IsAlive)
+	profileModel.Locked = types.BoolValue(profile.Locked)
+	profileModel.Language = optionalString(profile.Language)
 
-	currentResidence, diags := event.LocationValueFrom(ctx, profile.CurrentResidence)
+	currentResidence, diags := event.LocationValueFrom(ctx, profile.CurrentResidence, profileModel.CurrentResidence)
 	d.Append(diags...)
 	profileModel.CurrentResidence = currentResidence
 
@@ -58,19 +61,19 @@ func ValueFrom(ctx context.Context, profile *geniprofile.Profile, profileModel *
 	d.Append(diags...)
 	profileModel.Projects = projects
 
-	birth, diags := event.ValueFrom(ctx, profile.Birth)
+	birth, diags := event.ValueFrom(ctx, profile.Birth, profileModel.Birth)
 	d.Append(diags...)
 	profileModel.Birth = birth
 
-	baptism, diags := event.ValueFrom(ctx, profile.Baptism)
+	baptism, diags := event.ValueFrom(ctx, profile.Baptism, profileModel.Baptism)
 	d.Append(diags...)
 	profileModel.Baptism = baptism
 
-	death, diags := event.ValueFrom(ctx, profile.Death)
+	death, diags := event.ValueFrom(ctx, profile.Death, profileModel.Death)
 	d.Append(diags...)
 	profileModel.Death = death
 
-	burial, diags := event.ValueFrom(ctx, profile.Burial)
+	burial, diags := event.ValueFrom(ctx, profile.Burial, profileModel.Burial)
 	d.Append(diags...)
 	profileModel.Burial = burial
 
@@ -85,7 +88,9 @@ func ValueFrom(ctx context.Context, profile *geniprofile.Profile, profileModel *
 	return d
 }
 
-func detailStringsValueFrom(ctx context.Context, profile *geniprofile.Profile) (basetypes.MapValue, diag.Diagnostics) {
+// detailStringsValueFrom returns the about texts of profile by locale, written
+// in the about_format of profileModel, which holds their previous values.
+func detailStringsValueFrom(ctx context.Context, profile *geniprofile.Profile, profileModel *ResourceModel) (basetypes.MapValue, diag.Diagnostics) {
 	aboutMeMap := make(map[string]string)
 	for locale, localeDetails := range profile.DetailStrings {
 		if localeDetails.AboutMe != nil {
@@ -96,8 +101,12 @@ func detailStringsValueFrom(ctx context.Context, profile *geniprofile.Profile) (
 	if len(aboutMeMap) == 0 && profile.AboutMe != nil && *profile.AboutMe != "" {
 		aboutMeMap["en-US"] = *profile.AboutMe
 	}
+	for locale, stored := range aboutMeMap {
+		prior, _ := profileModel.About.Elements()[locale].(AboutValue)
+		aboutMeMap[locale] = aboutFromGeni(profileModel.AboutFormat, stored, prior.StringValue)
+	}
 
-	return types.MapValueFrom(ctx, types.StringType, aboutMeMap)
+	return types.MapValueFrom(ctx, AboutType{}, aboutMeMap)
 }
 
 // namesWithFlatFallback returns the API's localized name map when present, or
@@ -163,7 +172,7 @@ func NameValueFrom(ctx context.Context, profileNames map[string]geniprofile.Name
 	return nameMap, d
 }
 
-func RequestFrom(ctx context.Context, resourceModel ResourceModel) (*geniprofile.Request, diag.Diagnostics) {
+func RequestFrom(ctx context.Context, resourceModel ResourceModel) (*geniclient.ProfileRequest, diag.Diagnostics) {
 	var d diag.Diagnostics
 
 	birth, diags := event.ElementFrom(ctx, resourceModel.Birth)
@@ -206,13 +215,16 @@ func RequestFrom(ctx context.Context, resourceModel ResourceModel) (*geniprofile
 		d.Append(diags...)
 
 		for locale, detailsString := range aboutMeMap {
+			if detailsString != nil {
+				detailsString = new(aboutToGeni(resourceModel.AboutFormat, *detailsString))
+			}
 			convertedDetails[locale] = geniprofile.DetailsString{
 				AboutMe: detailsString,
 			}
 		}
 	}
 
-	profileRequest := &geniprofile.Request{
+	profileRequest := &geniclient.ProfileRequest{Request: geniprofile.Request{
 		Names:            convertedNames,
 		Gender:           resourceModel.Gender.ValueStringPointer(),
 		Birth:            birth,
@@ -227,6 +239,15 @@ func RequestFrom(ctx context.Context, resourceModel ResourceModel) (*geniprofile
 		Title:            resourceModel.Title.ValueString(),
 		Occupation:       resourceModel.Occupation.ValueString(),
 		Suffix:           resourceModel.Suffix.ValueString(),
+	}}
+
+	// The lock and the language are only sent when the plan knows them: a new
+	// profile without them configured gets the ones Geni gives.
+	if !resourceModel.Locked.IsUnknown() {
+		profileRequest.Locked = resourceModel.Locked.ValueBoolPointer()
+	}
+	if !resourceModel.Language.IsUnknown() {
+		profileRequest.Language = resourceModel.Language.ValueStringPointer()
 	}
 
 	return profileRequest, d
@@ -277,12 +298,15 @@ func UpdateComputedFields(ctx context.Context, profile *geniprofile.Profile, pro
 		profileModel.Guid = types.StringNull()
 	}
 
-	// Unions is Computed-only on the resource schema, so the planned set
-	// is Unknown at Create/Update time — overwriting it from the live API
-	// response cannot trigger Terraform's post-apply consistency check.
-	unions, diags := types.SetValueFrom(ctx, types.StringType, profile.Unions)
-	d.Append(diags...)
-	profileModel.Unions = unions
+	// Unions is Computed-only on the resource schema and planned from state,
+	// so it is only Unknown at Create time and for states without it. A known
+	// planned set is kept: overwriting it from the live API response would
+	// trigger Terraform's post-apply consistency check.
+	if profileModel.Unions.IsUnknown() {
+		unions, diags := types.SetValueFrom(ctx, types.StringType, profile.Unions)
+		d.Append(diags...)
+		profileModel.Unions = unions
+	}
 
 	// Projects is intentionally not updated here: Create/Update pass the
 	// pre-link API response (the AddProfileToProject calls fire afterwards),
@@ -310,7 +334,7 @@ func UpdateComputedFields(ctx context.Context, profile *geniprofile.Profile, pro
 	d.Append(diags...)
 	profileModel.CurrentResidence = currentResidence
 
-	detailStrings, diags := detailStringsValueFrom(ctx, profile)
+	detailStrings, diags := detailStringsValueFrom(ctx, profile, profileModel)
 	d.Append(diags...)
 
 	if len(detailStrings.Elements()) > 0 {
@@ -320,6 +344,15 @@ func UpdateComputedFields(ctx context.Context, profile *geniprofile.Profile, pro
 	profileModel.Deleted = types.BoolValue(profile.Deleted)
 	profileModel.MergedInto = types.StringValue(profile.MergedInto)
 
+	// The lock is planned from state or configuration unless the profile is
+	// new, and the language likewise.
+	if profileModel.Locked.IsUnknown() {
+		profileModel.Locked = types.BoolValue(profile.Locked)
+	}
+	if profileModel.Language.IsUnknown() {
+		profileModel.Language = optionalString(profile.Language)
+	}
+
 	// created_at is pinned to the prior state value by UseStateForUnknown, so on
 	// Update the plan carries a known value. A Geni merge makes the survivor
 	// inherit a different (older) created_at; overwriting the planned value here
@@ -120,6 +120,20 @@ func TestValueFrom(t *testing.T) {
 		Expect(actual.Projects.ElementType(t.Context())).To(Equal(types.StringType))
 	})
 
+	t.Run("Reads the profile's lock and language, with no language as null", func(t *testing.T) {
+		RegisterTestingT(t)
+		locked := &ResourceModel{}
+		unlocked := &ResourceModel{}
+
+		Expect(ValueFrom(t.Context(), &geniprofile.Profile{ID: "profile-1", Locked: true, Language: "ru"}, locked).HasError()).To(BeFalse())
+		Expect(ValueFrom(t.Context(), &geniprofile.Profile{ID: "profile-2"}, unlocked).HasError()).To(BeFalse())
+
+		Expect(locked.Locked).To(Equal(types.BoolValue(true)))
+		Expect(locked.Language).To(Equal(types.StringValue("ru")))
+		Expect(unlocked.Locked).To(Equal(types.BoolValue(false)))
+		Expect(unlocked.Language.IsNull()).To(BeTrue())
+	})
+
 	t.Run("when about me in multiple languages is defiled", func(t *testing.T) {
 		RegisterTestingT(t)
 		givenProfile := &geniprofile.Profile{
@@ -161,8 +175,8 @@ func TestRequestFrom(t *testing.T) {
 					"nicknames":       types.SetNull(types.StringType),
 				}),
 			}),
-			About: types.MapValueMust(types.StringType, map[string]attr.Value{
-				"en-US": types.StringValue("A test profile"),
+			About: types.MapValueMust(AboutType{}, map[string]attr.Value{
+				"en-US": NewAboutValue("A test profile"),
 			}),
 			Birth: types.ObjectValueMust(event.EventModelAttributeTypes(),
 				map[string]attr.Value{
@@ -173,6 +187,7 @@ func TestRequestFrom(t *testing.T) {
 							"range": types.StringNull(), "circa": types.BoolNull(),
 							"day": types.Int32Value(1), "month": types.Int32Value(1), "year": types.Int32Value(2000),
 							"end_circa": types.BoolNull(), "end_day": types.Int32Null(), "end_month": types.Int32Null(), "end_year": types.Int32Null(),
+							"calendar": types.StringNull(), "dual_year": types.Int32Null(), "gregorian": types.StringValue("2000-01-01"),
 						}),
 					"location": types.ObjectNull(event.LocationModelAttributeTypes()),
 				}),
@@ -217,7 +232,7 @@ func TestRequestFrom(t *testing.T) {
 			Alive:            types.BoolValue(false),
 			Public:           types.BoolValue(false),
 			Names:            types.MapNull(types.ObjectType{AttrTypes: NameAttributeTypes()}),
-			About:            types.MapNull(types.StringType),
+			About:            types.MapNull(AboutType{}),
 			Birth:            types.ObjectNull(event.EventModelAttributeTypes()),
 			Baptism:          types.ObjectNull(event.EventModelAttributeTypes()),
 			Death:            types.ObjectNull(event.EventModelAttributeTypes()),
@@ -233,17 +248,76 @@ func TestRequestFrom(t *testing.T) {
 		Expect(request.Gender).To(BeNil())
 		Expect(request.Names).To(BeEmpty())
 		Expect(request.DetailStrings).To(BeEmpty())
+		Expect(request.Locked).To(BeNil())
+		Expect(request.Language).To(BeNil())
+	})
+
+	t.Run("Sends the planned lock and language, including the ones kept from state", func(t *testing.T) {
+		RegisterTestingT(t)
+		givenModel := NewEmptyResourceModel()
+		givenModel.Locked = types.BoolValue(true)
+		givenModel.Language = types.StringValue("ru")
+
+		request, diags := RequestFrom(t.Context(), givenModel)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(request.Locked).To(HaveValue(BeTrue()))
+		Expect(request.Language).To(HaveValue(Equal("ru")))
+	})
+
+	t.Run("Leaves the lock and the language out of the request unless the plan knows them", func(t *testing.T) {
+		RegisterTestingT(t)
+		givenModel := NewEmptyResourceModel()
+		givenModel.Locked = types.BoolUnknown()
+		givenModel.Language = types.StringUnknown()
+
+		request, diags := RequestFrom(t.Context(), givenModel)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(request.Locked).To(BeNil())
+		Expect(request.Language).To(BeNil())
 	})
 }
 
 func TestUpdateComputedFields(t *testing.T) {
+	t.Run("Fills in the lock and the language only when the plan leaves them unknown", func(t *testing.T) {
+		RegisterTestingT(t)
+		given := &geniprofile.Profile{ID: "profile-1", Locked: true, Language: "ru"}
+		created := NewEmptyResourceModel()
+		created.Locked = types.BoolUnknown()
+		created.Language = types.StringUnknown()
+		updated := NewEmptyResourceModel()
+		updated.Locked = types.BoolValue(false)
+		updated.Language = types.StringValue("en")
+
+		Expect(UpdateComputedFields(t.Context(), given, &created).HasError()).To(BeFalse())
+		Expect(UpdateComputedFields(t.Context(), given, &updated).HasError()).To(BeFalse())
+
+		Expect(created.Locked).To(Equal(types.BoolValue(true)))
+		Expect(created.Language).To(Equal(types.StringValue("ru")))
+		Expect(updated.Locked).To(Equal(types.BoolValue(false)))
+		Expect(updated.Language).To(Equal(types.StringValue("en")))
+	})
+
+	t.Run("Keeps the unions the plan carries over from state", func(t *testing.T) {
+		RegisterTestingT(t)
+		given := &geniprofile.Profile{ID: "profile-1", Unions: []string{"union-2"}}
+		model := NewEmptyResourceModel()
+		planned, diags := types.SetValueFrom(t.Context(), types.StringType, []string{"union-1"})
+		Expect(diags.HasError()).To(BeFalse())
+		model.Unions = planned
+
+		Expect(UpdateComputedFields(t.Context(), given, &model).HasError()).To(BeFalse())
+
+		Expect(model.Unions).To(Equal(planned))
+	})
+
 	t.Run("Updates ID, unions, events, about, deleted, merged_into, and created_at", func(t *testing.T) {
 		RegisterTestingT(t)
 		givenProfile := &geniprofile.Profile{
 			ID: "profile-123",
-			// Unions is Computed-only on the resource schema, so any planned
-			// value is Unknown at apply time and the live API response is the
-			// authoritative source here.
+			// Unions is planned Unknown at Create time, so the live API
+			// response is the authoritative source here.
 			Unions: []string{"union-1", "union-2"},
 			// Projects is intentionally set to a stale value: the Create/Update
 			// API response is captured before the AddProfileToProject calls run,
@@ -270,8 +344,9 @@ func TestUpdateComputedFields(t *testing.T) {
 			Death:            types.ObjectNull(event.EventModelAttributeTypes()),
 			Burial:           types.ObjectNull(event.EventModelAttributeTypes()),
 			CurrentResidence: types.ObjectNull(event.LocationModelAttributeTypes()),
-			About:            types.MapNull(types.StringType),
+			About:            types.MapNull(AboutType{}),
 			Projects:         planProjects,
+			Unions:           types.SetUnknown(types.StringType),
 		}
 
 		diags := UpdateComputedFields(t.Context(), givenProfile, model)
@@ -279,7 +354,7 @@ func TestUpdateComputedFields(t *testing.T) {
 		Expect(diags.HasError()).To(BeFalse())
 		Expect(model.ID.ValueString()).To(Equal("profile-123"))
 		// Unions is Computed-only: the live API response is the authoritative
-		// value at Create/Update time (the plan was Unknown).
+		// value when the plan leaves it Unknown.
 		var actualUnions []string
 		Expect(model.Unions.ElementsAs(t.Context(), &actualUnions, false).HasError()).To(BeFalse())
 		Expect(actualUnions).To(ConsistOf("union-1", "union-2"))
@@ -317,7 +392,7 @@ func TestUpdateComputedFields(t *testing.T) {
 			Death:            types.ObjectNull(event.EventModelAttributeTypes()),
 			Burial:           types.ObjectNull(event.EventModelAttributeTypes()),
 			CurrentResidence: types.ObjectNull(event.LocationModelAttributeTypes()),
-			About:            types.MapNull(types.StringType),
+			About:            types.MapNull(AboutType{}),
 			Projects:         types.SetNull(types.StringType),
 			CreatedAt:        types.StringValue("1779831518"), // value pinned from prior state
 		}
@@ -343,7 +418,7 @@ func TestUpdateComputedFields(t *testing.T) {
 			Death:            types.ObjectNull(event.EventModelAttributeTypes()),
 			Burial:           types.ObjectNull(event.EventModelAttributeTypes()),
 			CurrentResidence: types.ObjectNull(event.LocationModelAttributeTypes()),
-			About:            types.MapNull(types.StringType),
+			About:            types.MapNull(AboutType{}),
 			Projects:         types.SetNull(types.StringType),
 			CreatedAt:        types.StringUnknown(),
 		}
@@ -11,6 +11,8 @@ import (
 
 // Create creates the resource.
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.batchClient.Invalidate()
+
 	var plan ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -43,11 +45,31 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		}
 	}
 
-	diags = UpdateComputedFields(ctx, profileResponse, &plan)
+	events, diags := r.writeEvents(ctx, profileResponse.ID, NewEmptyResourceModel().Events, plan.Events, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Events = events
+	if resp.Private != nil {
+		resp.Diagnostics.Append(saveManagedEvents(ctx, resp.Private, events)...)
+	}
+
+	diags = UpdateComputedFields(ctx, &profileResponse.Profile, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.MasterProfile = types.BoolValue(profileResponse.MasterProfile)
+	if relativesUnknown(plan) {
+		// The batch cache may still hold the unions as they were before the
+		// apply; the deferred Invalidate fires too late for this read.
+		r.batchClient.Invalidate()
+		resp.Diagnostics.Append(r.readRelatives(ctx, &profileResponse.Profile, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 
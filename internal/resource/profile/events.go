@@ -0,0 +1,332 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+const (
+	// adoptEventsKey is the private state key import sets to have the read
+	// that follows it, and only that one, adopt every event the profile has.
+	adoptEventsKey = "adopt_events"
+	// managedEventsKey is the private state key the ids of the events the
+	// configuration has listed are kept under. Only these are ever deleted.
+	managedEventsKey = "managed_events"
+)
+
+// privateState is a resource instance's private state.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// CustomEventModel is one of the profile's own events, an entry of the
+// `events` map.
+type CustomEventModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Date        types.Object `tfsdk:"date"`
+	Location    types.Object `tfsdk:"location"`
+}
+
+func (m CustomEventModel) AttributeTypes() map[string]attr.Type {
+	return CustomEventAttributeTypes()
+}
+
+// CustomEventAttributeTypes are those of an event, with the event's id.
+func CustomEventAttributeTypes() map[string]attr.Type {
+	attributeTypes := event.EventModelAttributeTypes()
+	attributeTypes["id"] = types.StringType
+	return attributeTypes
+}
+
+func eventsSchema() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Optional: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:      true,
+					PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+					Description:   "The event's id, such as event-1.",
+				},
+				"name": schema.StringAttribute{
+					Required:    true,
+					Description: "Event's name.",
+				},
+				"description": schema.StringAttribute{
+					Optional:    true,
+					Description: "Event's description.",
+				},
+				"date":     event.DateRangeSchema("Event's date."),
+				"location": event.LocationSchema("Event's location."),
+			},
+		},
+		Description: "The profile's own events besides its birth, baptism, death and burial, such as an emigration or a census entry, keyed by a label of your choosing. " +
+			"Each event is created, updated and deleted on Geni by itself; changing an event's label replaces it. " +
+			"Events added to the profile on Geni are left alone, except on import, which adds all of them keyed by their ids. " +
+			"Only events this map has listed in an apply are deleted when dropped from it: an imported event the configuration does not list is dropped from the state and kept on Geni. " +
+			"Experimental: requires `experimental_events` in the provider configuration, since the Geni API documentation does not list the event endpoints this uses.",
+	}
+}
+
+// readEvents returns the events the map tracked now has on Geni: each entry
+// of tracked is refreshed from the event with its id, or dropped when Geni no
+// longer has it. With adopt, which only the read after an import asks for,
+// the events it does not track are added too. Unless the provider opts in to
+// experimental events, tracked is returned as it is.
+func (r *Resource) readEvents(ctx context.Context, profileId string, tracked types.Map, adopt bool) (types.Map, diag.Diagnostics) {
+	if !r.experimentalEvents || !adopt && (tracked.IsNull() || tracked.IsUnknown()) {
+		return tracked, nil
+	}
+
+	events, err := r.client.Event().List(ctx, profileId)
+	if err != nil {
+		var d diag.Diagnostics
+		d.AddError("Error reading profile events", err.Error())
+		return tracked, d
+	}
+	return EventsValueFrom(ctx, events, tracked, adopt)
+}
+
+// writeEvents makes Geni's events of the profile profileId match the plan:
+// entries gone from the plan are deleted when their events are in managed,
+// and forgotten otherwise, new ones are created and changed ones updated. It
+// returns planned with the ids and the computed fields of the events filled
+// in. Unless the provider opts in to experimental events, which ModifyPlan
+// requires of a plan with events, it leaves Geni alone.
+func (r *Resource) writeEvents(ctx context.Context, profileId string, state, planned types.Map, managed map[string]struct{}) (types.Map, diag.Diagnostics) {
+	var d diag.Diagnostics
+	if !r.experimentalEvents {
+		return planned, d
+	}
+
+	prior := state.Elements()
+	for _, label := range slices.Sorted(maps.Keys(prior)) {
+		if _, kept := planned.Elements()[label]; kept {
+			continue
+		}
+		id := eventID(prior[label])
+		if _, ok := managed[id]; !ok {
+			continue
+		}
+		if err := r.client.Event().Delete(ctx, id); err != nil {
+			d.AddError("Error deleting profile event", err.Error())
+			return planned, d
+		}
+	}
+
+	if planned.IsNull() || planned.IsUnknown() {
+		return planned, d
+	}
+
+	written := maps.Clone(planned.Elements())
+	for _, label := range slices.Sorted(maps.Keys(written)) {
+		entry := written[label].(types.Object)
+		if priorEntry, ok := prior[label].(types.Object); ok && sameEvent(entry, priorEntry) {
+			continue
+		}
+
+		element, diags := customEventElementFrom(ctx, entry)
+		d.Append(diags...)
+		if d.HasError() {
+			return planned, d
+		}
+
+		var response *geniclient.Event
+		var err error
+		if id := eventID(entry); id != "" {
+			response, err = r.client.Event().Update(ctx, id, element)
+		} else {
+			response, err = r.client.Event().Create(ctx, profileId, element)
+		}
+		if err != nil {
+			d.AddError("Error writing profile event", err.Error())
+			return planned, d
+		}
+
+		updated, diags := event.UpdateComputedFieldsInEvent(ctx, withoutID(entry), &response.EventElement)
+		d.Append(diags...)
+		written[label], diags = withID(updated, response.ID)
+		d.Append(diags...)
+		if d.HasError() {
+			return planned, d
+		}
+	}
+
+	events, diags := types.MapValue(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}, written)
+	d.Append(diags...)
+	return events, d
+}
+
+// loadManagedEvents returns the ids of the events kept in private under
+// managedEventsKey.
+func loadManagedEvents(ctx context.Context, private privateState) (map[string]struct{}, diag.Diagnostics) {
+	managed := make(map[string]struct{})
+	data, diags := private.GetKey(ctx, managedEventsKey)
+	if diags.HasError() || len(data) == 0 {
+		return managed, diags
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		diags.AddWarning("Error reading the managed profile events",
+			"The list in private state is ignored, and no event dropped from events is deleted: "+err.Error())
+		return managed, diags
+	}
+	for _, id := range ids {
+		managed[id] = struct{}{}
+	}
+	return managed, diags
+}
+
+// saveManagedEvents keeps the ids of the events of written, the entries an
+// apply has just listed, in private under managedEventsKey.
+func saveManagedEvents(ctx context.Context, private privateState, written types.Map) diag.Diagnostics {
+	ids := make([]string, 0, len(written.Elements()))
+	for _, entry := range written.Elements() {
+		ids = append(ids, eventID(entry))
+	}
+	if len(ids) == 0 {
+		return private.SetKey(ctx, managedEventsKey, nil)
+	}
+	slices.Sort(ids)
+	data, err := json.Marshal(ids)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing the managed profile events", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, managedEventsKey, data)
+}
+
+// sameEvent reports whether planned sets the same fields as prior, an entry
+// of the state. The fields Geni computes, and those the plan leaves unknown
+// for Geni to compute, are not compared.
+func sameEvent(planned, prior types.Object) bool {
+	if planned.IsUnknown() {
+		return true
+	}
+	if planned.IsNull() || prior.IsNull() || prior.IsUnknown() {
+		return planned.Equal(prior)
+	}
+	priorAttributes := prior.Attributes()
+	for name, value := range planned.Attributes() {
+		if name == "id" || name == "gregorian" {
+			continue
+		}
+		if object, ok := value.(types.Object); ok {
+			priorObject, _ := priorAttributes[name].(types.Object)
+			if !sameEvent(object, priorObject) {
+				return false
+			}
+			continue
+		}
+		if !value.IsUnknown() && !value.Equal(priorAttributes[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// EventsValueFrom returns the entries of prior with the events of the same
+// ids, and without those whose events are gone. With adopt, every other event
+// is added too, keyed by its id. It is null when no entry is left.
+func EventsValueFrom(ctx context.Context, events []geniclient.Event, prior types.Map, adopt bool) (types.Map, diag.Diagnostics) {
+	var d diag.Diagnostics
+
+	labels := make(map[string]string, len(prior.Elements()))
+	for label, entry := range prior.Elements() {
+		labels[eventID(entry)] = label
+	}
+
+	entries := make(map[string]attr.Value, len(events))
+	for _, e := range events {
+		label, tracked := labels[e.ID]
+		if !tracked && !adopt {
+			continue
+		}
+		if !tracked {
+			label = e.ID
+		}
+
+		priorEntry, _ := prior.Elements()[label].(types.Object)
+		entry, diags := customEventValueFrom(ctx, e, priorEntry)
+		d.Append(diags...)
+		entries[label] = entry
+	}
+	if d.HasError() || len(entries) == 0 {
+		return types.MapNull(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}), d
+	}
+
+	eventMap, diags := types.MapValue(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}, entries)
+	d.Append(diags...)
+	return eventMap, d
+}
+
+// customEventValueFrom returns the entry of the event e. Unlike the birth and
+// the other events of a profile, an event with neither a date nor a location
+// is kept: Geni never creates one of these by itself.
+func customEventValueFrom(ctx context.Context, e geniclient.Event, prior types.Object) (types.Object, diag.Diagnostics) {
+	value, d := event.ValueFrom(ctx, &e.EventElement, prior)
+	if value.IsNull() {
+		description, _ := event.SplitHistorical(e.Description)
+		var diags diag.Diagnostics
+		value, diags = types.ObjectValue(event.EventModelAttributeTypes(), map[string]attr.Value{
+			"name":        types.StringValue(e.Name),
+			"description": types.StringPointerValue(description),
+			"date":        types.ObjectNull(event.DateRangeModelAttributeTypes()),
+			"location":    types.ObjectNull(event.LocationModelAttributeTypes()),
+		})
+		d.Append(diags...)
+	}
+	if d.HasError() {
+		return types.ObjectNull(CustomEventAttributeTypes()), d
+	}
+
+	entry, diags := withID(value, e.ID)
+	d.Append(diags...)
+	return entry, d
+}
+
+// customEventElementFrom returns the event Geni is sent for the entry.
+func customEventElementFrom(ctx context.Context, entry types.Object) (*geniprofile.EventElement, diag.Diagnostics) {
+	return event.ElementFrom(ctx, withoutID(entry))
+}
+
+// eventID returns the id of the event of entry, or "" when it has none yet.
+func eventID(entry attr.Value) string {
+	object, _ := entry.(types.Object)
+	id, _ := object.Attributes()["id"].(types.String)
+	return id.ValueString()
+}
+
+// withoutID returns the event of entry.
+func withoutID(entry types.Object) types.Object {
+	if entry.IsNull() || entry.IsUnknown() {
+		return types.ObjectNull(event.EventModelAttributeTypes())
+	}
+	attributes := maps.Clone(entry.Attributes())
+	delete(attributes, "id")
+	return types.ObjectValueMust(event.EventModelAttributeTypes(), attributes)
+}
+
+// withID returns the entry of the event e with the id id.
+func withID(e types.Object, id string) (types.Object, diag.Diagnostics) {
+	attributes := maps.Clone(e.Attributes())
+	attributes["id"] = types.StringValue(id)
+	return types.ObjectValue(CustomEventAttributeTypes(), attributes)
+}
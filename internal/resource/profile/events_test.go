@@ -0,0 +1,248 @@
+package profile
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+// fakeServerResource returns a profile Resource, with experimental events,
+// talking to a fake Geni server that holds one profile, and that profile's
+// id.
+func fakeServerResource(t *testing.T) (*Resource, string) {
+	t.Helper()
+	clients, _ := genitest.FakeServer(t, 1, nil)
+	return &Resource{client: clients.Client, experimentalEvents: true}, "profile-1"
+}
+
+// plannedEvent returns an entry of the `events` map for an event not created
+// yet.
+func plannedEvent(t *testing.T, name string, year int32) types.Object {
+	t.Helper()
+	entry, diags := customEventValueFrom(t.Context(), geniclient.Event{EventElement: geniprofile.EventElement{
+		Name: name,
+		Date: &geniprofile.DateElement{Year: new(year)},
+	}}, types.ObjectNull(CustomEventAttributeTypes()))
+	Expect(diags).To(BeEmpty())
+
+	attributes := maps.Clone(entry.Attributes())
+	attributes["id"] = types.StringUnknown()
+	return types.ObjectValueMust(CustomEventAttributeTypes(), attributes)
+}
+
+func eventsOf(entries map[string]attr.Value) types.Map {
+	return types.MapValueMust(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}, entries)
+}
+
+// withGregorian returns entry with its date's gregorian set to gregorian.
+func withGregorian(t *testing.T, entry types.Object, gregorian types.String) types.Object {
+	t.Helper()
+	attributes := maps.Clone(entry.Attributes())
+	date := maps.Clone(attributes["date"].(types.Object).Attributes())
+	date["gregorian"] = gregorian
+	attributes["date"] = types.ObjectValueMust(event.DateRangeModelAttributeTypes(), date)
+	return types.ObjectValueMust(CustomEventAttributeTypes(), attributes)
+}
+
+// withYear returns entry with its date's year set to year.
+func withYear(t *testing.T, entry types.Object, year int32) types.Object {
+	t.Helper()
+	attributes := maps.Clone(entry.Attributes())
+	date := maps.Clone(attributes["date"].(types.Object).Attributes())
+	date["year"] = types.Int32Value(year)
+	date["gregorian"] = types.StringUnknown()
+	attributes["date"] = types.ObjectValueMust(event.DateRangeModelAttributeTypes(), date)
+	return types.ObjectValueMust(CustomEventAttributeTypes(), attributes)
+}
+
+// managedIn returns the ids of the events of entries, as an apply that wrote
+// them keeps them.
+func managedIn(entries types.Map) map[string]struct{} {
+	managed := make(map[string]struct{})
+	for _, entry := range entries.Elements() {
+		managed[eventID(entry)] = struct{}{}
+	}
+	return managed
+}
+
+func yearOf(entry attr.Value) int32 {
+	date := entry.(types.Object).Attributes()["date"].(types.Object)
+	return date.Attributes()["year"].(types.Int32).ValueInt32()
+}
+
+func TestWriteEvents(t *testing.T) {
+	t.Run("Events are created, updated and deleted one by one", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, profileID := fakeServerResource(t)
+
+		created, diags := r.writeEvents(t.Context(), profileID, NewEmptyResourceModel().Events, eventsOf(map[string]attr.Value{
+			"emigration": plannedEvent(t, "Emigration", 1922),
+			"census":     plannedEvent(t, "Census", 1926),
+		}), nil)
+		Expect(diags).To(BeEmpty())
+		emigrationID := eventID(created.Elements()["emigration"])
+		censusID := eventID(created.Elements()["census"])
+		Expect(emigrationID).To(HavePrefix("event-"))
+		Expect(censusID).To(HavePrefix("event-"))
+
+		moved := maps.Clone(plannedEvent(t, "Emigration", 1923).Attributes())
+		moved["id"] = types.StringValue(emigrationID)
+		updated, diags := r.writeEvents(t.Context(), profileID, created, eventsOf(map[string]attr.Value{
+			"emigration": types.ObjectValueMust(CustomEventAttributeTypes(), moved),
+		}), managedIn(created))
+		Expect(diags).To(BeEmpty())
+		Expect(eventID(updated.Elements()["emigration"])).To(Equal(emigrationID))
+
+		events, err := r.client.Event().List(t.Context(), profileID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(1), "the census dropped from the plan is deleted")
+		Expect(events[0].ID).To(Equal(emigrationID))
+		Expect(*events[0].Date.Year).To(Equal(int32(1923)))
+	})
+
+	t.Run("Events the configuration never listed are dropped from state, not deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, profileID := fakeServerResource(t)
+		census, err := r.client.Event().Create(t.Context(), profileID, &geniprofile.EventElement{Name: "Census"})
+		Expect(err).ToNot(HaveOccurred())
+		imported, diags := r.readEvents(t.Context(), profileID, NewEmptyResourceModel().Events, true)
+		Expect(diags).To(BeEmpty())
+
+		written, diags := r.writeEvents(t.Context(), profileID, imported, NewEmptyResourceModel().Events, nil)
+
+		Expect(diags).To(BeEmpty())
+		Expect(written.IsNull()).To(BeTrue())
+		events, err := r.client.Event().List(t.Context(), profileID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].ID).To(Equal(census.ID))
+	})
+
+	t.Run("Fields Geni computes do not make an event change", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}, experimentalEvents: true}
+		state := plannedEvent(t, "Census", 1926)
+		stateAttributes := maps.Clone(state.Attributes())
+		stateAttributes["id"] = types.StringValue("event-1")
+		state = types.ObjectValueMust(CustomEventAttributeTypes(), stateAttributes)
+		planned := withGregorian(t, state, types.StringUnknown())
+
+		// An unchanged event is not sent: the zero client would panic.
+		written, diags := r.writeEvents(t.Context(), "profile-1", eventsOf(map[string]attr.Value{"census": state}),
+			eventsOf(map[string]attr.Value{"census": planned}), nil)
+
+		Expect(diags).To(BeEmpty())
+		Expect(written.Elements()["census"]).To(Equal(planned))
+		Expect(sameEvent(plannedEvent(t, "Census", 1927), state)).To(BeFalse())
+	})
+}
+
+func TestReadEvents(t *testing.T) {
+	t.Run("Only tracked events are read, and those deleted on Geni are dropped", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, profileID := fakeServerResource(t)
+		tracked, diags := r.writeEvents(t.Context(), profileID, NewEmptyResourceModel().Events, eventsOf(map[string]attr.Value{
+			"emigration": plannedEvent(t, "Emigration", 1922),
+			"census":     plannedEvent(t, "Census", 1926),
+		}), nil)
+		Expect(diags).To(BeEmpty())
+		_, err := r.client.Event().Create(t.Context(), profileID, &geniprofile.EventElement{Name: "Military service"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.client.Event().Delete(t.Context(), eventID(tracked.Elements()["census"]))).To(Succeed())
+
+		read, diags := r.readEvents(t.Context(), profileID, tracked, false)
+
+		Expect(diags).To(BeEmpty())
+		Expect(read.Elements()).To(HaveLen(1))
+		Expect(read.Elements()["emigration"]).To(Equal(tracked.Elements()["emigration"]))
+	})
+
+	t.Run("A null map is not read", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, profileID := fakeServerResource(t)
+		_, err := r.client.Event().Create(t.Context(), profileID, &geniprofile.EventElement{Name: "Census"})
+		Expect(err).ToNot(HaveOccurred())
+
+		read, diags := r.readEvents(t.Context(), profileID, NewEmptyResourceModel().Events, false)
+
+		Expect(diags).To(BeEmpty())
+		Expect(read.IsNull()).To(BeTrue())
+	})
+
+	t.Run("An empty map adopts nothing", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, profileID := fakeServerResource(t)
+		_, err := r.client.Event().Create(t.Context(), profileID, &geniprofile.EventElement{Name: "Census"})
+		Expect(err).ToNot(HaveOccurred())
+
+		read, diags := r.readEvents(t.Context(), profileID, eventsOf(map[string]attr.Value{}), false)
+
+		Expect(diags).To(BeEmpty())
+		Expect(read.IsNull()).To(BeTrue())
+	})
+
+	t.Run("Adopting, as the read after an import does, adds every event by its id", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, profileID := fakeServerResource(t)
+		census, err := r.client.Event().Create(t.Context(), profileID, &geniprofile.EventElement{
+			Name: "Census",
+			Date: &geniprofile.DateElement{Year: new(int32(1926))},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		service, err := r.client.Event().Create(t.Context(), profileID, &geniprofile.EventElement{
+			Name:        "Military service",
+			Description: new("Drafted"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		read, diags := r.readEvents(t.Context(), profileID, NewEmptyResourceModel().Events, true)
+
+		Expect(diags).To(BeEmpty())
+		Expect(read.Elements()).To(HaveKey(census.ID))
+		Expect(yearOf(read.Elements()[census.ID])).To(Equal(int32(1926)))
+		serviceEntry := read.Elements()[service.ID].(types.Object)
+		Expect(serviceEntry.Attributes()["name"]).To(Equal(types.StringValue("Military service")), "an event with neither a date nor a location is kept")
+		Expect(serviceEntry.Attributes()["description"]).To(Equal(types.StringValue("Drafted")))
+		Expect(serviceEntry.Attributes()["date"].IsNull()).To(BeTrue())
+	})
+}
+
+func TestExperimentalEvents(t *testing.T) {
+	planned := func(t *testing.T) types.Map {
+		return eventsOf(map[string]attr.Value{"census": plannedEvent(t, "Census", 1926)})
+	}
+
+	t.Run("Without experimental events, a plan with events fails", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}}
+		model := NewEmptyResourceModel()
+		model.Events = planned(t)
+
+		resp := modifyPlan(t, r, model)
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(resp.Diagnostics.Errors()[0].Summary()).To(Equal("Profile events are experimental"))
+	})
+
+	t.Run("Without experimental events, events are neither read nor written", func(t *testing.T) {
+		RegisterTestingT(t)
+		// The zero client would panic on any request.
+		r := &Resource{client: &geniclient.Client{}}
+
+		read, diags := r.readEvents(t.Context(), "profile-1", NewEmptyResourceModel().Events, true)
+		Expect(diags).To(BeEmpty())
+		Expect(read.IsNull()).To(BeTrue())
+
+		written, diags := r.writeEvents(t.Context(), "profile-1", planned(t), NewEmptyResourceModel().Events, map[string]struct{}{"event-1": {}})
+		Expect(diags).To(BeEmpty())
+		Expect(written.IsNull()).To(BeTrue())
+	})
+}
@@ -9,12 +9,13 @@ import (
 
 	"github.com/dmalch/go-geni"
 	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 func TestValidateProfileImportID(t *testing.T) {
 	t.Run("Not-found from fetch produces an error diagnostic", func(t *testing.T) {
 		RegisterTestingT(t)
-		fetch := func(_ context.Context, _ string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, _ string) (*geniclient.Profile, error) {
 			return nil, geni.ErrResourceNotFound
 		}
 
@@ -25,7 +26,7 @@ func TestValidateProfileImportID(t *testing.T) {
 
 	t.Run("Transport error is surfaced as an error diagnostic", func(t *testing.T) {
 		RegisterTestingT(t)
-		fetch := func(_ context.Context, _ string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, _ string) (*geniclient.Profile, error) {
 			return nil, errors.New("network exploded")
 		}
 
@@ -36,8 +37,8 @@ func TestValidateProfileImportID(t *testing.T) {
 
 	t.Run("Empty response Id is treated as not-found", func(t *testing.T) {
 		RegisterTestingT(t)
-		fetch := func(_ context.Context, _ string) (*geniprofile.Profile, error) {
-			return &geniprofile.Profile{}, nil
+		fetch := func(_ context.Context, _ string) (*geniclient.Profile, error) {
+			return &geniclient.Profile{}, nil
 		}
 
 		diags := validateProfileImportID(t.Context(), "profile-missing", fetch)
@@ -47,8 +48,8 @@ func TestValidateProfileImportID(t *testing.T) {
 
 	t.Run("Successful fetch yields no diagnostics", func(t *testing.T) {
 		RegisterTestingT(t)
-		fetch := func(_ context.Context, id string) (*geniprofile.Profile, error) {
-			return &geniprofile.Profile{ID: id}, nil
+		fetch := func(_ context.Context, id string) (*geniclient.Profile, error) {
+			return &geniclient.Profile{Profile: geniprofile.Profile{ID: id}}, nil
 		}
 
 		diags := validateProfileImportID(t.Context(), "profile-42", fetch)
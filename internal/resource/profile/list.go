@@ -11,9 +11,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
-	"github.com/dmalch/go-geni"
 	geniprofile "github.com/dmalch/go-geni/profile"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 	"github.com/dmalch/terraform-provider-genealogy/internal/listresource"
 )
 
@@ -21,7 +21,7 @@ var _ list.ListResource = (*listResource)(nil)
 var _ list.ListResourceWithConfigure = (*listResource)(nil)
 
 type listResource struct {
-	client *geni.Client
+	client *geniclient.Client
 }
 
 func NewListResource() list.ListResource {
@@ -59,7 +59,7 @@ func (r *listResource) List(ctx context.Context, req list.ListRequest, stream *l
 	stream.Results = streamManagedProfiles(ctx, r.client, req)
 }
 
-func streamManagedProfiles(ctx context.Context, c *geni.Client, req list.ListRequest) iter.Seq[list.ListResult] {
+func streamManagedProfiles(ctx context.Context, c *geniclient.Client, req list.ListRequest) iter.Seq[list.ListResult] {
 	return listresource.Paginate(ctx,
 		func(ctx context.Context, page int) ([]geniprofile.Profile, int, error) {
 			bulk, err := c.User().ManagedProfiles(ctx, page)
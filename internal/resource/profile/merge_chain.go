@@ -3,7 +3,7 @@ package profile
 import (
 	"context"
 
-	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 // FollowMergedInto walks the merged_into chain starting from initial, fetching
@@ -18,10 +18,10 @@ import (
 // the error so callers can include identity information in diagnostics.
 func FollowMergedInto(
 	ctx context.Context,
-	initial *geniprofile.Profile,
-	fetch func(context.Context, string) (*geniprofile.Profile, error),
+	initial *geniclient.Profile,
+	fetch func(context.Context, string) (*geniclient.Profile, error),
 	maxHops int,
-) (*geniprofile.Profile, error) {
+) (*geniclient.Profile, error) {
 	current := initial
 	for i := 0; i < maxHops && current.Deleted && current.MergedInto != ""; i++ {
 		next, err := fetch(ctx, current.MergedInto)
@@ -8,15 +8,16 @@ import (
 	. "github.com/onsi/gomega"
 
 	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 func TestFollowMergedInto(t *testing.T) {
 	t.Run("Returns the input unchanged when the profile is live", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		initial := &geniprofile.Profile{ID: "profile-1", Deleted: false}
+		initial := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-1", Deleted: false}}
 		fetchCalls := 0
-		fetch := func(_ context.Context, _ string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, _ string) (*geniclient.Profile, error) {
 			fetchCalls++
 			return nil, errors.New("unexpected fetch")
 		}
@@ -31,9 +32,9 @@ func TestFollowMergedInto(t *testing.T) {
 	t.Run("Returns the input when deleted but merged_into is empty", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		initial := &geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: ""}
+		initial := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: ""}}
 		fetchCalls := 0
-		fetch := func(_ context.Context, _ string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, _ string) (*geniclient.Profile, error) {
 			fetchCalls++
 			return nil, errors.New("unexpected fetch")
 		}
@@ -48,10 +49,10 @@ func TestFollowMergedInto(t *testing.T) {
 	t.Run("Follows a single hop to the live successor", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		initial := &geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}
-		successor := &geniprofile.Profile{ID: "profile-2", Deleted: false}
+		initial := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}}
+		successor := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-2", Deleted: false}}
 		fetchCalls := 0
-		fetch := func(_ context.Context, id string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, id string) (*geniclient.Profile, error) {
 			fetchCalls++
 			Expect(id).To(Equal("profile-2"))
 			return successor, nil
@@ -67,14 +68,14 @@ func TestFollowMergedInto(t *testing.T) {
 	t.Run("Walks a multi-hop chain until reaching a live profile", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		initial := &geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}
-		chain := map[string]*geniprofile.Profile{
-			"profile-2": {ID: "profile-2", Deleted: true, MergedInto: "profile-3"},
-			"profile-3": {ID: "profile-3", Deleted: true, MergedInto: "profile-4"},
-			"profile-4": {ID: "profile-4", Deleted: false},
+		initial := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}}
+		chain := map[string]*geniclient.Profile{
+			"profile-2": {Profile: geniprofile.Profile{ID: "profile-2", Deleted: true, MergedInto: "profile-3"}},
+			"profile-3": {Profile: geniprofile.Profile{ID: "profile-3", Deleted: true, MergedInto: "profile-4"}},
+			"profile-4": {Profile: geniprofile.Profile{ID: "profile-4", Deleted: false}},
 		}
 		var visited []string
-		fetch := func(_ context.Context, id string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, id string) (*geniclient.Profile, error) {
 			visited = append(visited, id)
 			p, ok := chain[id]
 			if !ok {
@@ -94,13 +95,13 @@ func TestFollowMergedInto(t *testing.T) {
 	t.Run("Stops at maxHops even if the chain is still deleted", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		initial := &geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}
-		chain := map[string]*geniprofile.Profile{
-			"profile-2": {ID: "profile-2", Deleted: true, MergedInto: "profile-3"},
-			"profile-3": {ID: "profile-3", Deleted: true, MergedInto: "profile-4"},
-			"profile-4": {ID: "profile-4", Deleted: true, MergedInto: "profile-5"},
+		initial := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}}
+		chain := map[string]*geniclient.Profile{
+			"profile-2": {Profile: geniprofile.Profile{ID: "profile-2", Deleted: true, MergedInto: "profile-3"}},
+			"profile-3": {Profile: geniprofile.Profile{ID: "profile-3", Deleted: true, MergedInto: "profile-4"}},
+			"profile-4": {Profile: geniprofile.Profile{ID: "profile-4", Deleted: true, MergedInto: "profile-5"}},
 		}
-		fetch := func(_ context.Context, id string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, id string) (*geniclient.Profile, error) {
 			return chain[id], nil
 		}
 
@@ -114,9 +115,9 @@ func TestFollowMergedInto(t *testing.T) {
 	t.Run("Returns the fetch error and the last successfully resolved profile", func(t *testing.T) {
 		RegisterTestingT(t)
 
-		initial := &geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}
+		initial := &geniclient.Profile{Profile: geniprofile.Profile{ID: "profile-1", Deleted: true, MergedInto: "profile-2"}}
 		boom := errors.New("transport blew up")
-		fetch := func(_ context.Context, _ string) (*geniprofile.Profile, error) {
+		fetch := func(_ context.Context, _ string) (*geniclient.Profile, error) {
 			return nil, boom
 		}
 
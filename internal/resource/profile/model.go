@@ -16,18 +16,56 @@ import (
 func NewEmptyResourceModel() ResourceModel {
 	return ResourceModel{
 		Names:            types.MapNull(types.ObjectType{AttrTypes: NameAttributeTypes()}),
-		About:            types.MapNull(types.StringType),
+		About:            types.MapNull(AboutType{}),
 		Unions:           types.SetNull(types.StringType),
+		Parents:          types.SetNull(types.StringType),
+		Partners:         types.SetNull(types.StringType),
+		Children:         types.SetNull(types.StringType),
+		Siblings:         types.SetNull(types.StringType),
 		Projects:         types.SetNull(types.StringType),
 		CurrentResidence: types.ObjectNull(event.LocationModelAttributeTypes()),
 		Birth:            types.ObjectNull(event.EventModelAttributeTypes()),
 		Baptism:          types.ObjectNull(event.EventModelAttributeTypes()),
 		Death:            types.ObjectNull(event.EventModelAttributeTypes()),
 		Burial:           types.ObjectNull(event.EventModelAttributeTypes()),
+		Events:           types.MapNull(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}),
 	}
 }
 
 type ResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Guid             types.String `tfsdk:"guid"`
+	Names            types.Map    `tfsdk:"names"`
+	Title            types.String `tfsdk:"title"`
+	Suffix           types.String `tfsdk:"suffix"`
+	Occupation       types.String `tfsdk:"occupation"`
+	Gender           types.String `tfsdk:"gender"`
+	Birth            types.Object `tfsdk:"birth"`
+	Baptism          types.Object `tfsdk:"baptism"`
+	Death            types.Object `tfsdk:"death"`
+	Burial           types.Object `tfsdk:"burial"`
+	Events           types.Map    `tfsdk:"events"`
+	CauseOfDeath     types.String `tfsdk:"cause_of_death"`
+	Unions           types.Set    `tfsdk:"unions"`
+	Parents          types.Set    `tfsdk:"parents"`
+	Partners         types.Set    `tfsdk:"partners"`
+	Children         types.Set    `tfsdk:"children"`
+	Siblings         types.Set    `tfsdk:"siblings"`
+	Projects         types.Set    `tfsdk:"projects"`
+	CurrentResidence types.Object `tfsdk:"current_residence"`
+	About            types.Map    `tfsdk:"about"`
+	AboutFormat      types.String `tfsdk:"about_format"`
+	Public           types.Bool   `tfsdk:"public"`
+	Alive            types.Bool   `tfsdk:"alive"`
+	Locked           types.Bool   `tfsdk:"locked"`
+	Language         types.String `tfsdk:"language"`
+	MasterProfile    types.Bool   `tfsdk:"master_profile"`
+	Deleted          types.Bool   `tfsdk:"deleted"`
+	MergedInto       types.String `tfsdk:"merged_into"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+}
+
+type ResourceModelV1 struct {
 	ID               types.String `tfsdk:"id"`
 	Guid             types.String `tfsdk:"guid"`
 	Names            types.Map    `tfsdk:"names"`
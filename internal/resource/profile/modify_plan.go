@@ -0,0 +1,90 @@
+package profile
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+// ModifyPlan fills in the locations of the profile's events, those of the
+// `events` map included, and of its current residence that reference a place
+// in the gazetteer (see event.PlanPlace). It then checks the chronology of the
+// planned profile as the provider configures it (see validateChronology).
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is planned on destroy. Before the provider is configured, it is
+	// not known whether it will have a gazetteer.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	for _, name := range []string{"birth", "baptism", "death", "burial"} {
+		var value types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		planned, diags := event.PlanEventPlace(ctx, value, r.places, path.Root(name))
+		resp.Diagnostics.Append(diags...)
+		if !diags.HasError() && !planned.Equal(value) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), planned)...)
+		}
+	}
+
+	var events types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("events"), &events)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !events.IsNull() && !r.experimentalEvents {
+		resp.Diagnostics.AddAttributeError(path.Root("events"), "Profile events are experimental",
+			"events reads and writes a profile's own events through endpoints the Geni API documentation does not "+
+				"list, which may change or go away without notice. Set experimental_events in the provider "+
+				"configuration to use them.")
+		return
+	}
+	if !events.IsNull() && !events.IsUnknown() {
+		plannedEvents := maps.Clone(events.Elements())
+		for label, value := range events.Elements() {
+			planned, diags := event.PlanEventPlace(ctx, value.(types.Object), r.places, path.Root("events").AtMapKey(label))
+			resp.Diagnostics.Append(diags...)
+			plannedEvents[label] = planned
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if planned := types.MapValueMust(events.ElementType(ctx), plannedEvents); !planned.Equal(events) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("events"), planned)...)
+		}
+	}
+
+	var residence types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("current_residence"), &residence)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned, diags := event.PlanPlace(ctx, residence, r.places, path.Root("current_residence"))
+	resp.Diagnostics.Append(diags...)
+	if !diags.HasError() && !planned.Equal(residence) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("current_residence"), planned)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data ResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateChronology(data, chronologyChecks{
+		strict:      r.strictValidation,
+		maxLifespan: r.maxLifespan,
+		today:       event.DayOf(time.Now()),
+	})...)
+}
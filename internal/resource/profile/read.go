@@ -11,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/dmalch/go-geni"
-	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 // Read reads the resource.
@@ -68,11 +68,31 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	}
 
 	newState := state
-	diags := ValueFrom(ctx, profileResponse, &newState)
+	diags := ValueFrom(ctx, &profileResponse.Profile, &newState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	newState.MasterProfile = types.BoolValue(profileResponse.MasterProfile)
+	resp.Diagnostics.Append(r.readRelatives(ctx, &profileResponse.Profile, &newState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Import asks the read that follows it, and only that one, to adopt the
+	// profile's events.
+	adopt, diags := req.Private.GetKey(ctx, adoptEventsKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState.Events, diags = r.readEvents(ctx, profileResponse.ID, state.Events, len(adopt) > 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(adopt) > 0 && resp.Private != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, adoptEventsKey, nil)...)
+	}
 
 	// If names in the new state are empty, and the names in the state contain one
 	// element for en-US, then use the state names.
@@ -96,7 +116,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, identityData)...)
 }
 
-func (r *Resource) getProfile(ctx context.Context, profileId string) (*geniprofile.Profile, error) {
+func (r *Resource) getProfile(ctx context.Context, profileId string) (*geniclient.Profile, error) {
 	return r.batchClient.GetProfile(ctx, profileId)
 }
 
@@ -120,6 +140,8 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		return
 	}
 	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	// Have the read that follows adopt the profile's events.
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, adoptEventsKey, []byte("true"))...)
 }
 
 // validateProfileImportID round-trips the API to confirm the imported profile
@@ -129,7 +151,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 func validateProfileImportID(
 	ctx context.Context,
 	id string,
-	fetch func(context.Context, string) (*geniprofile.Profile, error),
+	fetch func(context.Context, string) (*geniclient.Profile, error),
 ) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/go-geni"
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+)
+
+// Relatives are the profiles a profile is related to through its unions, each
+// list sorted and free of duplicates.
+type Relatives struct {
+	// Parents are the partners of the unions the profile is a child in.
+	Parents []string
+	// Partners are the other partners of the unions the profile is a partner in.
+	Partners []string
+	// Children are the children of the unions the profile is a partner in.
+	Children []string
+	// Siblings are the other children of the unions the profile is a child in.
+	Siblings []string
+}
+
+// RelativesFrom fetches each of the profile's unions through fetch and returns
+// the relatives they make. A union Geni no longer has is skipped.
+func RelativesFrom(
+	ctx context.Context,
+	profile *geniprofile.Profile,
+	fetch func(context.Context, string) (*geniunion.Union, error),
+) (Relatives, error) {
+	relatives := Relatives{Parents: []string{}, Partners: []string{}, Children: []string{}, Siblings: []string{}}
+	for _, unionID := range profile.Unions {
+		union, err := fetch(ctx, unionID)
+		if err != nil {
+			if errors.Is(err, geni.ErrResourceNotFound) {
+				continue
+			}
+			return Relatives{}, err
+		}
+
+		if slices.Contains(union.Partners, profile.ID) {
+			relatives.Partners = appendOthers(relatives.Partners, union.Partners, profile.ID)
+			relatives.Children = appendOthers(relatives.Children, union.Children, profile.ID)
+		}
+		if slices.Contains(union.Children, profile.ID) {
+			relatives.Parents = appendOthers(relatives.Parents, union.Partners, profile.ID)
+			relatives.Siblings = appendOthers(relatives.Siblings, union.Children, profile.ID)
+		}
+	}
+
+	for _, ids := range []*[]string{&relatives.Parents, &relatives.Partners, &relatives.Children, &relatives.Siblings} {
+		slices.Sort(*ids)
+		*ids = slices.Compact(*ids)
+	}
+	return relatives, nil
+}
+
+// appendOthers appends the ids other than self to dst.
+func appendOthers(dst, ids []string, self string) []string {
+	for _, id := range ids {
+		if id != self {
+			dst = append(dst, id)
+		}
+	}
+	return dst
+}
+
+// RelativesValueFrom sets the parents, partners, children and siblings of
+// profileModel from relatives.
+func RelativesValueFrom(ctx context.Context, relatives Relatives, profileModel *ResourceModel) diag.Diagnostics {
+	var d diag.Diagnostics
+
+	parents, diags := types.SetValueFrom(ctx, types.StringType, relatives.Parents)
+	d.Append(diags...)
+	profileModel.Parents = parents
+
+	partners, diags := types.SetValueFrom(ctx, types.StringType, relatives.Partners)
+	d.Append(diags...)
+	profileModel.Partners = partners
+
+	children, diags := types.SetValueFrom(ctx, types.StringType, relatives.Children)
+	d.Append(diags...)
+	profileModel.Children = children
+
+	siblings, diags := types.SetValueFrom(ctx, types.StringType, relatives.Siblings)
+	d.Append(diags...)
+	profileModel.Siblings = siblings
+
+	return d
+}
+
+// readRelatives sets the relatives of profileModel from the profile's unions.
+func (r *Resource) readRelatives(ctx context.Context, profile *geniprofile.Profile, profileModel *ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	relatives, err := RelativesFrom(ctx, profile, r.batchClient.GetUnion)
+	if err != nil {
+		diags.AddError("Error reading the profile's unions", err.Error())
+		return diags
+	}
+	return RelativesValueFrom(ctx, relatives, profileModel)
+}
+
+// relativesUnknown reports whether the plan leaves any of the relatives of
+// profileModel unknown. They are planned from state, so only a create or a
+// state without them needs reading them after the apply; known sets are
+// kept, like Unions in UpdateComputedFields.
+func relativesUnknown(profileModel ResourceModel) bool {
+	return profileModel.Parents.IsUnknown() || profileModel.Partners.IsUnknown() ||
+		profileModel.Children.IsUnknown() || profileModel.Siblings.IsUnknown()
+}
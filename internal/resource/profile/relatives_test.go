@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/go-geni"
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+)
+
+func TestRelativesFrom(t *testing.T) {
+	unions := map[string]*geniunion.Union{
+		// The union the profile was born into, and the one from a parent's
+		// remarriage.
+		"union-1": {ID: "union-1", Partners: []string{"profile-1", "profile-2"}, Children: []string{"profile-3", "profile-4"}},
+		"union-2": {ID: "union-2", Partners: []string{"profile-2", "profile-5"}, Children: []string{"profile-3", "profile-6"}},
+		// The profile's own marriages.
+		"union-3": {ID: "union-3", Partners: []string{"profile-3", "profile-7"}, Children: []string{"profile-8"}, AdoptedChildren: []string{"profile-8"}},
+		"union-4": {ID: "union-4", Partners: []string{"profile-3"}, Children: []string{"profile-9"}},
+	}
+	fetch := func(_ context.Context, id string) (*geniunion.Union, error) {
+		if union, ok := unions[id]; ok {
+			return union, nil
+		}
+		return nil, geni.ErrResourceNotFound
+	}
+
+	t.Run("Derives parents, partners, children and siblings from the unions", func(t *testing.T) {
+		RegisterTestingT(t)
+		profile := &geniprofile.Profile{ID: "profile-3", Unions: []string{"union-1", "union-2", "union-3", "union-4"}}
+
+		relatives, err := RelativesFrom(t.Context(), profile, fetch)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relatives.Parents).To(Equal([]string{"profile-1", "profile-2", "profile-5"}))
+		Expect(relatives.Partners).To(Equal([]string{"profile-7"}))
+		Expect(relatives.Children).To(Equal([]string{"profile-8", "profile-9"}))
+		Expect(relatives.Siblings).To(Equal([]string{"profile-4", "profile-6"}))
+	})
+
+	t.Run("A profile without unions has no relatives", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		relatives, err := RelativesFrom(t.Context(), &geniprofile.Profile{ID: "profile-10"}, fetch)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relatives.Parents).To(BeEmpty())
+		Expect(relatives.Parents).ToNot(BeNil())
+		Expect(relatives.Siblings).To(BeEmpty())
+	})
+
+	t.Run("A union Geni no longer has is skipped", func(t *testing.T) {
+		RegisterTestingT(t)
+		profile := &geniprofile.Profile{ID: "profile-7", Unions: []string{"union-99", "union-3"}}
+
+		relatives, err := RelativesFrom(t.Context(), profile, fetch)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relatives.Partners).To(Equal([]string{"profile-3"}))
+		Expect(relatives.Children).To(Equal([]string{"profile-8"}))
+	})
+
+	t.Run("Any other error is returned", func(t *testing.T) {
+		RegisterTestingT(t)
+		failing := func(context.Context, string) (*geniunion.Union, error) {
+			return nil, errors.New("boom")
+		}
+
+		_, err := RelativesFrom(t.Context(), &geniprofile.Profile{ID: "profile-3", Unions: []string{"union-1"}}, failing)
+
+		Expect(err).To(MatchError("boom"))
+	})
+}
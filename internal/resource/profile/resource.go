@@ -6,20 +6,30 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
-	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithIdentity = &Resource{} // new interface
 var _ resource.ResourceWithUpgradeState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 type Resource struct {
 	resource.ResourceWithConfigure
-	client                   *geni.Client
+	client                   *geniclient.Client
 	batchClient              *genibatch.Client
 	autoUpdateMergedProfiles bool
+	strictValidation         bool
+	maxLifespan              int64
+	// places is nil unless a gazetteer is configured.
+	places *gazetteer.Gazetteer
+	// experimentalEvents lets the `events` map use Geni's undocumented event
+	// endpoints.
+	experimentalEvents bool
 }
 
 func NewProfileResource() resource.Resource {
@@ -54,4 +64,8 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	r.client = cfg.Client
 	r.batchClient = cfg.BatchClient
 	r.autoUpdateMergedProfiles = cfg.AutoUpdateMergedProfiles
+	r.strictValidation = cfg.StrictValidation
+	r.maxLifespan = cfg.MaxLifespan
+	r.places = cfg.Gazetteer
+	r.experimentalEvents = cfg.ExperimentalEvents
 }
@@ -28,7 +28,8 @@ var (
 // Schema defines the schema for the resource.
 func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Version: 1,
+		Version:     2,
+		Description: "A person's profile on Geni. Maiden names and nicknames are kept per locale in `names`, as `birth_last_name` and `nicknames`. Geni's profile API has no religion, nationality or ethnicity fields, so the profile has no attributes for them.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:      true,
@@ -97,9 +98,34 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Description: "Nested maps of locales to name fields to values.",
 			},
 			"unions": schema.SetAttribute{
-				ElementType: types.StringType,
-				Computed:    true,
-				Description: "List of union IDs the profile belongs to. Computed from Geni on refresh.",
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+				Description:   "List of union IDs the profile belongs to. Computed from Geni on refresh.",
+			},
+			"parents": schema.SetAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+				Description:   "IDs of the partners of the unions the profile is a child in. Computed from Geni on refresh.",
+			},
+			"partners": schema.SetAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+				Description:   "IDs of the other partners of the unions the profile is a partner in. Computed from Geni on refresh.",
+			},
+			"children": schema.SetAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+				Description:   "IDs of the children of the unions the profile is a partner in, including foster and adopted children. Computed from Geni on refresh.",
+			},
+			"siblings": schema.SetAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Set{setplanmodifier.UseStateForUnknown()},
+				Description:   "IDs of the other children of the unions the profile is a child in, including half-siblings. Computed from Geni on refresh.",
 			},
 			"projects": schema.SetAttribute{
 				ElementType: types.StringType,
@@ -131,6 +157,7 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				DescriptionComputed: true,
 				Description:         "Burial event information.",
 			}),
+			"events": eventsSchema(),
 			"cause_of_death": schema.StringAttribute{
 				Optional:    true,
 				Description: "Profile's death cause",
@@ -138,14 +165,17 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 			"current_residence": event.LocationSchema("Event's location."),
 			"about": schema.MapAttribute{
 				Optional:    true,
-				ElementType: types.StringType,
+				ElementType: AboutType{},
 				Validators: []validator.Map{
-					// Do not allow trailing spaces or newlines
-					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[\S\s]*\S+$`), "cannot have trailing whitespaces or newlines")),
 					// Keys must be locales
 					mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`), "must be a valid locale code")),
 				},
-				Description: "Profile's about me section.",
+				Description: "Profile's about me section. Texts that only differ from Geni's copy by its rewrites — HTML entities, line endings, spaces at the ends of lines — are not a change.",
+			},
+			"about_format": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("html", aboutFormatMarkdown)},
+				Description: "Format of the about texts: `html`, the default, sends them to Geni as written, and `markdown` converts them from Markdown to the HTML Geni displays, and back when reading.",
 			},
 			"public": schema.BoolAttribute{
 				Required:    true,
@@ -155,6 +185,23 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Required:    true,
 				Description: "Profile's alive status.",
 			},
+			"locked": schema.BoolAttribute{
+				Computed:      true,
+				Optional:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Description:   "Whether the profile is locked down by a curator, so that its managers can no longer change it. Only curators can lock or unlock a profile; when not set, the profile keeps the lock it has.",
+			},
+			"language": schema.StringAttribute{
+				Computed:      true,
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+				Description:   "The profile's language, as set on Geni; when not set, the profile keeps the language it has.",
+			},
+			"master_profile": schema.BoolAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+				Description:   "Whether a curator has made the profile a master profile, the one Geni keeps for the person among its duplicates.",
+			},
 			"deleted": schema.BoolAttribute{
 				Computed:      true,
 				Optional:      true,
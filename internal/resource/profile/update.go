@@ -13,6 +13,8 @@ import (
 
 // Update updates the resource.
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.batchClient.Invalidate()
+
 	var plan, state ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -83,11 +85,38 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		}
 	}
 
-	diags = UpdateComputedFields(ctx, profileResponse, &plan)
+	managed, diags := loadManagedEvents(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	events, diags := r.writeEvents(ctx, profileResponse.ID, state.Events, plan.Events, managed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Events = events
+	if resp.Private != nil {
+		resp.Diagnostics.Append(saveManagedEvents(ctx, resp.Private, events)...)
+	}
+
+	diags = UpdateComputedFields(ctx, &profileResponse.Profile, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if plan.MasterProfile.IsUnknown() {
+		plan.MasterProfile = types.BoolValue(profileResponse.MasterProfile)
+	}
+	if relativesUnknown(plan) {
+		// The batch cache may still hold the unions as they were before the
+		// apply; the deferred Invalidate fires too late for this read.
+		r.batchClient.Invalidate()
+		resp.Diagnostics.Append(r.readRelatives(ctx, &profileResponse.Profile, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 
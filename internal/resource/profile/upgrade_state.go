@@ -2,16 +2,26 @@ package profile
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/geniplanmodifier"
@@ -19,7 +29,7 @@ import (
 
 func (r *Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
 	return map[int64]resource.StateUpgrader{
-		// State upgrade implementation from 0 (prior state version) to 1 (Schema.Version)
+		// State upgrade implementation from 0 (prior state version) to 2 (Schema.Version)
 		0: {
 			PriorSchema: &schema.Schema{
 				Attributes: map[string]schema.Attribute{
@@ -85,31 +95,15 @@ func (r *Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpg
 						)},
 						Description: "List of project IDs.",
 					},
-					"birth": event.Schema(event.SchemaOptions{
-						NameComputed:        true,
-						DescriptionComputed: true,
-						Description:         "Birth event information.",
-					}),
-					"baptism": event.Schema(event.SchemaOptions{
-						NameComputed:        true,
-						DescriptionComputed: true,
-						Description:         "Baptism event information.",
-					}),
-					"death": event.Schema(event.SchemaOptions{
-						NameComputed:        true,
-						DescriptionComputed: true,
-						Description:         "Death event information.",
-					}),
-					"burial": event.Schema(event.SchemaOptions{
-						NameComputed:        true,
-						DescriptionComputed: true,
-						Description:         "Burial event information.",
-					}),
+					"birth":   priorEventSchema("Birth event information."),
+					"baptism": priorEventSchema("Baptism event information."),
+					"death":   priorEventSchema("Death event information."),
+					"burial":  priorEventSchema("Burial event information."),
 					"cause_of_death": schema.StringAttribute{
 						Optional:    true,
 						Description: "Profile's death cause",
 					},
-					"current_residence": event.LocationSchema("Event's location."),
+					"current_residence": priorLocationSchema("Event's location."),
 					"about": schema.StringAttribute{
 						Optional:    true,
 						Description: "Profile's about me section.",
@@ -152,37 +146,461 @@ func (r *Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpg
 				}
 
 				upgradedStateData := ResourceModel{
-					ID:               priorStateData.ID,
-					Names:            priorStateData.Names,
-					Gender:           priorStateData.Gender,
-					Birth:            priorStateData.Birth,
-					Baptism:          priorStateData.Baptism,
-					Death:            priorStateData.Death,
-					Burial:           priorStateData.Burial,
-					CauseOfDeath:     priorStateData.CauseOfDeath,
-					Unions:           priorStateData.Unions,
-					Projects:         priorStateData.Projects,
-					CurrentResidence: priorStateData.CurrentResidence,
-					Public:           priorStateData.Public,
-					Alive:            priorStateData.Alive,
-					Deleted:          priorStateData.Deleted,
-					MergedInto:       priorStateData.MergedInto,
-					CreatedAt:        priorStateData.CreatedAt,
+					ID:           priorStateData.ID,
+					Names:        priorStateData.Names,
+					Gender:       priorStateData.Gender,
+					Events:       types.MapNull(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}),
+					CauseOfDeath: priorStateData.CauseOfDeath,
+					Unions:       priorStateData.Unions,
+					Parents:      types.SetNull(types.StringType),
+					Partners:     types.SetNull(types.StringType),
+					Children:     types.SetNull(types.StringType),
+					Siblings:     types.SetNull(types.StringType),
+					Projects:     priorStateData.Projects,
+					Public:       priorStateData.Public,
+					Alive:        priorStateData.Alive,
+					Deleted:      priorStateData.Deleted,
+					MergedInto:   priorStateData.MergedInto,
+					CreatedAt:    priorStateData.CreatedAt,
+				}
+				resp.Diagnostics.Append(upgradeEvents(ctx, &upgradedStateData, priorStateData.Birth, priorStateData.Baptism, priorStateData.Death, priorStateData.Burial, priorStateData.CurrentResidence)...)
+				if resp.Diagnostics.HasError() {
+					return
 				}
 
 				if priorStateData.About.ValueString() != "" {
-					upgradedStateData.About, resp.Diagnostics = types.MapValueFrom(ctx, types.StringType, map[string]string{
+					upgradedStateData.About, resp.Diagnostics = types.MapValueFrom(ctx, AboutType{}, map[string]string{
 						"en-US": priorStateData.About.ValueString(),
 					})
 					if resp.Diagnostics.HasError() {
 						return
 					}
 				} else {
-					upgradedStateData.About = types.MapNull(types.StringType)
+					upgradedStateData.About = types.MapNull(AboutType{})
 				}
 
 				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedStateData)...)
 			},
 		},
+		// State upgrade implementation from 1 (prior state version) to 2 (Schema.Version)
+		1: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:      true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+						Validators:    []validator.String{stringvalidator.RegexMatches(profileIdFormat, "must be in the format profile-1")},
+						Description:   "The unique identifier for the profile. This is a string that starts with 'profile-' followed by a number.",
+					},
+					"guid": schema.StringAttribute{
+						Computed:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+						Description:   "The globally unique identifier (GUID) for the profile, as assigned by Geni.",
+					},
+					"gender": schema.StringAttribute{
+						Optional:    true,
+						Validators:  []validator.String{stringvalidator.OneOf("female", "male")},
+						Description: "Profile's gender.",
+					},
+					"title": schema.StringAttribute{
+						Optional:    true,
+						Description: "Profile's name title (e.g. \"Dr.\", \"Sir\").",
+					},
+					"suffix": schema.StringAttribute{
+						Optional:    true,
+						Description: "Profile's name suffix (e.g. \"Jr.\", \"III\").",
+					},
+					"occupation": schema.StringAttribute{
+						Optional:    true,
+						Description: "Profile's occupation.",
+					},
+					"names": schema.MapNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"first_name": schema.StringAttribute{
+									Optional:    true,
+									Description: "The first name of the person.",
+								},
+								"middle_name": schema.StringAttribute{
+									Optional:    true,
+									Description: "The middle name of the person.",
+								},
+								"last_name": schema.StringAttribute{
+									Optional:    true,
+									Description: "The last name of the person.",
+								},
+								"birth_last_name": schema.StringAttribute{
+									Optional:    true,
+									Description: "The birth last name of the person.",
+								},
+								"display_name": schema.StringAttribute{
+									Optional:    true,
+									Description: "The display name of the person.",
+								},
+								"nicknames": schema.SetAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "The nicknames of the person.",
+								},
+							},
+						},
+						Validators: []validator.Map{
+							// Keys must be locales
+							mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`), "must be a valid locale code")),
+						},
+						Description: "Nested maps of locales to name fields to values.",
+					},
+					"unions": schema.SetAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "List of union IDs the profile belongs to. Computed from Geni on refresh.",
+					},
+					"projects": schema.SetAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						PlanModifiers: []planmodifier.Set{setplanmodifier.RequiresReplaceIf(
+							geniplanmodifier.ValuesAreRemovedFromState,
+							"If the value of this attribute is configured and changes, Terraform will destroy and recreate the resource.",
+							"If the value of this attribute is configured and changes, Terraform will destroy and recreate the resource.",
+						)},
+						Description: "List of project IDs.",
+					},
+					"birth":   priorEventSchema("Birth event information."),
+					"baptism": priorEventSchema("Baptism event information."),
+					"death":   priorEventSchema("Death event information."),
+					"burial":  priorEventSchema("Burial event information."),
+					"cause_of_death": schema.StringAttribute{
+						Optional:    true,
+						Description: "Profile's death cause",
+					},
+					"current_residence": priorLocationSchema("Event's location."),
+					"about": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Map{
+							// Do not allow trailing spaces or newlines
+							mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[\S\s]*\S+$`), "cannot have trailing whitespaces or newlines")),
+							// Keys must be locales
+							mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`), "must be a valid locale code")),
+						},
+						Description: "Profile's about me section.",
+					},
+					"public": schema.BoolAttribute{
+						Required:    true,
+						Description: "Profile's public visibility.",
+					},
+					"alive": schema.BoolAttribute{
+						Required:    true,
+						Description: "Profile's alive status.",
+					},
+					"deleted": schema.BoolAttribute{
+						Computed:      true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+						Description:   "Profile's deleted status.",
+					},
+					"merged_into": schema.StringAttribute{
+						Computed:      true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+						Description:   "The ID of the profile this profile was merged into.",
+					},
+					"created_at": schema.StringAttribute{
+						Computed:      true,
+						Optional:      true,
+						PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+						Validators:    []validator.String{stringvalidator.RegexMatches(createdAtFormat, "must be a Unix epoch time in seconds")},
+						Description:   "The Unix epoch time in seconds when the profile was created.",
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorStateData ResourceModelV1
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedStateData := ResourceModel{
+					ID:            priorStateData.ID,
+					Guid:          priorStateData.Guid,
+					Names:         priorStateData.Names,
+					Title:         priorStateData.Title,
+					Suffix:        priorStateData.Suffix,
+					Occupation:    priorStateData.Occupation,
+					Gender:        priorStateData.Gender,
+					Events:        types.MapNull(types.ObjectType{AttrTypes: CustomEventAttributeTypes()}),
+					CauseOfDeath:  priorStateData.CauseOfDeath,
+					Unions:        priorStateData.Unions,
+					Parents:       types.SetNull(types.StringType),
+					Partners:      types.SetNull(types.StringType),
+					Children:      types.SetNull(types.StringType),
+					Siblings:      types.SetNull(types.StringType),
+					Projects:      priorStateData.Projects,
+					Public:        priorStateData.Public,
+					Alive:         priorStateData.Alive,
+					Locked:        types.BoolNull(),
+					Language:      types.StringNull(),
+					MasterProfile: types.BoolNull(),
+					Deleted:       priorStateData.Deleted,
+					MergedInto:    priorStateData.MergedInto,
+					CreatedAt:     priorStateData.CreatedAt,
+				}
+				resp.Diagnostics.Append(upgradeEvents(ctx, &upgradedStateData, priorStateData.Birth, priorStateData.Baptism, priorStateData.Death, priorStateData.Burial, priorStateData.CurrentResidence)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var about map[string]string
+				resp.Diagnostics.Append(priorStateData.About.ElementsAs(ctx, &about, false)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				if about != nil {
+					upgradedStateData.About, resp.Diagnostics = types.MapValueFrom(ctx, AboutType{}, about)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+				} else {
+					upgradedStateData.About = types.MapNull(AboutType{})
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedStateData)...)
+			},
+		},
+	}
+}
+
+// upgradeEvents sets the events and the current residence of upgraded to
+// those of a prior state, whose event and location objects lack the
+// attributes added to them since.
+func upgradeEvents(ctx context.Context, upgraded *ResourceModel, birth, baptism, death, burial, currentResidence types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, e := range []struct {
+		target *types.Object
+		prior  types.Object
+	}{
+		{&upgraded.Birth, birth},
+		{&upgraded.Baptism, baptism},
+		{&upgraded.Death, death},
+		{&upgraded.Burial, burial},
+	} {
+		var d diag.Diagnostics
+		*e.target, d = upgradeObject(ctx, e.prior, event.EventModelAttributeTypes())
+		diags.Append(d...)
+	}
+
+	var d diag.Diagnostics
+	upgraded.CurrentResidence, d = upgradeObject(ctx, currentResidence, event.LocationModelAttributeTypes())
+	diags.Append(d...)
+	return diags
+}
+
+// upgradeObject returns prior as an object of attrTypes, with the attributes
+// prior lacks null. Nested objects are upgraded the same way.
+func upgradeObject(ctx context.Context, prior types.Object, attrTypes map[string]attr.Type) (types.Object, diag.Diagnostics) {
+	if prior.IsNull() {
+		return types.ObjectNull(attrTypes), nil
+	}
+	if prior.IsUnknown() {
+		return types.ObjectUnknown(attrTypes), nil
+	}
+
+	var diags diag.Diagnostics
+	priorAttributes := prior.Attributes()
+	attributes := make(map[string]attr.Value, len(attrTypes))
+	for name, attrType := range attrTypes {
+		value, ok := priorAttributes[name]
+		switch {
+		case !ok:
+			nullValue, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+			if err != nil {
+				diags.AddError("Error upgrading state", fmt.Sprintf("Cannot make a null %s: %s", name, err))
+				continue
+			}
+			attributes[name] = nullValue
+		case isObjectType(attrType):
+			object, ok := value.(types.Object)
+			if !ok {
+				diags.AddError("Error upgrading state", fmt.Sprintf("The prior %s is not an object.", name))
+				continue
+			}
+			var d diag.Diagnostics
+			attributes[name], d = upgradeObject(ctx, object, attrType.(types.ObjectType).AttrTypes)
+			diags.Append(d...)
+		default:
+			attributes[name] = value
+		}
+	}
+	if diags.HasError() {
+		return types.ObjectNull(attrTypes), diags
+	}
+
+	upgraded, d := types.ObjectValue(attrTypes, attributes)
+	diags.Append(d...)
+	return upgraded, diags
+}
+
+func isObjectType(t attr.Type) bool {
+	_, ok := t.(types.ObjectType)
+	return ok
+}
+
+// priorEventSchema is event.Schema as versions 0 and 1 of the schema had it,
+// before the dates gained calendars and the locations gazetteer places and
+// historical names.
+func priorEventSchema(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Event's name.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Event's description.",
+			},
+			"date":     priorDateRangeSchema("Event's date."),
+			"location": priorLocationSchema("Event's location."),
+		},
+		Validators: []validator.Object{
+			objectvalidator.Any(
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("date")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("location")),
+			),
+		},
+		Description: description,
+	}
+}
+
+// priorLocationSchema is event.LocationSchema as versions 0 and 1 of the
+// schema had it.
+func priorLocationSchema(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"city": schema.StringAttribute{
+				Optional:    true,
+				Description: "City name.",
+			},
+			"country": schema.StringAttribute{
+				Optional:    true,
+				Description: "Country name.",
+			},
+			"county": schema.StringAttribute{
+				Optional:    true,
+				Description: "County name.",
+			},
+			"latitude": schema.Float64Attribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
+				Description:   "Latitude coordinate.",
+			},
+			"longitude": schema.Float64Attribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
+				Description:   "Longitude coordinate.",
+			},
+			"place_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Place name.",
+			},
+			"state": schema.StringAttribute{
+				Optional:    true,
+				Description: "State name.",
+			},
+			"street_address1": schema.StringAttribute{
+				Optional:    true,
+				Description: "First line of the street address.",
+			},
+			"street_address2": schema.StringAttribute{
+				Optional:    true,
+				Description: "Second line of the street address.",
+			},
+			"street_address3": schema.StringAttribute{
+				Optional:    true,
+				Description: "Third line of the street address.",
+			},
+		},
+		Validators: []validator.Object{
+			objectvalidator.Any(
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("city")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("country")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("county")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("place_name")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("state")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("street_address1")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("street_address2")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("street_address3")),
+			),
+		},
+		Description: description,
+	}
+}
+
+// priorDateRangeSchema is event.DateRangeSchema as versions 0 and 1 of the
+// schema had it.
+func priorDateRangeSchema(description string) schema.SingleNestedAttribute {
+	rangePath := path.MatchRelative().AtParent().AtName("range")
+
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"range": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("before", "after", "between")},
+				Description: "Range (before, after, or between).",
+			},
+			"circa": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Indicates whether the date is an approximation.",
+			},
+			"day": schema.Int32Attribute{
+				Optional:    true,
+				Description: "Day of the month.",
+			},
+			"month": schema.Int32Attribute{
+				Optional:    true,
+				Description: "Month of the year.",
+			},
+			"year": schema.Int32Attribute{
+				Optional:    true,
+				Description: "Date's year.",
+			},
+			"end_circa": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Indicates whether the end date is an approximation.",
+			},
+			"end_day": schema.Int32Attribute{
+				Optional:    true,
+				Validators:  []validator.Int32{int32validator.AlsoRequires(rangePath)},
+				Description: "Date's end day (only valid if range is between).",
+			},
+			"end_month": schema.Int32Attribute{
+				Optional:    true,
+				Validators:  []validator.Int32{int32validator.AlsoRequires(rangePath)},
+				Description: "Date's end month (only valid if range is between).",
+			},
+			"end_year": schema.Int32Attribute{
+				Optional:    true,
+				Validators:  []validator.Int32{int32validator.AlsoRequires(rangePath)},
+				Description: "Date's end year (only valid if range is between).",
+			},
+		},
+		Validators: []validator.Object{
+			objectvalidator.Any(
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("day")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("month")),
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("year")),
+			),
+		},
+		Description: description,
 	}
 }
@@ -0,0 +1,95 @@
+package profile
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+func TestUpgradeState(t *testing.T) {
+	t.Run("A version 1 state the released provider wrote upgrades to the current schema", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		upgraded := upgradeState(t, 1, "testdata/profile_state_v1.json")
+
+		Expect(upgraded.ID).To(Equal(types.StringValue("profile-1")))
+		Expect(upgraded.Guid).To(Equal(types.StringValue("6000000012345678901")))
+		Expect(upgraded.Occupation).To(Equal(types.StringValue("Teacher")))
+		Expect(upgraded.About.Elements()).To(HaveKeyWithValue("en-US", NewAboutValue("Born in Tver, emigrated in 1923.")))
+		Expect(upgraded.AboutFormat.IsNull()).To(BeTrue())
+		Expect(upgraded.Parents.IsNull()).To(BeTrue())
+		Expect(upgraded.Locked.IsNull()).To(BeTrue())
+		Expect(upgraded.Events.IsNull()).To(BeTrue())
+
+		var birth event.Model
+		Expect(upgraded.Birth.As(t.Context(), &birth, basetypes.ObjectAsOptions{})).To(BeEmpty())
+		Expect(birth.Name).To(Equal(types.StringValue("Birth of Anna")))
+		var date event.DateRangeModel
+		Expect(birth.Date.As(t.Context(), &date, basetypes.ObjectAsOptions{})).To(BeEmpty())
+		Expect(date.Year).To(Equal(types.Int32Value(1901)))
+		Expect(date.Calendar.IsNull()).To(BeTrue())
+		var location event.LocationModel
+		Expect(birth.Location.As(t.Context(), &location, basetypes.ObjectAsOptions{})).To(BeEmpty())
+		Expect(location.City).To(Equal(types.StringValue("Tver")))
+		Expect(location.PlaceID.IsNull()).To(BeTrue())
+		Expect(location.Historical.IsNull()).To(BeTrue())
+
+		Expect(upgraded.Baptism.IsNull()).To(BeTrue())
+		Expect(upgraded.Baptism.AttributeTypes(t.Context())).To(Equal(event.EventModelAttributeTypes()))
+		Expect(upgraded.CurrentResidence.Attributes()).To(HaveKeyWithValue("city", types.StringValue("Paris")))
+		Expect(upgraded.CurrentResidence.Attributes()).To(HaveKeyWithValue("place_id", types.StringNull()))
+	})
+
+	t.Run("A version 0 state upgrades its about text to the en-US one", func(t *testing.T) {
+		RegisterTestingT(t)
+
+		upgraded := upgradeState(t, 0, "testdata/profile_state_v0.json")
+
+		Expect(upgraded.ID).To(Equal(types.StringValue("profile-1")))
+		Expect(upgraded.About.Elements()).To(HaveKeyWithValue("en-US", NewAboutValue("Born in Tver, emigrated in 1923.")))
+		Expect(upgraded.Birth.Attributes()).To(HaveKey("date"))
+		Expect(upgraded.CurrentResidence.IsNull()).To(BeTrue())
+		Expect(upgraded.CurrentResidence.AttributeTypes(t.Context())).To(Equal(event.LocationModelAttributeTypes()))
+	})
+}
+
+// upgradeState upgrades the raw state in file, which version of the schema
+// wrote, the way Terraform has the provider upgrade it.
+func upgradeState(t *testing.T, version int64, file string) ResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	r := &Resource{}
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	Expect(ok).To(BeTrue())
+	raw, err := os.ReadFile(file)
+	Expect(err).ToNot(HaveOccurred())
+	// The prior schema must describe the raw state exactly: an attribute it
+	// does not have fails the upgrade.
+	priorState, err := (&tfprotov6.RawState{JSON: raw}).UnmarshalWithOpts(upgrader.PriorSchema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{})
+	Expect(err).ToNot(HaveOccurred())
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Raw: priorState, Schema: *upgrader.PriorSchema}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		Schema: schemaResp.Schema,
+	}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	Expect(resp.Diagnostics).To(BeEmpty())
+
+	var upgraded ResourceModel
+	Expect(resp.State.Get(ctx, &upgraded)).To(BeEmpty())
+	return upgraded
+}
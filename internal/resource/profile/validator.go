@@ -0,0 +1,136 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The chronology is checked by ModifyPlan, since how is up to the provider
+	// configuration, which terraform validate does not have.
+	resp.Diagnostics.Append(validateCurrentResidence(data)...)
+}
+
+// validateCurrentResidence checks that data's current residence has no
+// historical names, which are kept in an event's description and a residence
+// has none.
+func validateCurrentResidence(data ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	historical, _ := data.CurrentResidence.Attributes()["historical"].(types.Object)
+	if !historical.IsNull() {
+		diags.AddAttributeError(path.Root("current_residence").AtName("historical"), "Historical Names Not Supported",
+			"Geni has no field for historical names, and a current residence has no description to keep them in. Remove historical.")
+	}
+	return diags
+}
+
+// chronologyChecks configures validateChronology.
+type chronologyChecks struct {
+	// strict reports issues as errors instead of warnings.
+	strict bool
+	// maxLifespan is the most years a profile may live, or zero for no limit.
+	maxLifespan int64
+	// today is the Julian Day Number a living profile's age is counted to.
+	today int64
+}
+
+// report adds an issue to diags at p, as an error when the checks are strict.
+func (c chronologyChecks) report(diags *diag.Diagnostics, p path.Path, summary, detail string) {
+	if c.strict {
+		diags.AddAttributeError(p, summary, detail)
+	} else {
+		diags.AddAttributeWarning(p, summary, detail)
+	}
+}
+
+// lifeEvent is one of a profile's events, in the order they happen in a life.
+type lifeEvent struct {
+	field string
+	value types.Object
+}
+
+// validateChronology checks that data's events are in the order of a life —
+// birth, baptism, death, burial — allowing for the slack of circa dates and
+// ranges, that a living profile has no death or burial, and that the profile
+// does not live longer than c.maxLifespan. Events without a year are skipped.
+func validateChronology(data ResourceModel, c chronologyChecks) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	events := []lifeEvent{
+		{"birth", data.Birth},
+		{"baptism", data.Baptism},
+		{"death", data.Death},
+		{"burial", data.Burial},
+	}
+
+	if data.Alive.ValueBool() {
+		for _, e := range events[2:] {
+			if !e.value.IsNull() {
+				c.report(&diags, path.Root(e.field), "Living Profile With Death Details",
+					fmt.Sprintf("The profile is alive but has a %s. Set alive to false, or remove %s.", e.field, e.field))
+			}
+		}
+	}
+
+	for i, earlier := range events {
+		earlierPeriod, ok := event.PeriodOf(earlier.value)
+		if !ok {
+			continue
+		}
+		for _, later := range events[i+1:] {
+			laterPeriod, ok := event.PeriodOf(later.value)
+			if !ok || !earlierPeriod.After(laterPeriod) {
+				continue
+			}
+			c.report(&diags, path.Root(later.field).AtName("date"), "Inconsistent Chronology",
+				fmt.Sprintf("The %s must not be before the %s, but the %s is on or before %s and the %s on or after %s.",
+					later.field, earlier.field, later.field, laterPeriod.Latest(), earlier.field, earlierPeriod.Earliest()))
+		}
+	}
+
+	if c.maxLifespan > 0 {
+		diags.Append(validateLifespan(data, c)...)
+	}
+
+	return diags
+}
+
+// validateLifespan checks that the shortest life data's birth and death allow,
+// or its age today when it is alive, is within c.maxLifespan years.
+func validateLifespan(data ResourceModel, c chronologyChecks) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	birth, ok := event.PeriodOf(data.Birth)
+	if !ok || birth.Latest() == "" {
+		return diags
+	}
+
+	end, at, until := c.today, path.Root("birth").AtName("date"), "today"
+	if death, ok := event.PeriodOf(data.Death); ok && death.Earliest() != "" {
+		end, at, until = death.From, path.Root("death").AtName("date"), "death"
+	} else if !data.Alive.ValueBool() {
+		return diags
+	}
+
+	if years := event.YearsBetween(birth.To, end); years > c.maxLifespan {
+		c.report(&diags, at, "Implausible Lifespan",
+			fmt.Sprintf("The profile's dates make it at least %d years old at %s, more than the provider's max_lifespan of %d.",
+				years, until, c.maxLifespan))
+	}
+	return diags
+}
@@ -0,0 +1,236 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+func TestValidateChronology(t *testing.T) {
+	today := event.DayOf(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	lenient := chronologyChecks{today: today}
+
+	t.Run("Events in the order of a life pass", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, year(1900), year(1900), year(1970), year(1970))
+
+		Expect(validateChronology(data, lenient)).To(BeEmpty())
+	})
+
+	t.Run("A death before the birth is a warning at the death's date", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, year(1900), nil, year(1890), nil)
+
+		diags := validateChronology(data, lenient)
+
+		Expect(diags.WarningsCount()).To(Equal(1))
+		Expect(diags.ErrorsCount()).To(BeZero())
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("death").AtName("date")))
+		Expect(diags[0].Detail()).To(Equal("The death must not be before the birth, but the death is on or before 31 December 1890 " +
+			"and the birth on or after 1 January 1900."))
+	})
+
+	t.Run("Strict checks report errors", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, year(1900), nil, year(1890), nil)
+
+		diags := validateChronology(data, chronologyChecks{strict: true, today: today})
+
+		Expect(diags.ErrorsCount()).To(Equal(1))
+		Expect(diags.WarningsCount()).To(BeZero())
+	})
+
+	t.Run("A burial before the death is reported at the burial", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, nil, nil, day(1950, 6, 2), day(1950, 6, 1))
+
+		diags := validateChronology(data, lenient)
+
+		Expect(diags).To(HaveLen(1))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("burial").AtName("date")))
+	})
+
+	t.Run("Dates in the same year are not in conflict", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, day(1900, 6, 1), year(1900), nil, nil)
+
+		Expect(validateChronology(data, lenient)).To(BeEmpty())
+	})
+
+	t.Run("A circa date allows for some years of error", func(t *testing.T) {
+		RegisterTestingT(t)
+		birth := &geniprofile.DateElement{Year: new(int32(1900)), Circa: new(true)}
+		data := lifeOf(t, birth, nil, year(1897), nil)
+
+		Expect(validateChronology(data, lenient)).To(BeEmpty())
+	})
+
+	t.Run("A range is only in conflict when all of it is", func(t *testing.T) {
+		RegisterTestingT(t)
+		death := &geniprofile.DateElement{Range: new("before"), Year: new(int32(1905))}
+		data := lifeOf(t, year(1900), nil, death, nil)
+
+		Expect(validateChronology(data, lenient)).To(BeEmpty())
+
+		death = &geniprofile.DateElement{Range: new("between"), Year: new(int32(1880)), EndYear: new(int32(1890))}
+		data = lifeOf(t, year(1900), nil, death, nil)
+
+		Expect(validateChronology(data, lenient)).To(HaveLen(1))
+	})
+
+	t.Run("A living profile with a death or burial is reported", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, year(1900), nil, year(1970), year(1970))
+		data.Alive = types.BoolValue(true)
+
+		diags := validateChronology(data, lenient)
+
+		Expect(diags).To(HaveLen(2))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("death")))
+		Expect(genitest.PathOf(diags[1])).To(Equal(path.Root("burial")))
+	})
+
+	t.Run("A lifespan is only checked when a maximum is set", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, year(1800), nil, year(1950), nil)
+
+		Expect(validateChronology(data, lenient)).To(BeEmpty())
+
+		diags := validateChronology(data, chronologyChecks{maxLifespan: 120, today: today})
+
+		Expect(diags).To(HaveLen(1))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("death").AtName("date")))
+		Expect(diags[0].Detail()).To(Equal("The profile's dates make it at least 149 years old at death, more than the provider's max_lifespan of 120."))
+	})
+
+	t.Run("A living profile's age is counted to today", func(t *testing.T) {
+		RegisterTestingT(t)
+		data := lifeOf(t, year(1850), nil, nil, nil)
+		data.Alive = types.BoolValue(true)
+
+		diags := validateChronology(data, chronologyChecks{maxLifespan: 120, today: today})
+
+		Expect(diags).To(HaveLen(1))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("birth").AtName("date")))
+
+		data.Alive = types.BoolValue(false)
+		Expect(validateChronology(data, chronologyChecks{maxLifespan: 120, today: today})).To(BeEmpty())
+	})
+}
+
+func TestChronologyAtPlanTime(t *testing.T) {
+	t.Run("Validation leaves the chronology to the plan", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}, strictValidation: true}
+		config := tfsdk.Config{Schema: schemaOf(t, r)}
+		config.Raw = planOf(t, r, lifeOf(t, year(1900), nil, year(1890), nil)).Raw
+		var resp resource.ValidateConfigResponse
+
+		r.ValidateConfig(t.Context(), resource.ValidateConfigRequest{Config: config}, &resp)
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+
+	t.Run("The plan checks the chronology as the provider configures it", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}, strictValidation: true, maxLifespan: 80}
+
+		resp := modifyPlan(t, r, lifeOf(t, year(1800), nil, year(1790), nil))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(resp.Diagnostics.Errors()[0].Summary()).To(Equal("Inconsistent Chronology"))
+
+		resp = modifyPlan(t, r, lifeOf(t, year(1800), nil, year(1900), nil))
+
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(1))
+		Expect(genitest.PathOf(resp.Diagnostics.Errors()[0])).To(Equal(path.Root("death").AtName("date")))
+	})
+}
+
+func TestValidateCurrentResidence(t *testing.T) {
+	RegisterTestingT(t)
+	data := NewEmptyResourceModel()
+	Expect(validateCurrentResidence(data)).To(BeEmpty())
+
+	residence, diags := event.LocationValueFrom(t.Context(), &geniprofile.LocationElement{City: new("Moscow")},
+		types.ObjectNull(event.LocationModelAttributeTypes()))
+	Expect(diags).To(BeEmpty())
+	data.CurrentResidence, diags = event.WithHistorical(t.Context(), residence, types.ObjectValueMust(
+		event.HistoricalModelAttributeTypes(), map[string]attr.Value{
+			"place_name": types.StringNull(),
+			"city":       types.StringValue("Moskva"),
+			"county":     types.StringNull(),
+			"state":      types.StringNull(),
+			"country":    types.StringValue("Russian Empire"),
+		}))
+	Expect(diags).To(BeEmpty())
+
+	diags = validateCurrentResidence(data)
+	Expect(diags.Errors()).To(HaveLen(1))
+	Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("current_residence").AtName("historical")))
+}
+
+// lifeOf returns a profile with the given event dates, nil for no event.
+func lifeOf(t *testing.T, birth, baptism, death, burial *geniprofile.DateElement) ResourceModel {
+	t.Helper()
+	data := NewEmptyResourceModel()
+	data.Alive = types.BoolNull()
+	for _, e := range []struct {
+		date *geniprofile.DateElement
+		to   *types.Object
+	}{{birth, &data.Birth}, {baptism, &data.Baptism}, {death, &data.Death}, {burial, &data.Burial}} {
+		if e.date == nil {
+			continue
+		}
+		value, diags := event.ValueFrom(t.Context(), &geniprofile.EventElement{Date: e.date}, types.ObjectNull(event.EventModelAttributeTypes()))
+		Expect(diags.HasError()).To(BeFalse())
+		*e.to = value
+	}
+	return data
+}
+
+// planOf returns the plan of data for r.
+func planOf(t *testing.T, r *Resource, data ResourceModel) tfsdk.Plan {
+	t.Helper()
+	s := schemaOf(t, r)
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(t.Context()), nil)}
+	Expect(plan.Set(t.Context(), data)).To(BeEmpty())
+	return plan
+}
+
+// modifyPlan runs r.ModifyPlan on a new profile planned as data.
+func modifyPlan(t *testing.T, r *Resource, data ResourceModel) *resource.ModifyPlanResponse {
+	t.Helper()
+	plan := planOf(t, r, data)
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(t.Context(), resource.ModifyPlanRequest{Plan: plan}, resp)
+	return resp
+}
+
+func schemaOf(t *testing.T, r *Resource) schema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	r.Schema(t.Context(), resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+func year(y int32) *geniprofile.DateElement {
+	return &geniprofile.DateElement{Year: new(y)}
+}
+
+func day(y, m, d int32) *geniprofile.DateElement {
+	return &geniprofile.DateElement{Year: new(y), Month: new(m), Day: new(d)}
+}
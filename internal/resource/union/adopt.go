@@ -0,0 +1,145 @@
+package union
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
+)
+
+// planAdoption plans a new union whose two partners already share a union on
+// Geni. Creating it would add a second union that Geni then auto-merges into
+// the first, leaving the resource tracking an empty duplicate. So a single
+// shared union is adopted by planning its id, and Create only adds the members
+// it lacks. Several shared unions fail the plan, since it cannot tell which
+// one the configuration means, and so does a shared union with members the
+// configuration does not list: adopting it would have the next plan remove
+// them, detaching real relatives on Geni. Setting id, or importing the union,
+// opts in to managing it as configured.
+func (r *Resource) planAdoption(ctx context.Context, plan *ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// A configured id is already the union to manage, and partners that are not
+	// known until apply are new profiles with no unions yet.
+	if !plan.ID.IsUnknown() || plan.Partners.IsUnknown() || len(plan.Partners.Elements()) != 2 {
+		return diags
+	}
+
+	partnerIds, d := tfset.Strings(ctx, plan.Partners)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	slices.Sort(partnerIds)
+
+	shared, d := r.sharedUnions(ctx, partnerIds[0], partnerIds[1])
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	switch len(shared) {
+	case 0:
+		return diags
+	case 1:
+	default:
+		diags.AddAttributeError(path.Root("id"), "Partners already share several unions",
+			fmt.Sprintf("%s and %s are already partners in %s on Geni. Creating another union for them would make "+
+				"Geni merge it into one of these. Set id to the union to manage, or import it.",
+				partnerIds[0], partnerIds[1], strings.Join(shared, ", ")))
+		return diags
+	}
+
+	unionID := shared[0]
+	extra, d := r.unlistedMembers(ctx, unionID, *plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(extra) > 0 {
+		diags.AddAttributeError(path.Root("id"), "Partners already share a union with other members",
+			fmt.Sprintf("%s and %s are already partners in %s on Geni, which also has %s. The configuration does not "+
+				"list them, so adopting the union would remove them from it on the next apply. List them in the "+
+				"configuration, or set id to %s, or import it, to manage the union as configured.",
+				partnerIds[0], partnerIds[1], unionID, strings.Join(extra, ", "), unionID))
+		return diags
+	}
+
+	plan.ID = types.StringValue(unionID)
+	diags.AddAttributeWarning(path.Root("id"), "Adopting existing union",
+		fmt.Sprintf("%s and %s are already partners in %s on Geni, so this union is planned as %s instead of "+
+			"a new one that Geni would merge into it. Apply adds only the children it lacks.",
+			partnerIds[0], partnerIds[1], unionID, unionID))
+	return diags
+}
+
+// sharedUnions returns the unions, in id order, that both profiles are
+// partners in. Profiles that share a union only as siblings do not count.
+func (r *Resource) sharedUnions(ctx context.Context, partner1, partner2 string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var unions [2][]string
+	for i, id := range []string{partner1, partner2} {
+		profile, err := r.batchClient.GetProfile(ctx, id)
+		if err != nil {
+			diags.AddAttributeError(path.Root(fieldPartners), "Error reading partner", err.Error())
+			return nil, diags
+		}
+		// A merged profile's unions belong to the profile it was merged into,
+		// which the configuration does not name.
+		if profile.Deleted {
+			return nil, diags
+		}
+		unions[i] = profile.Unions
+	}
+
+	var shared []string
+	for _, unionID := range unions[0] {
+		if !slices.Contains(unions[1], unionID) || slices.Contains(shared, unionID) {
+			continue
+		}
+		union, err := r.batchClient.GetUnion(ctx, unionID)
+		if err != nil {
+			diags.AddError("Error reading union", err.Error())
+			return nil, diags
+		}
+		if slices.Contains(union.Partners, partner1) && slices.Contains(union.Partners, partner2) {
+			shared = append(shared, unionID)
+		}
+	}
+	slices.Sort(shared)
+	return shared, diags
+}
+
+// unlistedMembers returns the members of unionID, in id order, that plan does
+// not list.
+func (r *Resource) unlistedMembers(ctx context.Context, unionID string, plan ResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	listed := make(map[string]struct{})
+	for _, set := range []types.Set{plan.Partners, plan.Children, plan.FosterChildren, plan.AdoptedChildren} {
+		if set.IsUnknown() {
+			continue
+		}
+		ids, d := tfset.Strings(ctx, set)
+		diags.Append(d...)
+		for _, id := range ids {
+			listed[id] = struct{}{}
+		}
+	}
+
+	union, err := r.batchClient.GetUnion(ctx, unionID)
+	if err != nil {
+		diags.AddError("Error reading union", err.Error())
+		return nil, diags
+	}
+	extra := missingEdges(slices.Concat(union.Partners, union.Children), listed)
+	slices.Sort(extra)
+	return extra, diags
+}
@@ -0,0 +1,89 @@
+package union
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+)
+
+func TestPlanAdoption(t *testing.T) {
+	t.Run("A union the partners already share is adopted", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 4)
+		existing, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3", "profile-4"}, nil, 1990)
+		resp := modifyPlan(t, r, nil, &plan)
+
+		Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+		var id types.String
+		Expect(resp.Plan.GetAttribute(t.Context(), path.Root("id"), &id).HasError()).To(BeFalse())
+		Expect(id).To(Equal(existing.ID))
+		Expect(resp.Diagnostics.Warnings()).To(HaveLen(1))
+		Expect(resp.Diagnostics.Warnings()[0].Summary()).To(Equal("Adopting existing union"))
+
+		plan.ID = id
+		r.batchClient.Invalidate()
+		created, diags := createUnion(t, r, plan)
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(created.ID).To(Equal(existing.ID))
+		partner, err := r.client.Profile().Get(t.Context(), "profile-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(partner.Unions).To(ConsistOf(existing.ID.ValueString()))
+		union, err := r.client.Union().Get(t.Context(), existing.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Partners).To(ConsistOf("profile-1", "profile-2"))
+		Expect(union.Children).To(ConsistOf("profile-3", "profile-4"))
+	})
+
+	t.Run("A shared union with members the configuration does not list fails the plan", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 4)
+		existing, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-4"}, nil, 1990)
+		resp := modifyPlan(t, r, nil, &plan)
+
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		Expect(resp.Diagnostics.Errors()[0].Summary()).To(Equal("Partners already share a union with other members"))
+		Expect(resp.Diagnostics.Errors()[0].Detail()).To(ContainSubstring("which also has profile-3"))
+		Expect(resp.Diagnostics.Errors()[0].Detail()).To(ContainSubstring("set id to " + existing.ID.ValueString()))
+		union, err := r.client.Union().Get(t.Context(), existing.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Children).To(ConsistOf("profile-3"))
+	})
+
+	t.Run("Partners with no union in common plan a new one", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		_, diags := createUnion(t, r, unionPlan(t, nil, []string{"profile-1", "profile-2"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		// The two profiles share a union only as siblings.
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990)
+		resp := modifyPlan(t, r, nil, &plan)
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+		var id types.String
+		Expect(resp.Plan.GetAttribute(t.Context(), path.Root("id"), &id).HasError()).To(BeFalse())
+		Expect(id.IsUnknown()).To(BeTrue())
+	})
+
+	t.Run("A configured id is left alone", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 2)
+		_, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, nil, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, nil, nil, 1990)
+		plan.ID = types.StringValue("union-99")
+		resp := modifyPlan(t, r, nil, &plan)
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+	})
+}
@@ -0,0 +1,154 @@
+package union
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+// posthumousBirthMargin is how many days after a parent's death a child may
+// still be born.
+const posthumousBirthMargin = 300
+
+// chronologyChecks configures validateChronology.
+type chronologyChecks struct {
+	// strict reports issues as errors instead of warnings.
+	strict bool
+	// partnerGenders reports partners of the same gender.
+	partnerGenders bool
+}
+
+// report adds an issue to diags at p, as an error when the checks are strict.
+func (c chronologyChecks) report(diags *diag.Diagnostics, p path.Path, summary, detail string) {
+	if c.strict {
+		diags.AddAttributeError(p, summary, detail)
+	} else {
+		diags.AddAttributeWarning(p, summary, detail)
+	}
+}
+
+// checkChronology reads the partners and children of plan from Geni and checks
+// them against each other and against the union's events (see
+// validateChronology). Members not known until apply are skipped.
+func (r *Resource) checkChronology(ctx context.Context, plan ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if r.batchClient == nil {
+		return diags
+	}
+
+	partners, d := r.knownProfiles(ctx, plan.Partners)
+	diags.Append(d...)
+	children, d := r.knownProfiles(ctx, plan.Children)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(validateChronology(plan, partners, children, chronologyChecks{
+		strict:         r.strictValidation,
+		partnerGenders: r.checkPartnerGenders,
+	})...)
+	return diags
+}
+
+// knownProfiles returns the profiles of the known ids in set, leaving out the
+// deleted ones.
+func (r *Resource) knownProfiles(ctx context.Context, set types.Set) ([]*geniprofile.Profile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if set.IsNull() || set.IsUnknown() {
+		return nil, diags
+	}
+
+	var profiles []*geniprofile.Profile
+	for _, element := range set.Elements() {
+		id, ok := element.(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			continue
+		}
+		profile, err := r.batchClient.GetProfile(ctx, id.ValueString())
+		if err != nil {
+			diags.AddError("Error reading profile", err.Error())
+			return nil, diags
+		}
+		if !profile.Deleted {
+			profiles = append(profiles, &profile.Profile)
+		}
+	}
+	return profiles, diags
+}
+
+// validateChronology checks that no child of plan is born before a partner,
+// or more than posthumousBirthMargin days after a partner dies, that the
+// marriage is within the partners' lives, and that the divorce is after the
+// marriage and before the partners die, allowing for the slack of circa dates
+// and ranges. Foster and adopted children are not checked, since a child may
+// be taken in by someone younger than itself. With c.partnerGenders, partners
+// of the same gender are reported too.
+func validateChronology(plan ResourceModel, partners, children []*geniprofile.Profile, c chronologyChecks) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	marriage, married := event.PeriodOf(plan.Marriage)
+	divorce, divorced := event.PeriodOf(plan.Divorce)
+	marriageDate, divorceDate := path.Root("marriage").AtName("date"), path.Root("divorce").AtName("date")
+
+	if married && divorced && marriage.After(divorce) {
+		c.report(&diags, divorceDate, "Inconsistent Chronology",
+			fmt.Sprintf("The divorce must not be before the marriage, but the divorce is on or before %s and the marriage on or after %s.",
+				divorce.Latest(), marriage.Earliest()))
+	}
+
+	for _, partner := range partners {
+		birth, born := event.PeriodOfElement(partner.Birth)
+		death, died := event.PeriodOfElement(partner.Death)
+
+		if born && married && birth.After(marriage) {
+			c.report(&diags, marriageDate, "Inconsistent Chronology",
+				fmt.Sprintf("The marriage must not be before the birth of partner %s, but the marriage is on or before %s and the birth on or after %s.",
+					partner.ID, marriage.Latest(), birth.Earliest()))
+		}
+		if died && married && marriage.After(death) {
+			c.report(&diags, marriageDate, "Inconsistent Chronology",
+				fmt.Sprintf("The marriage must not be after the death of partner %s, but the marriage is on or after %s and the death on or before %s.",
+					partner.ID, marriage.Earliest(), death.Latest()))
+		}
+		if died && divorced && divorce.After(death) {
+			c.report(&diags, divorceDate, "Inconsistent Chronology",
+				fmt.Sprintf("The divorce must not be after the death of partner %s, but the divorce is on or after %s and the death on or before %s.",
+					partner.ID, divorce.Earliest(), death.Latest()))
+		}
+
+		for _, child := range children {
+			childBirth, ok := event.PeriodOfElement(child.Birth)
+			if !ok {
+				continue
+			}
+			if born && birth.After(childBirth) {
+				c.report(&diags, path.Root(fieldChildren), "Inconsistent Chronology",
+					fmt.Sprintf("Child %s must not be born before its parent %s, but the child is born on or before %s and the parent on or after %s.",
+						child.ID, partner.ID, childBirth.Latest(), birth.Earliest()))
+			}
+			if died && childBirth.After(death.Extended(posthumousBirthMargin)) {
+				c.report(&diags, path.Root(fieldChildren), "Inconsistent Chronology",
+					fmt.Sprintf("Child %s must not be born long after its parent %s dies, but the child is born on or after %s and the parent dies on or before %s.",
+						child.ID, partner.ID, childBirth.Earliest(), death.Latest()))
+			}
+		}
+	}
+
+	if c.partnerGenders && len(partners) == 2 {
+		first, second := partners[0], partners[1]
+		if first.Gender != nil && second.Gender != nil && *first.Gender == *second.Gender {
+			c.report(&diags, path.Root(fieldPartners), "Partners Of The Same Gender",
+				fmt.Sprintf("Partners %s and %s are both %s. Unset check_partner_genders in the provider configuration if this is intended.",
+					first.ID, second.ID, *first.Gender))
+		}
+	}
+
+	return diags
+}
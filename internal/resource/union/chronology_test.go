@@ -0,0 +1,166 @@
+package union
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+func TestValidateChronology(t *testing.T) {
+	t.Run("Members and events in order are not reported", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+		plan.Divorce = eventIn(t, 1960)
+
+		diags := validateChronology(plan,
+			[]*geniprofile.Profile{person("profile-1", "male", 1925, 1990), person("profile-2", "male", 1928, 0)},
+			[]*geniprofile.Profile{person("profile-3", "", 1952, 0)},
+			chronologyChecks{})
+
+		Expect(diags).To(BeEmpty())
+	})
+
+	t.Run("A child born before a parent is reported with both ids", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+
+		diags := validateChronology(plan,
+			[]*geniprofile.Profile{person("profile-1", "", 1925, 0)},
+			[]*geniprofile.Profile{person("profile-3", "", 1920, 0)},
+			chronologyChecks{})
+
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Severity()).To(Equal(diag.SeverityWarning))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("children")))
+		Expect(diags[0].Detail()).To(Equal("Child profile-3 must not be born before its parent profile-1, " +
+			"but the child is born on or before 31 December 1920 and the parent on or after 1 January 1925."))
+	})
+
+	t.Run("A child born within the margin after a parent's death is not reported", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+		father := person("profile-1", "", 1925, 0)
+		father.Death = &geniprofile.EventElement{Date: &geniprofile.DateElement{Day: new(int32(1)), Month: new(int32(3)), Year: new(int32(1955))}}
+		child := person("profile-3", "", 0, 0)
+		child.Birth = &geniprofile.EventElement{Date: &geniprofile.DateElement{Day: new(int32(1)), Month: new(int32(11)), Year: new(int32(1955))}}
+
+		Expect(validateChronology(plan, []*geniprofile.Profile{father}, []*geniprofile.Profile{child}, chronologyChecks{})).To(BeEmpty())
+
+		child.Birth.Date.Year = new(int32(1957))
+		diags := validateChronology(plan, []*geniprofile.Profile{father}, []*geniprofile.Profile{child}, chronologyChecks{})
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Detail()).To(HavePrefix("Child profile-3 must not be born long after its parent profile-1 dies"))
+	})
+
+	t.Run("A marriage outside a partner's life is reported at its date", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+
+		diags := validateChronology(plan,
+			[]*geniprofile.Profile{person("profile-1", "", 1955, 0), person("profile-2", "", 1900, 1940)},
+			nil, chronologyChecks{})
+
+		Expect(diags).To(HaveLen(2))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("marriage").AtName("date")))
+		Expect(diags[0].Detail()).To(HavePrefix("The marriage must not be before the birth of partner profile-1"))
+		Expect(genitest.PathOf(diags[1])).To(Equal(path.Root("marriage").AtName("date")))
+		Expect(diags[1].Detail()).To(HavePrefix("The marriage must not be after the death of partner profile-2"))
+	})
+
+	t.Run("A divorce before the marriage or after a partner's death is reported at its date", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+		plan.Divorce = eventIn(t, 1945)
+
+		diags := validateChronology(plan, nil, nil, chronologyChecks{})
+
+		Expect(diags).To(HaveLen(1))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("divorce").AtName("date")))
+		Expect(diags[0].Detail()).To(Equal("The divorce must not be before the marriage, " +
+			"but the divorce is on or before 31 December 1945 and the marriage on or after 1 January 1950."))
+
+		plan.Divorce = eventIn(t, 1970)
+		diags = validateChronology(plan, []*geniprofile.Profile{person("profile-1", "", 1920, 1960)}, nil, chronologyChecks{})
+
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Detail()).To(HavePrefix("The divorce must not be after the death of partner profile-1"))
+	})
+
+	t.Run("Partners of the same gender are only reported when configured", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+		partners := []*geniprofile.Profile{person("profile-1", "female", 0, 0), person("profile-2", "female", 0, 0)}
+
+		Expect(validateChronology(plan, partners, nil, chronologyChecks{})).To(BeEmpty())
+
+		diags := validateChronology(plan, partners, nil, chronologyChecks{partnerGenders: true})
+		Expect(diags).To(HaveLen(1))
+		Expect(genitest.PathOf(diags[0])).To(Equal(path.Root("partners")))
+		Expect(diags[0].Detail()).To(HavePrefix("Partners profile-1 and profile-2 are both female."))
+	})
+
+	t.Run("Strict checks report errors", func(t *testing.T) {
+		RegisterTestingT(t)
+		plan := unionPlan(t, nil, nil, nil, 1950)
+
+		diags := validateChronology(plan, []*geniprofile.Profile{person("profile-1", "", 1955, 0)}, nil,
+			chronologyChecks{strict: true})
+
+		Expect(diags.Errors()).To(HaveLen(1))
+	})
+}
+
+func TestModifyPlanChecksChronology(t *testing.T) {
+	RegisterTestingT(t)
+	r, _ := fakeServerResource(t, 0)
+	var ids []string
+	for _, born := range []int32{1925, 1928, 1920} {
+		profile, err := r.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{
+			Birth: &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(born)}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		ids = append(ids, profile.ID)
+	}
+
+	plan := unionPlan(t, ids[:2], ids[2:], nil, 1950)
+	resp := modifyPlan(t, r, nil, &plan)
+
+	Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+	Expect(resp.Diagnostics.Warnings()).To(HaveLen(2))
+	for _, warning := range resp.Diagnostics.Warnings() {
+		Expect(warning.Summary()).To(Equal("Inconsistent Chronology"))
+		Expect(warning.Detail()).To(HavePrefix("Child " + ids[2] + " must not be born before its parent"))
+	}
+}
+
+// person returns a profile born and dying in the given years, zero for none.
+func person(id, gender string, born, died int32) *geniprofile.Profile {
+	profile := &geniprofile.Profile{ID: id}
+	if gender != "" {
+		profile.Gender = new(gender)
+	}
+	if born != 0 {
+		profile.Birth = &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(born)}}
+	}
+	if died != 0 {
+		profile.Death = &geniprofile.EventElement{Date: &geniprofile.DateElement{Year: new(died)}}
+	}
+	return profile
+}
+
+func eventIn(t *testing.T, year int32) types.Object {
+	t.Helper()
+	value, diags := event.ValueFrom(t.Context(), &geniprofile.EventElement{
+		Date: &geniprofile.DateElement{Year: new(year)},
+	}, types.ObjectNull(event.EventModelAttributeTypes()))
+	Expect(diags.HasError()).To(BeFalse())
+	return value
+}
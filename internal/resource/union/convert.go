@@ -2,6 +2,8 @@ package union
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -41,11 +43,11 @@ func ValueFrom(ctx context.Context, union *geniunion.Union, unionModel *Resource
 	unionModel.AdoptedChildren = setOrNull(ctx, union.AdoptedChildren, &d)
 	unionModel.Partners = setOrNull(ctx, union.Partners, &d)
 
-	marriage, diags := event.ValueFrom(ctx, union.Marriage)
+	marriage, diags := event.ValueFrom(ctx, union.Marriage, unionModel.Marriage)
 	d.Append(diags...)
 	unionModel.Marriage = marriage
 
-	divorce, diags := event.ValueFrom(ctx, union.Divorce)
+	divorce, diags := event.ValueFrom(ctx, union.Divorce, unionModel.Divorce)
 	d.Append(diags...)
 	unionModel.Divorce = divorce
 
@@ -97,9 +99,9 @@ type childModifierChange struct {
 
 // childrenWithChangedModifier returns any child ids that exist in both
 // plan and state but moved between the biological / foster / adopted
-// buckets. Geni's public API has no endpoint to change a child's
-// relationship modifier on an existing edge, so these changes cannot be
-// applied and the caller should surface a warning.
+// buckets, ordered by id. Geni's public API has no endpoint to change a
+// child's relationship modifier on an existing edge, so these changes are
+// made through the Geni website.
 func childrenWithChangedModifier(ctx context.Context, plan, state ResourceModel) []childModifierChange {
 	planLabel := labelsFor(ctx, plan)
 	stateLabel := labelsFor(ctx, state)
@@ -110,9 +112,47 @@ func childrenWithChangedModifier(ctx context.Context, plan, state ResourceModel)
 			out = append(out, childModifierChange{id: id, from: from, to: to})
 		}
 	}
+	slices.SortFunc(out, func(a, b childModifierChange) int { return strings.Compare(a.id, b.id) })
 	return out
 }
 
+// childAttributes maps a child label to the attribute listing such children.
+var childAttributes = map[string]string{
+	"biological": fieldChildren,
+	"foster":     fieldFosterChildren,
+	"adopted":    fieldAdoptedChildren,
+}
+
+// websiteModifiers maps a child label to the relationship modifier the Geni
+// website's relationship form uses for it.
+var websiteModifiers = map[string]string{
+	"biological": "bio",
+	"foster":     "foster",
+	"adopted":    "adopt",
+}
+
+// apiModifiers maps a child label to the relationship modifier the Geni API
+// adds such a child with.
+var apiModifiers = map[string]string{
+	"biological": "",
+	"foster":     "foster",
+	"adopted":    "adopt",
+}
+
+// childLabel returns how the child id is related to the live union: its
+// labelled subsets mark foster and adopted children, and every other child is
+// biological.
+func childLabel(union *geniunion.Union, id string) string {
+	switch {
+	case slices.Contains(union.FosterChildren, id):
+		return "foster"
+	case slices.Contains(union.AdoptedChildren, id):
+		return "adopted"
+	default:
+		return "biological"
+	}
+}
+
 func labelsFor(ctx context.Context, m ResourceModel) map[string]string {
 	labels := make(map[string]string)
 	add := func(set types.Set, label string) {
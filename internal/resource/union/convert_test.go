@@ -259,12 +259,12 @@ func TestUpdateComputedFields(t *testing.T) {
 			Date: &geniprofile.DateElement{
 				Year: new(int32(1990)),
 			},
-		})
+		}, types.ObjectNull(event.EventModelAttributeTypes()))
 		divorceObj, _ := event.ValueFrom(t.Context(), &geniprofile.EventElement{
 			Date: &geniprofile.DateElement{
 				Year: new(int32(2000)),
 			},
-		})
+		}, types.ObjectNull(event.EventModelAttributeTypes()))
 
 		model := &ResourceModel{
 			Marriage: marriageObj,
@@ -315,6 +315,9 @@ func TestRequestFrom(t *testing.T) {
 						"end_day":   types.Int32Null(),
 						"end_month": types.Int32Null(),
 						"end_year":  types.Int32Null(),
+						"calendar":  types.StringNull(),
+						"dual_year": types.Int32Null(),
+						"gregorian": types.StringValue("1990-06-15"),
 					}),
 				"location": types.ObjectNull(event.LocationModelAttributeTypes()),
 			})
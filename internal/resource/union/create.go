@@ -13,60 +13,104 @@ import (
 
 // Create creates the resource.
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.batchClient.Invalidate()
+
 	var plan ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// A union is built by creating temporary profiles and merging them into the
-	// real ones (Geni cannot link existing profiles directly). If a step fails
-	// after the union exists, persist it so Terraform tracks the partial union
-	// instead of stranding it and creating another on the next apply.
+	// Journal the temporary profiles, so that the next apply can merge or delete
+	// any this one fails to. The framework always hands Create private state.
+	j := &journal{}
+	state, diags := r.create(ctx, plan, j)
+	resp.Diagnostics.Append(diags...)
+
+	if state.ID.IsUnknown() || state.ID.IsNull() {
+		// No union was created, so there is no state to keep the journal in, and
+		// no later apply would learn of its orphans: delete them now.
+		resp.Diagnostics.Append(r.forget(ctx, j)...)
+		return
+	}
+
+	resp.Diagnostics.Append(j.orphanDiagnostics()...)
+	if resp.Private != nil {
+		resp.Diagnostics.Append(j.save(ctx, resp.Private, journalKey)...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	// Set data returned by API in identity
+	identity := ResourceIdentityModel{
+		ID: state.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+}
+
+// create builds the union plan describes, journaling its temporary profiles in
+// j, and returns its state.
+//
+// A union is built by creating temporary profiles and merging them into the
+// real ones (Geni cannot link existing profiles directly). If a step fails
+// after the union exists, the partial union is returned, so that the caller
+// tracks it instead of stranding it and creating another on the next apply.
+func (r *Resource) create(ctx context.Context, plan ResourceModel, j *journal) (state ResourceModel, diags diag.Diagnostics) {
 	defer func() {
-		if resp.Diagnostics.HasError() {
-			persistPartialUnion(ctx, resp, plan)
+		if diags.HasError() {
+			state = partialUnion(plan)
 		}
 	}()
 
-	partnerIds, diags := tfset.Strings(ctx, plan.Partners)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	partnerIds, d := tfset.Strings(ctx, plan.Partners)
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	// A planned id is a union that already exists, such as one ModifyPlan adopted
+	// because the partners already share it; re-adding them would make another.
+	partnersJoined := false
+	if len(plan.Partners.Elements()) == 2 && !plan.ID.IsUnknown() && !plan.ID.IsNull() {
+		_, livePartners, _, d := r.currentUnionMembers(ctx, plan.ID.ValueString(), plan.Partners)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
+		}
+		partnersJoined = len(missingEdges(partnerIds, livePartners)) == 0
 	}
 
 	// If there are two partners, we can create a union by calling the profile/add-partner API
-	if len(plan.Partners.Elements()) == 2 {
+	if len(plan.Partners.Elements()) == 2 && !partnersJoined {
 		// It is impossible to create a union from two existing profiles using the API,
 		// so we create a temporary partner profile and merge it with the existing
 		// second partner profile.
-		tmpProfile, err := r.addAndMerge(ctx, partnerIds[1], func(ctx context.Context) (*geniprofile.Profile, error) {
+		tmpProfile, err := r.addAndMerge(ctx, j, partnerIds[1], func(ctx context.Context) (*geniprofile.Profile, error) {
 			return r.client.Profile().AddPartner(ctx, partnerIds[0])
 		})
 		plan.ID = unionIDFrom(plan.ID, tmpProfile)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root(fieldPartners), "Error adding partner", err.Error())
-			return
+			diags.AddAttributeError(path.Root(fieldPartners), "Error adding partner", err.Error())
+			return plan, diags
 		}
 	}
 
 	// Set the children. If the union already exists and has children, we can set
 	// them by calling the union/add-child API. If not, we can use profile/add-child
 	// on a parent profile.
-	childrenIds, diags := tfset.Strings(ctx, plan.Children)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	childrenIds, d := tfset.Strings(ctx, plan.Children)
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
 	}
-	fosterIds, diags := tfset.Strings(ctx, plan.FosterChildren)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	fosterIds, d := tfset.Strings(ctx, plan.FosterChildren)
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
 	}
-	adoptedIds, diags := tfset.Strings(ctx, plan.AdoptedChildren)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	adoptedIds, d := tfset.Strings(ctx, plan.AdoptedChildren)
+	diags.Append(d...)
+	if diags.HasError() {
+		return plan, diags
 	}
 
 	allChildrenIds := make([]string, 0, len(childrenIds)+len(fosterIds)+len(adoptedIds))
@@ -105,11 +149,11 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 			case !plan.ID.IsUnknown() && !plan.ID.IsNull():
 				// The union already exists — add the child to it.
 				if !membersFetched {
-					var diags diag.Diagnostics
-					liveResolvedID, _, liveChildren, diags = r.currentUnionMembers(ctx, plan.ID.ValueString(), plan.Partners)
-					resp.Diagnostics.Append(diags...)
-					if resp.Diagnostics.HasError() {
-						return
+					var d diag.Diagnostics
+					liveResolvedID, _, liveChildren, d = r.currentUnionMembers(ctx, plan.ID.ValueString(), plan.Partners)
+					diags.Append(d...)
+					if diags.HasError() {
+						return plan, diags
 					}
 					membersFetched = true
 				}
@@ -117,17 +161,17 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 					// Already a child of the live union — nothing to add.
 					continue
 				}
-				tmpProfile, err = r.addAndMerge(ctx, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
+				tmpProfile, err = r.addAndMerge(ctx, j, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
 					return r.client.Union().AddChild(ctx, liveResolvedID, geniprofile.WithModifier(modifier))
 				})
 			case len(partnerIds) > 0:
 				// When one parent is known, add the child to the parent.
-				tmpProfile, err = r.addAndMerge(ctx, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
+				tmpProfile, err = r.addAndMerge(ctx, j, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
 					return r.client.Profile().AddChild(ctx, partnerIds[0], geniprofile.WithModifier(modifier))
 				})
 			case len(allChildrenIds) > 1:
 				// With no partners, add the child as a sibling of the next child.
-				tmpProfile, err = r.addAndMerge(ctx, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
+				tmpProfile, err = r.addAndMerge(ctx, j, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
 					return r.client.Profile().AddSibling(ctx, allChildrenIds[i+1], geniprofile.WithModifier(modifier))
 				})
 				// Skip the next iteration because we already added that child.
@@ -140,37 +184,31 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 			plan.ID = unionIDFrom(plan.ID, tmpProfile)
 			if err != nil {
-				resp.Diagnostics.AddAttributeError(path.Root(fieldChildren), "Error adding child with ID="+childId, err.Error())
-				return
+				diags.AddAttributeError(path.Root(fieldChildren), "Error adding child with ID="+childId, err.Error())
+				return plan, diags
 			}
 		}
 	}
 
 	if !plan.Marriage.IsUnknown() && !plan.Marriage.IsNull() {
-		unionRequest, diags := RequestFrom(ctx, plan)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		unionRequest, d := RequestFrom(ctx, plan)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 
 		unionResponse, err := r.client.Union().Update(ctx, plan.ID.ValueString(), unionRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating union", err.Error())
-			return
+			diags.AddError("Error updating union", err.Error())
+			return plan, diags
 		}
 
-		diags = UpdateComputedFields(ctx, unionResponse, &plan)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		d = UpdateComputedFields(ctx, unionResponse, &plan)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
-
-	// Set data returned by API in identity
-	identity := ResourceIdentityModel{
-		ID: plan.ID,
-	}
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+	return plan, diags
 }
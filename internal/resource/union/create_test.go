@@ -0,0 +1,78 @@
+package union
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+)
+
+func TestCreateFailingMidway(t *testing.T) {
+	t.Run("A temporary profile left behind before the union is known is deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		// Geni answers the partner add without the new union, refuses the merge,
+		// and refuses the first attempt to delete the temporary profile, so
+		// create returns with an orphan and no union id.
+		flaky := &flakyTransport{failures: map[string]int{"/merge/": 1, "/delete": 1}}
+		clients, _ := genitest.FakeServer(t, 2, flaky)
+		r := &Resource{client: clients.Client, batchClient: clients.BatchClient}
+
+		_, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, nil, nil, 1990))
+
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags.Errors()[0].Summary()).To(Equal("Error adding partner"))
+		Expect(diags.Warnings()).To(BeEmpty())
+		Expect(flaky.tempProfile).ToNot(BeEmpty())
+		tmp, err := r.client.Profile().Get(t.Context(), flaky.tempProfile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tmp.Deleted).To(BeTrue())
+	})
+}
+
+// flakyTransport passes requests on to the fake Geni server, failing the
+// given number of requests whose path contains each key. It drops the unions
+// from the profile an add-partner request answers with, and keeps its id.
+type flakyTransport struct {
+	failures    map[string]int
+	tempProfile string
+}
+
+func (f *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for fragment, left := range f.failures {
+		if left > 0 && strings.Contains(req.URL.Path, fragment) {
+			f.failures[fragment] = left - 1
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Internal error"}}`)),
+				Request:    req,
+			}, nil
+		}
+	}
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil || !strings.HasSuffix(req.URL.Path, "/add-partner") {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	var profile map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, err
+	}
+	delete(profile, "unions")
+	f.tempProfile, _ = profile["id"].(string)
+	body, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
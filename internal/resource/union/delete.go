@@ -8,13 +8,22 @@ import (
 
 // Delete deletes the resource.
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.batchClient.Invalidate()
+
 	var state ResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// We can't delete a union, so we just remove the resource from the state
+	// We can't delete a union, so we just remove the resource from the state,
+	// along with any temporary profiles failed applies left in it.
+	j, diags := loadJournal(ctx, req.Private, journalKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.forget(ctx, j)...)
 
 	resp.State.RemoveResource(ctx)
 }
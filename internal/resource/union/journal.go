@@ -0,0 +1,191 @@
+package union
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/dmalch/go-geni"
+	geniunion "github.com/dmalch/go-geni/union"
+)
+
+// journalKey is the private state key the journal is kept under.
+const journalKey = "temp_profiles"
+
+const (
+	// stepCreated marks a temporary profile that is neither merged nor
+	// deleted yet: an orphan, once the apply that created it is over.
+	stepCreated = "created"
+	stepMerged  = "merged"
+	stepDeleted = "deleted"
+)
+
+// journal records every temporary profile Create and Update build union edges
+// with (see addAndMerge), and what became of it. It is kept in private state,
+// so a temporary profile left behind by a failed apply is merged into its
+// profile when a later apply adds that edge again, or deleted when none does.
+type journal struct {
+	Steps []journalStep `json:"steps,omitempty"`
+}
+
+// journalStep is one temporary profile.
+type journalStep struct {
+	// TempProfile is the id of the temporary profile.
+	TempProfile string `json:"temp_profile"`
+	// Into is the id of the profile it is merged into.
+	Into string `json:"into"`
+	// Status is stepCreated, stepMerged or stepDeleted.
+	Status string `json:"status"`
+}
+
+// PrivateData is a resource instance's private state, which the framework
+// hands to Create, Read, Update and Delete.
+type PrivateData interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// loadJournal returns the orphans listed by the journal kept in private under
+// key. The steps that were settled belong to earlier applies and are dropped.
+func loadJournal(ctx context.Context, private PrivateData, key string) (*journal, diag.Diagnostics) {
+	j := &journal{}
+	if private == nil {
+		return j, nil
+	}
+	data, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(data) == 0 {
+		return j, diags
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		diags.AddWarning("Error reading the temporary profile journal",
+			"The journal in private state is ignored: "+err.Error())
+		return &journal{}, diags
+	}
+	j.Steps = j.orphans()
+	return j, diags
+}
+
+// save writes the journal to private under key, or removes it when it is
+// empty.
+func (j *journal) save(ctx context.Context, private PrivateData, key string) diag.Diagnostics {
+	if private == nil {
+		return nil
+	}
+	if len(j.Steps) == 0 {
+		return private.SetKey(ctx, key, nil)
+	}
+	data, err := json.Marshal(j)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error writing the temporary profile journal", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, key, data)
+}
+
+// record adds a temporary profile created to be merged into into, and returns
+// its step.
+func (j *journal) record(tempProfile, into string) *journalStep {
+	j.Steps = append(j.Steps, journalStep{TempProfile: tempProfile, Into: into, Status: stepCreated})
+	return &j.Steps[len(j.Steps)-1]
+}
+
+// orphanFor returns the orphan meant to be merged into into, if any.
+func (j *journal) orphanFor(into string) (*journalStep, bool) {
+	for i := range j.Steps {
+		if j.Steps[i].Status == stepCreated && j.Steps[i].Into == into {
+			return &j.Steps[i], true
+		}
+	}
+	return nil, false
+}
+
+// orphans returns the temporary profiles that are neither merged nor deleted.
+func (j *journal) orphans() []journalStep {
+	var orphans []journalStep
+	for _, step := range j.Steps {
+		if step.Status == stepCreated {
+			orphans = append(orphans, step)
+		}
+	}
+	return orphans
+}
+
+// hideOrphans returns union without the journal's orphans among its members,
+// so Read does not report them as drift. Nobody configures them, and a later
+// apply merges or deletes them.
+func (j *journal) hideOrphans(union *geniunion.Union) *geniunion.Union {
+	orphans := j.orphans()
+	if len(orphans) == 0 {
+		return union
+	}
+	isOrphan := func(id string) bool {
+		return slices.ContainsFunc(orphans, func(step journalStep) bool { return step.TempProfile == id })
+	}
+	hidden := *union
+	hidden.Partners = slices.DeleteFunc(slices.Clone(union.Partners), isOrphan)
+	hidden.Children = slices.DeleteFunc(slices.Clone(union.Children), isOrphan)
+	hidden.FosterChildren = slices.DeleteFunc(slices.Clone(union.FosterChildren), isOrphan)
+	hidden.AdoptedChildren = slices.DeleteFunc(slices.Clone(union.AdoptedChildren), isOrphan)
+	return &hidden
+}
+
+// deleteOrphans rolls back the journal's orphans by deleting them. An orphan
+// Geni no longer has counts as deleted; one that cannot be deleted stays in
+// the journal for the next apply.
+func (r *Resource) deleteOrphans(ctx context.Context, j *journal) {
+	for i := range j.Steps {
+		step := &j.Steps[i]
+		if step.Status != stepCreated {
+			continue
+		}
+		if err := r.client.Profile().Delete(ctx, step.TempProfile); err != nil && !errors.Is(err, geni.ErrResourceNotFound) {
+			tflog.Error(ctx, "failed to delete orphan temporary profile",
+				map[string]any{"profile_id": step.TempProfile, "error": err})
+			r.markOrphan(ctx, step.TempProfile)
+			continue
+		}
+		step.Status = stepDeleted
+	}
+}
+
+// forget deletes the journal's orphans, for a union Terraform stops managing,
+// and warns about those that are left on Geni.
+func (r *Resource) forget(ctx context.Context, j *journal) diag.Diagnostics {
+	var diags diag.Diagnostics
+	r.deleteOrphans(ctx, j)
+	if names := j.orphanNames(); names != "" {
+		diags.AddWarning("Orphaned temporary profiles",
+			"The provider could not delete the temporary profiles "+names+". Delete them on geni.com.")
+	}
+	return diags
+}
+
+// orphanDiagnostics warns about the journal's orphans, which the next apply
+// merges or deletes.
+func (j *journal) orphanDiagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+	if names := j.orphanNames(); names != "" {
+		diags.AddWarning("Orphaned temporary profiles",
+			"The provider could not merge or delete the temporary profiles "+names+". The next apply merges "+
+				"each into its profile if that profile is still configured, and deletes it otherwise; they can "+
+				"also be deleted on geni.com.")
+	}
+	return diags
+}
+
+// orphanNames lists the journal's orphans, each with the profile it was meant
+// to be merged into.
+func (j *journal) orphanNames() string {
+	orphans := j.orphans()
+	names := make([]string, 0, len(orphans))
+	for _, step := range orphans {
+		names = append(names, step.TempProfile+" (for "+step.Into+")")
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,225 @@
+package union
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/onsi/gomega"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+)
+
+func TestJournal(t *testing.T) {
+	t.Run("Only orphans survive a save and load", func(t *testing.T) {
+		RegisterTestingT(t)
+		private := fakePrivate{}
+		j := &journal{}
+		j.record("profile-8", "profile-1").Status = stepMerged
+		j.record("profile-9", "profile-2")
+		j.record("profile-10", "profile-3").Status = stepDeleted
+
+		Expect(j.save(t.Context(), private, journalKey)).To(BeEmpty())
+		loaded, diags := loadJournal(t.Context(), private, journalKey)
+
+		Expect(diags).To(BeEmpty())
+		Expect(loaded.Steps).To(Equal([]journalStep{{TempProfile: "profile-9", Into: "profile-2", Status: stepCreated}}))
+	})
+
+	t.Run("An empty journal is removed from private state", func(t *testing.T) {
+		RegisterTestingT(t)
+		private := fakePrivate{journalKey: []byte(`{"steps":[]}`)}
+
+		Expect((&journal{}).save(t.Context(), private, journalKey)).To(BeEmpty())
+
+		Expect(private).ToNot(HaveKey(journalKey))
+	})
+
+	t.Run("An unreadable journal is ignored with a warning", func(t *testing.T) {
+		RegisterTestingT(t)
+		loaded, diags := loadJournal(t.Context(), fakePrivate{journalKey: []byte(`[]`)}, journalKey)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(diags.Warnings()).To(HaveLen(1))
+		Expect(loaded.Steps).To(BeEmpty())
+	})
+
+	t.Run("Orphans are hidden from the union and named in a warning", func(t *testing.T) {
+		RegisterTestingT(t)
+		j := &journal{}
+		j.record("profile-9", "profile-3")
+		union := &geniunion.Union{Partners: []string{"profile-1", "profile-2"}, Children: []string{"profile-9", "profile-4"}}
+
+		hidden := j.hideOrphans(union)
+
+		Expect(hidden.Children).To(Equal([]string{"profile-4"}))
+		Expect(union.Children).To(Equal([]string{"profile-9", "profile-4"}))
+		diags := j.orphanDiagnostics()
+		Expect(diags.Warnings()).To(HaveLen(1))
+		Expect(diags.Warnings()[0].Detail()).To(ContainSubstring("profile-9 (for profile-3)"))
+	})
+
+	t.Run("A moved journal is kept under its new key only", func(t *testing.T) {
+		RegisterTestingT(t)
+		private := fakePrivate{}
+		j := &journal{}
+		j.record("profile-9", "profile-3")
+		Expect(j.save(t.Context(), private, "temp_profiles:profile-2")).To(BeEmpty())
+
+		Expect(MoveJournal(t.Context(), private, "temp_profiles:profile-2", "temp_profiles:profile-7")).To(BeEmpty())
+
+		Expect(private).ToNot(HaveKey("temp_profiles:profile-2"))
+		moved, diags := loadJournal(t.Context(), private, "temp_profiles:profile-7")
+		Expect(diags).To(BeEmpty())
+		Expect(moved.Steps).To(Equal([]journalStep{{TempProfile: "profile-9", Into: "profile-3", Status: stepCreated}}))
+	})
+
+	t.Run("A journal moved onto another keeps the orphans of both", func(t *testing.T) {
+		RegisterTestingT(t)
+		private := fakePrivate{}
+		from := &journal{}
+		from.record("profile-9", "profile-3")
+		Expect(from.save(t.Context(), private, "temp_profiles:profile-2")).To(BeEmpty())
+		to := &journal{}
+		to.record("profile-10", "profile-4")
+		Expect(to.save(t.Context(), private, "temp_profiles:profile-7")).To(BeEmpty())
+
+		Expect(MoveJournal(t.Context(), private, "temp_profiles:profile-2", "temp_profiles:profile-7")).To(BeEmpty())
+
+		moved, diags := loadJournal(t.Context(), private, "temp_profiles:profile-7")
+		Expect(diags).To(BeEmpty())
+		Expect(moved.Steps).To(ConsistOf(
+			journalStep{TempProfile: "profile-9", Into: "profile-3", Status: stepCreated},
+			journalStep{TempProfile: "profile-10", Into: "profile-4", Status: stepCreated},
+		))
+	})
+}
+
+func TestAddAndMergeJournal(t *testing.T) {
+	t.Run("A merged temporary profile is journaled as merged", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 2)
+		j := &journal{}
+
+		tmp, err := r.addAndMerge(t.Context(), j, "profile-2", func(ctx context.Context) (*geniprofile.Profile, error) {
+			return r.client.Profile().AddPartner(ctx, "profile-1")
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(j.Steps).To(Equal([]journalStep{{TempProfile: tmp.ID, Into: "profile-2", Status: stepMerged}}))
+	})
+
+	t.Run("A temporary profile deleted after a failed merge is journaled as deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 1)
+		j := &journal{}
+
+		_, err := r.addAndMerge(t.Context(), j, "profile-99", func(ctx context.Context) (*geniprofile.Profile, error) {
+			return r.client.Profile().AddPartner(ctx, "profile-1")
+		})
+
+		Expect(err).To(HaveOccurred())
+		Expect(j.Steps).To(HaveLen(1))
+		Expect(j.Steps[0].Status).To(Equal(stepDeleted))
+		Expect(j.orphanDiagnostics()).To(BeEmpty())
+	})
+
+	t.Run("A profile with no en-US name does not take the temporary profile's name when it is merged in", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 1)
+		survivor, err := r.client.Profile().Create(t.Context(), &geniclient.ProfileRequest{Request: geniprofile.Request{
+			Names: map[string]geniprofile.NameElement{"ru": {FirstName: new("Анна")}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = r.addAndMerge(t.Context(), &journal{}, survivor.ID, func(ctx context.Context) (*geniprofile.Profile, error) {
+			return r.client.Profile().AddPartner(ctx, "profile-1")
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		merged, err := r.client.Profile().Get(t.Context(), survivor.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(merged.Names).To(HaveKey("ru"))
+		Expect(merged.Names).ToNot(HaveKey("en-US"))
+		Expect(IsTempProfile(&merged.Profile)).To(BeFalse())
+	})
+
+	t.Run("Only a live orphan is named", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 2)
+		orphan, err := r.client.Profile().AddPartner(t.Context(), "profile-1")
+		Expect(err).ToNot(HaveOccurred())
+		mergedAway, err := r.client.Profile().AddPartner(t.Context(), "profile-1")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = r.client.Profile().Merge(t.Context(), "profile-2", mergedAway.ID)
+		Expect(err).ToNot(HaveOccurred())
+
+		r.markOrphan(t.Context(), orphan.ID)
+		r.markOrphan(t.Context(), mergedAway.ID)
+
+		named, err := r.client.Profile().Get(t.Context(), orphan.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsTempProfile(&named.Profile)).To(BeTrue())
+		survivor, err := r.client.Profile().Get(t.Context(), "profile-2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsTempProfile(&survivor.Profile)).To(BeFalse())
+	})
+
+	t.Run("An orphan left by an earlier apply is merged instead of creating another", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 4)
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		orphan, err := r.client.Union().AddChild(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		j := &journal{}
+		j.record(orphan.ID, "profile-4")
+
+		_, err = r.addAndMerge(t.Context(), j, "profile-4", func(context.Context) (*geniprofile.Profile, error) {
+			return nil, errors.New("no temporary profile should be created")
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(j.Steps).To(Equal([]journalStep{{TempProfile: orphan.ID, Into: "profile-4", Status: stepMerged}}))
+		union, err := r.client.Union().Get(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Children).To(ConsistOf("profile-3", "profile-4"))
+	})
+
+	t.Run("An orphan no apply needs is deleted", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		orphan, err := r.client.Union().AddChild(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		j := &journal{}
+		j.record(orphan.ID, "profile-4")
+
+		r.deleteOrphans(t.Context(), j)
+
+		Expect(j.orphans()).To(BeEmpty())
+		union, err := r.client.Union().Get(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Children).To(ConsistOf("profile-3"))
+	})
+}
+
+// fakePrivate stands in for the private state the framework hands a resource.
+type fakePrivate map[string][]byte
+
+func (p fakePrivate) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivate) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+	} else {
+		p[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package union
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// The methods below manage a union the way geni_union does, for resources that
+// are built from unions, such as geni_family. They work on a ResourceModel
+// instead of the framework's plan and state, and keep the union's temporary
+// profile journal in private under key, so that one resource can keep the
+// journals of several unions. A nil private keeps no journal across calls.
+// Diagnostics have paths relative to the union's attributes.
+
+// PlanUnion does for plan what ModifyPlan does: its events' locations are
+// filled in from the gazetteer, a new union (nil state) adopts the union its
+// partners already share, and an existing one fails the plan on a change only
+// the Geni website can make when no session is configured. Both then have
+// their chronology checked.
+func (r *Resource) PlanUnion(ctx context.Context, plan *ResourceModel, state *ResourceModel) diag.Diagnostics {
+	if r.client == nil {
+		return nil
+	}
+	diags := r.PlanPlaces(ctx, plan)
+	if diags.HasError() {
+		return diags
+	}
+	if state == nil {
+		diags.Append(r.planAdoption(ctx, plan)...)
+	} else {
+		diags.Append(r.checkWebsiteEdits(ctx, *plan, *state)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(r.checkChronology(ctx, *plan)...)
+	return diags
+}
+
+// CreateUnion builds the union plan describes and returns its state. As with
+// Create, the returned state has the id of a union that was only partly built,
+// and an unknown id when none was.
+func (r *Resource) CreateUnion(ctx context.Context, plan ResourceModel, private PrivateData, key string) (ResourceModel, diag.Diagnostics) {
+	defer r.batchClient.Invalidate()
+
+	j, diags := loadJournal(ctx, private, key)
+	if diags.HasError() {
+		return plan, diags
+	}
+	state, d := r.create(ctx, plan, j)
+	diags.Append(d...)
+	diags.Append(j.orphanDiagnostics()...)
+	diags.Append(j.save(ctx, private, key)...)
+	return state, diags
+}
+
+// ReadUnion refreshes state from Geni and reports whether the union still
+// exists.
+func (r *Resource) ReadUnion(ctx context.Context, state ResourceModel, private PrivateData, key string) (ResourceModel, bool, diag.Diagnostics) {
+	j, diags := loadJournal(ctx, private, key)
+	if diags.HasError() {
+		return state, false, diags
+	}
+	state, found, d := r.read(ctx, state, j)
+	diags.Append(d...)
+	return state, found, diags
+}
+
+// UpdateUnion changes the union from state to plan and returns its new state.
+func (r *Resource) UpdateUnion(ctx context.Context, plan, state ResourceModel, private PrivateData, key string) (ResourceModel, diag.Diagnostics) {
+	defer r.batchClient.Invalidate()
+
+	j, diags := loadJournal(ctx, private, key)
+	if diags.HasError() {
+		return plan, diags
+	}
+	plan, d := r.update(ctx, plan, state, j)
+	diags.Append(d...)
+	diags.Append(j.orphanDiagnostics()...)
+	diags.Append(j.save(ctx, private, key)...)
+	return plan, diags
+}
+
+// ForgetUnion stops managing a union. Geni cannot delete a union, so like
+// Delete it only deletes the temporary profiles failed applies left in it.
+func (r *Resource) ForgetUnion(ctx context.Context, private PrivateData, key string) diag.Diagnostics {
+	defer r.batchClient.Invalidate()
+
+	j, diags := loadJournal(ctx, private, key)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(r.forget(ctx, j)...)
+	diags.Append(j.save(ctx, private, key)...)
+	return diags
+}
+
+// MoveJournal moves the temporary profile journal kept in private under from
+// to to, for a resource that keys a union's journal by something a refresh
+// can change, such as a partner Geni merged into another profile. Orphans
+// already journaled under to are kept.
+func MoveJournal(ctx context.Context, private PrivateData, from, to string) diag.Diagnostics {
+	if from == to {
+		return nil
+	}
+	j, diags := loadJournal(ctx, private, from)
+	if diags.HasError() || len(j.Steps) == 0 {
+		return diags
+	}
+	moved, d := loadJournal(ctx, private, to)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	moved.Steps = append(moved.Steps, j.Steps...)
+	diags.Append(moved.save(ctx, private, to)...)
+	diags.Append((&journal{}).save(ctx, private, from)...)
+	return diags
+}
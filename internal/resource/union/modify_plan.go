@@ -0,0 +1,138 @@
+package union
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+// ModifyPlan fills in the locations of the union's events from the gazetteer
+// (see PlanPlaces) and adopts the union a new union's partners already share
+// (see planAdoption). On update, it fails a plan that removes partners or children,
+// or moves a child between children, foster_children and adopted_children,
+// when the provider has no Geni website session to make the change with,
+// instead of letting apply report a change that never happened. Either way it
+// then checks the chronology of the union's members and events (see
+// checkChronology).
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is planned on destroy. Before the provider is configured Geni
+	// cannot be asked, nor is it known whether a session will be.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.PlanPlaces(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("marriage"), plan.Marriage)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("divorce"), plan.Divorce)...)
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.planAdoption(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.ID.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+		}
+	} else {
+		var state ResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.checkWebsiteEdits(ctx, plan, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkChronology(ctx, plan)...)
+}
+
+// PlanPlaces fills in the locations of plan's marriage and divorce that
+// reference a place in the gazetteer (see event.PlanPlace).
+func (r *Resource) PlanPlaces(ctx context.Context, plan *ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// Before the provider is configured, it is not known whether it will have
+	// a gazetteer.
+	if r.client == nil {
+		return diags
+	}
+
+	marriage, d := event.PlanEventPlace(ctx, plan.Marriage, r.places, path.Root("marriage"))
+	diags.Append(d...)
+	plan.Marriage = marriage
+
+	divorce, d := event.PlanEventPlace(ctx, plan.Divorce, r.places, path.Root("divorce"))
+	diags.Append(d...)
+	plan.Divorce = divorce
+
+	return diags
+}
+
+// checkWebsiteEdits fails a plan that changes the union from state in a way
+// only the Geni website can, when no website session is configured, and warns
+// about the moves between child sets the Geni API is left to try.
+func (r *Resource) checkWebsiteEdits(ctx context.Context, plan, state ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if r.web != nil {
+		return diags
+	}
+
+	// Members that are not known until apply are checked then.
+	for _, set := range []types.Set{plan.Partners, plan.Children, plan.FosterChildren, plan.AdoptedChildren} {
+		if set.IsUnknown() {
+			return diags
+		}
+	}
+
+	removed, d := removedMembers(ctx, plan, state)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	byAttribute := make(map[string][]string)
+	for _, m := range removed {
+		byAttribute[m.attribute] = append(byAttribute[m.attribute], m.id)
+	}
+	for _, attribute := range []string{fieldPartners, fieldChildren, fieldFosterChildren, fieldAdoptedChildren} {
+		ids := byAttribute[attribute]
+		if len(ids) == 0 {
+			continue
+		}
+		summary := "Cannot remove children"
+		if attribute == fieldPartners {
+			summary = "Cannot remove partners"
+		}
+		diags.AddAttributeError(path.Root(attribute), summary,
+			fmt.Sprintf("%s cannot be removed from %s: the Geni API has no endpoint to remove a profile from a union. "+
+				"Set web_session in the provider configuration to remove them through the Geni website, "+
+				"or keep them in %s.", strings.Join(ids, ", "), state.ID.ValueString(), attribute))
+	}
+
+	for _, m := range childrenWithChangedModifier(ctx, plan, state) {
+		from, to := childAttributes[m.from], childAttributes[m.to]
+		diags.AddAttributeWarning(path.Root(to), "Changing relationship modifier through the Geni API",
+			fmt.Sprintf("%s is moved from %s to %s of %s by adding it to the union again through the Geni API, "+
+				"which has no endpoint to change a child's relationship to a union. Geni may keep the relationship "+
+				"%s has, which fails the apply. Set web_session in the provider configuration to change it through "+
+				"the Geni website instead.", m.id, from, to, state.ID.ValueString(), m.id))
+	}
+	return diags
+}
@@ -0,0 +1,148 @@
+package union
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
+	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
+)
+
+func TestModifyPlan(t *testing.T) {
+	RegisterTestingT(t)
+	state := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990)
+	state.ID = types.StringValue("union-1")
+	dropped := unionPlan(t, []string{"profile-1"}, nil, nil, 1990)
+	dropped.ID = state.ID
+
+	t.Run("Without a website session, removing members fails the plan", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}}
+
+		resp := modifyPlan(t, r, &state, &dropped)
+
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		Expect(resp.Diagnostics.Errors()).To(HaveLen(2))
+		partners := resp.Diagnostics.Errors()[0]
+		Expect(partners.Summary()).To(Equal("Cannot remove partners"))
+		Expect(partners.Detail()).To(HavePrefix("profile-2 cannot be removed from union-1"))
+		Expect(partners.Detail()).To(ContainSubstring("web_session"))
+		children := resp.Diagnostics.Errors()[1]
+		Expect(children.Summary()).To(Equal("Cannot remove children"))
+		Expect(children.Detail()).To(HavePrefix("profile-3 cannot be removed from union-1"))
+	})
+
+	t.Run("Without a website session, moving a child between child sets warns that the API re-adds it", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}}
+		moved := unionPlan(t, []string{"profile-1", "profile-2"}, nil, []string{"profile-3"}, 1990)
+		moved.ID = state.ID
+
+		resp := modifyPlan(t, r, &state, &moved)
+
+		Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+		Expect(resp.Diagnostics.Warnings()).To(HaveLen(1))
+		diagnostic := resp.Diagnostics.Warnings()[0]
+		Expect(diagnostic.Summary()).To(Equal("Changing relationship modifier through the Geni API"))
+		Expect(diagnostic.Detail()).To(HavePrefix("profile-3 is moved from children to adopted_children of union-1"))
+	})
+
+	t.Run("With a website session, removing members plans", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}, web: &fakeWeb{}}
+
+		resp := modifyPlan(t, r, &state, &dropped)
+
+		Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+	})
+
+	t.Run("Members not known until apply are not checked", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}}
+		unknown := dropped
+		unknown.Partners = types.SetUnknown(types.StringType)
+
+		resp := modifyPlan(t, r, &state, &unknown)
+
+		Expect(resp.Diagnostics.HasError()).To(BeFalse())
+	})
+
+	t.Run("The marriage's place is filled in from the gazetteer", func(t *testing.T) {
+		RegisterTestingT(t)
+		places, err := gazetteer.Read(strings.NewReader("id\tcity\tcountry\n524901\tMoscow\tRussia\n"))
+		Expect(err).ToNot(HaveOccurred())
+		r := &Resource{client: &geniclient.Client{}, places: places}
+		placed := state
+		placed.Marriage = placedMarriage(t, "524901")
+
+		resp := modifyPlan(t, r, &state, &placed)
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+		var city, country types.String
+		Expect(resp.Plan.GetAttribute(t.Context(), path.Root("marriage").AtName("location").AtName("city"), &city)).To(BeEmpty())
+		Expect(resp.Plan.GetAttribute(t.Context(), path.Root("marriage").AtName("location").AtName("country"), &country)).To(BeEmpty())
+		Expect(city).To(Equal(types.StringValue("Moscow")))
+		Expect(country).To(Equal(types.StringValue("Russia")))
+	})
+
+	t.Run("Creating and destroying a union remove nothing", func(t *testing.T) {
+		RegisterTestingT(t)
+		r := &Resource{client: &geniclient.Client{}}
+
+		Expect(modifyPlan(t, r, nil, &dropped).Diagnostics.HasError()).To(BeFalse())
+		Expect(modifyPlan(t, r, &state, nil).Diagnostics.HasError()).To(BeFalse())
+	})
+}
+
+// modifyPlan drives r.ModifyPlan from state to plan, either of which may be
+// nil for a create or a destroy.
+func modifyPlan(t *testing.T, r *Resource, state, plan *ResourceModel) *resource.ModifyPlanResponse {
+	t.Helper()
+	unionSchema, _ := unionSchemas(t, r)
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: unionSchema, Raw: nullValue(t, unionSchema)},
+		State: tfsdk.State{Schema: unionSchema, Raw: nullValue(t, unionSchema)},
+	}
+	if plan != nil {
+		Expect(req.Plan.Set(t.Context(), plan).HasError()).To(BeFalse())
+	}
+	if state != nil {
+		Expect(req.State.Set(t.Context(), state).HasError()).To(BeFalse())
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(t.Context(), req, resp)
+	return resp
+}
+
+// placedMarriage returns a marriage in 1990 at the gazetteer place placeID,
+// with its location planned as placeComponentModifier and
+// placeCoordinateModifier leave it.
+func placedMarriage(t *testing.T, placeID string) types.Object {
+	t.Helper()
+	location := map[string]attr.Value{
+		"place_id":        types.StringValue(placeID),
+		"latitude":        types.Float64Unknown(),
+		"longitude":       types.Float64Unknown(),
+		"street_address1": types.StringNull(),
+		"street_address2": types.StringNull(),
+		"street_address3": types.StringNull(),
+		"historical":      types.ObjectNull(event.HistoricalModelAttributeTypes()),
+	}
+	for _, name := range gazetteer.Components {
+		location[name] = types.StringUnknown()
+	}
+
+	marriage := unionPlan(t, nil, nil, nil, 1990).Marriage.Attributes()
+	marriage["location"] = types.ObjectValueMust(event.LocationModelAttributeTypes(), location)
+	return types.ObjectValueMust(event.EventModelAttributeTypes(), marriage)
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/dmalch/go-geni"
-	geniprofile "github.com/dmalch/go-geni/profile"
 	geniunion "github.com/dmalch/go-geni/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
 )
 
@@ -34,52 +34,71 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		}
 	}
 
+	// Temporary profiles that a failed apply left in the union are not drift.
+	j, diags := loadJournal(ctx, req.Private, journalKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, found, diags := r.read(ctx, state, j)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	// Set data returned by API in identity
+	identityData.ID = state.ID
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identityData)...)
+}
+
+// read refreshes state from Geni, hiding the orphans in j, and reports whether
+// the union still exists. A union Geni auto-merged into another is followed to
+// the surviving one.
+func (r *Resource) read(ctx context.Context, state ResourceModel, j *journal) (ResourceModel, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	unionResponse, err := r.batchClient.GetUnion(ctx, state.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, geni.ErrResourceNotFound) {
-			resp.Diagnostics.AddWarning("Union not found", "The union was not found in the Geni API. Removing from state.")
-			resp.State.RemoveResource(ctx)
-			return
+			diags.AddWarning("Union not found", "The union was not found in the Geni API. Removing from state.")
+			return state, false, diags
 		}
 
-		resp.Diagnostics.AddError("Error reading union", err.Error())
-		return
+		diags.AddError("Error reading union", err.Error())
+		return state, false, diags
 	}
 
 	// If the union doesn't have any partners and children, remove the resource from
 	// the state
 	if len(unionResponse.Partners) == 0 && len(unionResponse.Children) == 0 {
-		existingUnionId, diags := r.findExistingUnionForPartners(ctx, state.Partners)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		existingUnionId, d := r.findExistingUnionForPartners(ctx, state.Partners)
+		diags.Append(d...)
+		if diags.HasError() {
+			return state, false, diags
 		}
 
 		if existingUnionId != "" {
-			resp.Diagnostics.AddWarning("Found existing union", "The union in the state has no partners and children. Found an existing union with ID "+existingUnionId+".")
+			diags.AddWarning("Found existing union", "The union in the state has no partners and children. Found an existing union with ID "+existingUnionId+".")
 			unionResponse, err = r.client.Union().Get(ctx, existingUnionId)
 			if err != nil {
-				resp.Diagnostics.AddError("Error reading union", err.Error())
-				return
+				diags.AddError("Error reading union", err.Error())
+				return state, false, diags
 			}
 		} else {
-			resp.Diagnostics.AddWarning("Union has no partners and children", "The union has no partners and children. Removing from state.")
-			resp.State.RemoveResource(ctx)
-			return
+			diags.AddWarning("Union has no partners and children", "The union has no partners and children. Removing from state.")
+			return state, false, diags
 		}
 	}
 
-	diags := ValueFrom(ctx, unionResponse, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
-
-	// Set data returned by API in identity
-	identityData.ID = types.StringValue(unionResponse.ID)
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, identityData)...)
+	diags.Append(ValueFrom(ctx, j.hideOrphans(unionResponse), &state)...)
+	return state, true, diags
 }
 
 func (r *Resource) findExistingUnionForPartners(ctx context.Context, partners types.Set) (string, diag.Diagnostics) {
@@ -181,7 +200,7 @@ func (r *Resource) findExistingUnionForPartners(ctx context.Context, partners ty
 	return "", diags
 }
 
-func (r *Resource) findMergedProfile(ctx context.Context, profileResponse *geniprofile.Profile) (*geniprofile.Profile, diag.Diagnostics) {
+func (r *Resource) findMergedProfile(ctx context.Context, profileResponse *geniclient.Profile) (*geniclient.Profile, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	for i := 0; i < 10 && profileResponse.Deleted && profileResponse.MergedInto != ""; i++ {
@@ -0,0 +1,54 @@
+package union
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
+)
+
+// removal is a profile the plan drops from the union, and the attribute that
+// listed it.
+type removal struct {
+	id        string
+	attribute string
+}
+
+// removedMembers returns the partners and children in state that the plan no
+// longer lists anywhere. A child moved between children, foster_children and
+// adopted_children is not removed.
+func removedMembers(ctx context.Context, plan, state ResourceModel) ([]removal, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	planned := make(map[string]struct{})
+	for _, set := range []types.Set{plan.Children, plan.FosterChildren, plan.AdoptedChildren} {
+		ids, d := tfset.Strings(ctx, set)
+		diags.Append(d...)
+		for _, id := range ids {
+			planned[id] = struct{}{}
+		}
+	}
+	planPartners, d := tfset.Strings(ctx, plan.Partners)
+	diags.Append(d...)
+
+	var removed []removal
+	collect := func(set types.Set, attribute string, kept map[string]struct{}) {
+		ids, d := tfset.Strings(ctx, set)
+		diags.Append(d...)
+		slices.Sort(ids)
+		for _, id := range ids {
+			if _, ok := kept[id]; !ok {
+				removed = append(removed, removal{id: id, attribute: attribute})
+			}
+		}
+	}
+	collect(state.Partners, fieldPartners, tfset.Index(planPartners))
+	collect(state.Children, fieldChildren, planned)
+	collect(state.FosterChildren, fieldFosterChildren, planned)
+	collect(state.AdoptedChildren, fieldAdoptedChildren, planned)
+
+	return removed, diags
+}
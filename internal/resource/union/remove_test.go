@@ -0,0 +1,35 @@
+package union
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRemovedMembers(t *testing.T) {
+	t.Run("Partners and children the plan drops are removed", func(t *testing.T) {
+		RegisterTestingT(t)
+		state := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3", "profile-4"}, []string{"profile-5"}, 1990)
+		plan := unionPlan(t, []string{"profile-1"}, []string{"profile-3"}, nil, 1990)
+
+		removed, diags := removedMembers(t.Context(), plan, state)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(removed).To(Equal([]removal{
+			{id: "profile-2", attribute: fieldPartners},
+			{id: "profile-4", attribute: fieldChildren},
+			{id: "profile-5", attribute: fieldAdoptedChildren},
+		}))
+	})
+
+	t.Run("A child moved to another child set is not removed", func(t *testing.T) {
+		RegisterTestingT(t)
+		state := unionPlan(t, []string{"profile-1"}, []string{"profile-3"}, nil, 1990)
+		plan := unionPlan(t, []string{"profile-1"}, nil, []string{"profile-3"}, 1990)
+
+		removed, diags := removedMembers(t.Context(), plan, state)
+
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(removed).To(BeEmpty())
+	})
+}
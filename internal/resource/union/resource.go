@@ -6,18 +6,29 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
-	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal/config"
+	"github.com/dmalch/terraform-provider-genealogy/internal/gazetteer"
 	"github.com/dmalch/terraform-provider-genealogy/internal/genibatch"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithIdentity = &Resource{} // new interface
+var _ resource.ResourceWithModifyPlan = &Resource{}
+
 type Resource struct {
 	resource.ResourceWithConfigure
-	client                   *geni.Client
+	client                   *geniclient.Client
 	batchClient              *genibatch.Client
 	autoUpdateMergedProfiles bool
+	// web is nil unless a Geni website session is configured.
+	web webEditor
+	// strictValidation makes the chronology checks fail the plan.
+	strictValidation bool
+	// checkPartnerGenders reports partners of the same gender.
+	checkPartnerGenders bool
+	// places is nil unless a gazetteer is configured.
+	places *gazetteer.Gazetteer
 }
 
 func NewUnionResource() resource.Resource {
@@ -52,4 +63,10 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	r.client = cfg.Client
 	r.batchClient = cfg.BatchClient
 	r.autoUpdateMergedProfiles = cfg.AutoUpdateMergedProfiles
+	r.strictValidation = cfg.StrictValidation
+	r.checkPartnerGenders = cfg.CheckPartnerGenders
+	r.places = cfg.Gazetteer
+	if cfg.Web != nil {
+		r.web = cfg.Web
+	}
 }
@@ -26,13 +26,14 @@ var unionIdFormat = regexp.MustCompile(`^union-(g)?\d+$`)
 // Schema defines the schema for the resource.
 func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "A union of partners and their children. Removing a partner or a child from the union requires `web_session` in the provider configuration: the Geni API cannot do it, so the provider does it through the Geni website. Moving a child between `children`, `foster_children` and `adopted_children` is done through the Geni website too when `web_session` is set; without it, the provider adds the child to the union again through the Geni API with its new relationship modifier, which Geni may ignore.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:      true,
 				Optional:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 				Validators:    []validator.String{stringvalidator.RegexMatches(unionIdFormat, "must be in the format union-1 or union-g1")},
-				Description:   "The unique identifier for the union. This is a string that starts with 'union-' followed by a number.",
+				Description:   "The unique identifier for the union. This is a string that starts with 'union-' followed by a number. When a new union's two partners already share a union on Geni, the plan adopts that union's id instead of creating a duplicate, or fails if the union has members the configuration does not list.",
 			},
 			fieldPartners: schema.SetAttribute{
 				ElementType: types.StringType,
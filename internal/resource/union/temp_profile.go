@@ -2,44 +2,112 @@ package union
 
 import (
 	"context"
+	"errors"
 
-	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"github.com/dmalch/go-geni"
 	geniprofile "github.com/dmalch/go-geni/profile"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 	"github.com/dmalch/terraform-provider-genealogy/internal/resource/event"
 )
 
+// TempProfileMarker is the first name a temporary profile is given once the
+// provider fails to merge and to delete it, so that it can be told apart from
+// the real profiles, as the geni_orphan_profiles data source does. A
+// temporary profile is not named before it is merged: Geni fills the blank
+// fields of the profile it is merged into from it, names included.
+const TempProfileMarker = "Terraform temporary profile"
+
+// IsTempProfile reports whether p is named TempProfileMarker, in any locale.
+func IsTempProfile(p *geniprofile.Profile) bool {
+	if p.FirstName != nil && *p.FirstName == TempProfileMarker {
+		return true
+	}
+	for _, name := range p.Names {
+		if name.FirstName != nil && *name.FirstName == TempProfileMarker {
+			return true
+		}
+	}
+	return false
+}
+
+// tempProfileRequest names a profile TempProfileMarker.
+func tempProfileRequest() *geniclient.ProfileRequest {
+	return &geniclient.ProfileRequest{Request: geniprofile.Request{
+		Names: map[string]geniprofile.NameElement{"en-US": {FirstName: new(TempProfileMarker)}},
+	}}
+}
+
 // addAndMerge creates a temporary Geni profile via create and merges it into the
 // existing profile realID. Geni has no API to link two existing profiles into a
 // union, so every union edge is built create-then-merge.
 //
 // If the merge fails the temporary profile is an orphan — live on Geni but
-// untracked by Terraform — so addAndMerge best-effort deletes it. The temp
+// untracked by Terraform — so addAndMerge best-effort deletes it, and names it
+// TempProfileMarker when that fails too. The temp
 // profile is returned even on merge failure (alongside the error) so the caller
 // can still recover the union id from tmp.Unions for partial-state persistence.
+//
+// Every temporary profile is recorded in j. When j already holds an orphan for
+// realID, left behind by an earlier apply, it is merged instead of creating
+// another.
 func (r *Resource) addAndMerge(
 	ctx context.Context,
+	j *journal,
 	realID string,
 	create func(context.Context) (*geniprofile.Profile, error),
 ) (*geniprofile.Profile, error) {
+	if orphan, ok := j.orphanFor(realID); ok {
+		_, err := r.client.Profile().Merge(ctx, realID, orphan.TempProfile)
+		switch {
+		case err == nil:
+			orphan.Status = stepMerged
+			return &geniprofile.Profile{ID: orphan.TempProfile}, nil
+		case errors.Is(err, geni.ErrResourceNotFound):
+			// Deleted on geni.com in the meantime; build the edge afresh.
+			orphan.Status = stepDeleted
+		default:
+			return nil, err
+		}
+	}
+
 	tmp, err := create(ctx)
 	if err != nil {
 		return nil, err
 	}
+	step := j.record(tmp.ID, realID)
 
 	if _, err := r.client.Profile().Merge(ctx, realID, tmp.ID); err != nil {
 		if delErr := r.client.Profile().Delete(ctx, tmp.ID); delErr != nil {
 			tflog.Error(ctx, "failed to delete orphan temporary profile after a failed merge",
 				map[string]any{"profile_id": tmp.ID, "error": delErr})
+			r.markOrphan(ctx, tmp.ID)
+		} else {
+			step.Status = stepDeleted
 		}
 		return tmp, err
 	}
+	step.Status = stepMerged
 
 	return tmp, nil
 }
 
+// markOrphan names the temporary profile tmpID TempProfileMarker, so that it
+// can be found once this apply is over. A profile that is no longer live is
+// left alone: one merged after all would pass the name on.
+func (r *Resource) markOrphan(ctx context.Context, tmpID string) {
+	tmp, err := r.client.Profile().Get(ctx, tmpID)
+	if err != nil || tmp.Deleted || tmp.MergedInto != "" {
+		return
+	}
+	if _, err := r.client.Profile().Update(ctx, tmpID, tempProfileRequest()); err != nil {
+		tflog.Warn(ctx, "failed to mark temporary profile",
+			map[string]any{"profile_id": tmpID, "error": err})
+	}
+}
+
 // unionIDFrom returns the union id once it is known: the current value if it is
 // already set, otherwise the union the temporary profile was created in.
 func unionIDFrom(current types.String, tmp *geniprofile.Profile) types.String {
@@ -52,19 +120,12 @@ func unionIDFrom(current types.String, tmp *geniprofile.Profile) types.String {
 	return current
 }
 
-// persistPartialUnion writes a union that was created but not fully configured
-// into state, so a failed Create does not strand it untracked — which would
-// otherwise make the next apply create yet another union. Marriage and Divorce
-// are nulled because their computed fields are still unresolved on the failure
-// path; the next Read repopulates them from the API.
-func persistPartialUnion(ctx context.Context, resp *resource.CreateResponse, plan ResourceModel) {
-	if plan.ID.IsUnknown() || plan.ID.IsNull() {
-		return // no union was created — nothing to track
-	}
-
+// partialUnion returns the state of a union that was created but not fully
+// configured. Marriage and Divorce are nulled because their computed fields are
+// still unresolved on the failure path; the next Read repopulates them from the
+// API. The id stays unknown when no union was created.
+func partialUnion(plan ResourceModel) ResourceModel {
 	plan.Marriage = types.ObjectNull(event.EventModelAttributeTypes())
 	plan.Divorce = types.ObjectNull(event.EventModelAttributeTypes())
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, ResourceIdentityModel{ID: plan.ID})...)
+	return plan
 }
@@ -14,6 +14,8 @@ import (
 
 // Update updates the resource.
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.batchClient.Invalidate()
+
 	var plan, state ResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -32,6 +34,35 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		}
 	}
 
+	// Resume from the orphans earlier applies left behind, and journal the
+	// temporary profiles of this one.
+	j, diags := loadJournal(ctx, req.Private, journalKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan, diags = r.update(ctx, plan, state, j)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(j.orphanDiagnostics()...)
+	if resp.Private != nil {
+		resp.Diagnostics.Append(j.save(ctx, resp.Private, journalKey)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	// Set data returned by API in identity
+	identityData.ID = plan.ID
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identityData)...)
+}
+
+// update changes the union from state to plan, journaling its temporary
+// profiles in j, and returns its new state.
+func (r *Resource) update(ctx context.Context, plan, state ResourceModel, j *journal) (ResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	partnersChanged := !plan.Partners.Equal(state.Partners)
 	childrenChanged := !plan.Children.Equal(state.Children) ||
 		!plan.FosterChildren.Equal(state.FosterChildren) ||
@@ -46,38 +77,47 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	var resolvedID string
 	var livePartners, liveChildren map[string]struct{}
 	if partnersChanged || childrenChanged {
-		var diags diag.Diagnostics
-		resolvedID, livePartners, liveChildren, diags = r.currentUnionMembers(ctx, plan.ID.ValueString(), state.Partners)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		var d diag.Diagnostics
+		resolvedID, livePartners, liveChildren, d = r.currentUnionMembers(ctx, plan.ID.ValueString(), state.Partners)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
+		}
+
+		// Removals and moves between the child sets go first, while the live
+		// union still matches what the Geni website shows for it.
+		removed, d := removedMembers(ctx, plan, state)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
+		}
+		moved := childrenWithChangedModifier(ctx, plan, state)
+		if len(removed) > 0 || len(moved) > 0 {
+			if r.web != nil {
+				diags.Append(r.editOnWebsite(ctx, resolvedID, removed, moved)...)
+			} else {
+				diags.Append(r.editThroughAPI(ctx, j, resolvedID, removed, moved)...)
+			}
+			if diags.HasError() {
+				return plan, diags
+			}
 		}
 	}
 
 	// Check if parents were updated
 	if partnersChanged {
-		planPartnerIds, diags := tfset.Strings(ctx, plan.Partners)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		planPartnerIds, d := tfset.Strings(ctx, plan.Partners)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
-		knownPlanPartnerIds := tfset.Index(planPartnerIds)
-
-		statePartnerIds, diags := tfset.Strings(ctx, state.Partners)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		statePartnerIds, d := tfset.Strings(ctx, state.Partners)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 		knownStatePartnerIds := tfset.Index(statePartnerIds)
 
-		for _, partnerId := range statePartnerIds {
-			// If the partner is not in the plan, fail the update because we can't remove
-			// partners from a union using the API
-			if _, ok := knownPlanPartnerIds[partnerId]; !ok {
-				resp.Diagnostics.AddAttributeWarning(path.Root(fieldPartners), "Cannot remove partners", "Partners cannot be removed from a union using terraform unless the profile is deleted")
-			}
-		}
-
 		for _, partnerId := range planPartnerIds {
 			// If the partner is not in the state, we need to add it
 			if _, ok := knownStatePartnerIds[partnerId]; !ok {
@@ -90,73 +130,55 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 				// It is impossible to add an existing profile to a union using the
 				// API, so create a temporary profile and merge it with the existing
 				// one. addAndMerge deletes the temp profile if the merge fails.
-				if _, err := r.addAndMerge(ctx, partnerId, func(ctx context.Context) (*geniprofile.Profile, error) {
+				if _, err := r.addAndMerge(ctx, j, partnerId, func(ctx context.Context) (*geniprofile.Profile, error) {
 					return r.client.Union().AddPartner(ctx, resolvedID)
 				}); err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root(fieldPartners), "Error adding partner", err.Error())
-					return
+					diags.AddAttributeError(path.Root(fieldPartners), "Error adding partner", err.Error())
+					return plan, diags
 				}
 			}
 		}
 	}
 
-	// Warn on modifier changes: Geni has no endpoint to re-tag an existing child.
-	for _, m := range childrenWithChangedModifier(ctx, plan, state) {
-		resp.Diagnostics.AddAttributeWarning(path.Root(fieldChildren),
-			"Cannot change relationship modifier",
-			"Profile "+m.id+" cannot be moved from "+m.from+" to "+m.to+
-				" via the Geni API. Re-tag the relationship on Geni.com, then re-run terraform.",
-		)
-	}
-
 	// Check if any of the three child sets were updated
 	if childrenChanged {
-		planBio, diags := tfset.Strings(ctx, plan.Children)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		planBio, d := tfset.Strings(ctx, plan.Children)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
-		planFoster, diags := tfset.Strings(ctx, plan.FosterChildren)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		planFoster, d := tfset.Strings(ctx, plan.FosterChildren)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
-		planAdopted, diags := tfset.Strings(ctx, plan.AdoptedChildren)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		planAdopted, d := tfset.Strings(ctx, plan.AdoptedChildren)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 
-		stateBio, diags := tfset.Strings(ctx, state.Children)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		stateBio, d := tfset.Strings(ctx, state.Children)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
-		stateFoster, diags := tfset.Strings(ctx, state.FosterChildren)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		stateFoster, d := tfset.Strings(ctx, state.FosterChildren)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
-		stateAdopted, diags := tfset.Strings(ctx, state.AdoptedChildren)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		stateAdopted, d := tfset.Strings(ctx, state.AdoptedChildren)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 
 		planAll := append(append(append([]string{}, planBio...), planFoster...), planAdopted...)
 		stateAll := append(append(append([]string{}, stateBio...), stateFoster...), stateAdopted...)
 
-		knownPlanAll := tfset.Index(planAll)
 		knownStateAll := tfset.Index(stateAll)
 
-		for _, childId := range stateAll {
-			// If the child is not in the plan, fail the update because we can't remove
-			// children from a union using the API
-			if _, ok := knownPlanAll[childId]; !ok {
-				resp.Diagnostics.AddAttributeWarning(path.Root(fieldChildren), "Cannot remove children", "Children cannot be removed from a union using terraform unless the profile is deleted")
-			}
-		}
-
 		fosterSet := tfset.Index(planFoster)
 		adoptedSet := tfset.Index(planAdopted)
 
@@ -174,11 +196,11 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 				// API, so create a temporary profile and merge it with the existing
 				// one. addAndMerge deletes the temp profile if the merge fails.
 				modifier := modifierFor(childId, fosterSet, adoptedSet)
-				if _, err := r.addAndMerge(ctx, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
+				if _, err := r.addAndMerge(ctx, j, childId, func(ctx context.Context) (*geniprofile.Profile, error) {
 					return r.client.Union().AddChild(ctx, resolvedID, geniprofile.WithModifier(modifier))
 				}); err != nil {
-					resp.Diagnostics.AddAttributeError(path.Root(fieldChildren), "Error adding child with ID="+childId, err.Error())
-					return
+					diags.AddAttributeError(path.Root(fieldChildren), "Error adding child with ID="+childId, err.Error())
+					return plan, diags
 				}
 			}
 		}
@@ -186,10 +208,10 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	// Check if marriage or divorce were updated
 	if !plan.Marriage.Equal(state.Marriage) || !plan.Divorce.Equal(state.Divorce) {
-		unionRequest, diags := RequestFrom(ctx, plan)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		unionRequest, d := RequestFrom(ctx, plan)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 
 		// Pre-wipe entire events that the plan removes (block dropped entirely).
@@ -205,8 +227,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		}
 		if len(wholeEventWipeKeys) > 0 {
 			if err := r.client.Profile().WipeEvents(ctx, plan.ID.ValueString(), wholeEventWipeKeys); err != nil {
-				resp.Diagnostics.AddError("Error clearing event fields", err.Error())
-				return
+				diags.AddError("Error clearing event fields", err.Error())
+				return plan, diags
 			}
 		}
 
@@ -222,27 +244,27 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		}
 		if len(wipeEvents) > 0 {
 			if err := r.client.Profile().WipeEventDates(ctx, plan.ID.ValueString(), wipeEvents); err != nil {
-				resp.Diagnostics.AddError("Error clearing date fields", err.Error())
-				return
+				diags.AddError("Error clearing date fields", err.Error())
+				return plan, diags
 			}
 		}
 
 		unionResponse, err := r.client.Union().Update(ctx, plan.ID.ValueString(), unionRequest)
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating union", err.Error())
-			return
+			diags.AddError("Error updating union", err.Error())
+			return plan, diags
 		}
 
-		diags = UpdateComputedFields(ctx, unionResponse, &plan)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		d = UpdateComputedFields(ctx, unionResponse, &plan)
+		diags.Append(d...)
+		if diags.HasError() {
+			return plan, diags
 		}
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	// An orphan still unmerged is for a profile the configuration no longer
+	// adds, so roll it back.
+	r.deleteOrphans(ctx, j)
 
-	// Set data returned by API in identity
-	identityData.ID = plan.ID
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, identityData)...)
+	return plan, diags
 }
@@ -3,6 +3,7 @@ package union
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -35,21 +36,27 @@ func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConf
 		)
 	}
 
+	resp.Diagnostics.Append(ValidateChildSets(data, path.Empty())...)
+}
+
+// ValidateChildSets checks that no profile is in more than one of data's
+// children, foster_children and adopted_children, which are attributes of at.
+func ValidateChildSets(data ResourceModel, at path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
 	childSets := []struct {
 		field string
-		root  path.Path
 		set   types.Set
 	}{
-		{fieldChildren, path.Root(fieldChildren), data.Children},
-		{fieldFosterChildren, path.Root(fieldFosterChildren), data.FosterChildren},
-		{fieldAdoptedChildren, path.Root(fieldAdoptedChildren), data.AdoptedChildren},
+		{fieldChildren, data.Children},
+		{fieldFosterChildren, data.FosterChildren},
+		{fieldAdoptedChildren, data.AdoptedChildren},
 	}
 	for i := range childSets {
 		left := setIDs(childSets[i].set)
 		for j := i + 1; j < len(childSets); j++ {
 			right := setIDs(childSets[j].set)
 			if id := firstCommon(left, right); id != "" {
-				resp.Diagnostics.AddAttributeError(childSets[j].root,
+				diags.AddAttributeError(at.AtName(childSets[j].field),
 					"Overlapping Child Sets",
 					"Profile "+id+" appears in both "+childSets[i].field+" and "+childSets[j].field+". "+
 						"Each child must belong to exactly one relationship category.",
@@ -57,6 +64,7 @@ func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConf
 			}
 		}
 	}
+	return diags
 }
 
 func setIDs(s types.Set) map[string]struct{} {
@@ -0,0 +1,216 @@
+package union
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	geniprofile "github.com/dmalch/go-geni/profile"
+	geniunion "github.com/dmalch/go-geni/union"
+	"github.com/dmalch/terraform-provider-genealogy/internal/tfset"
+)
+
+// webEditor makes the union edits the Geni API has no endpoint for through the
+// Geni website. *geniweb.Client implements it.
+type webEditor interface {
+	UnionWebID(ctx context.Context, memberGuid string, partners, children []string) (string, error)
+	Detach(ctx context.Context, profileGuid, unionWebID string) error
+	SetParentModifier(ctx context.Context, childGuid, unionWebID, modifier string) error
+}
+
+// websiteUnion is a union as the Geni website knows it.
+type websiteUnion struct {
+	// live is the union as the API returns it now.
+	live *geniunion.Union
+	// webID is the website's id of the union.
+	webID string
+	// guids maps the API id of every member to the guid the website uses.
+	guids map[string]string
+}
+
+// findOnWebsite looks unionID up on the Geni website through the family of
+// memberID, one of its members.
+func (r *Resource) findOnWebsite(ctx context.Context, unionID, memberID string) (websiteUnion, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var u websiteUnion
+
+	live, err := r.batchClient.GetUnion(ctx, unionID)
+	if err != nil {
+		diags.AddError("Error reading union", err.Error())
+		return u, diags
+	}
+	u.live = live
+
+	// The website knows profiles by guid only.
+	profiles, err := r.client.Profile().GetBulk(ctx, slices.Concat(live.Partners, live.Children))
+	if err != nil {
+		diags.AddError("Error reading union members", err.Error())
+		return u, diags
+	}
+	u.guids = make(map[string]string, len(profiles.Results))
+	for _, p := range profiles.Results {
+		if p.Guid != "" {
+			u.guids[p.ID] = p.Guid
+		}
+	}
+
+	memberGuid, ok := u.guids[memberID]
+	if !ok {
+		diags.AddError("Error finding union "+unionID+" on the Geni website", "Geni returned no guid for "+memberID+".")
+		return u, diags
+	}
+	u.webID, err = r.web.UnionWebID(ctx, memberGuid, u.guidsOf(live.Partners), u.guidsOf(live.Children))
+	if err != nil {
+		diags.AddError("Error finding union "+unionID+" on the Geni website", err.Error())
+	}
+	return u, diags
+}
+
+// guidsOf returns the guids of the given members, skipping any without one.
+func (u websiteUnion) guidsOf(ids []string) []string {
+	guids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if guid, ok := u.guids[id]; ok {
+			guids = append(guids, guid)
+		}
+	}
+	return guids
+}
+
+// editOnWebsite removes the given members from unionID and moves the given
+// children between the biological, foster and adopted sets, through the Geni
+// website, so r.web must be set. Members already gone, and children whose
+// relationship already matches, are left alone. Since the website ignores a request it cannot act
+// on, the union is read back to confirm every edit.
+func (r *Resource) editOnWebsite(ctx context.Context, unionID string, removed []removal, moved []childModifierChange) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	live, err := r.batchClient.GetUnion(ctx, unionID)
+	if err != nil {
+		diags.AddError("Error reading union", err.Error())
+		return diags
+	}
+	members := tfset.Index(slices.Concat(live.Partners, live.Children))
+	removed = slices.DeleteFunc(slices.Clone(removed), func(m removal) bool {
+		_, ok := members[m.id]
+		return !ok
+	})
+	moved = slices.DeleteFunc(slices.Clone(moved), func(m childModifierChange) bool {
+		return !slices.Contains(live.Children, m.id) || childLabel(live, m.id) == m.to
+	})
+	if len(removed) == 0 && len(moved) == 0 {
+		return diags
+	}
+
+	var memberID string
+	if len(removed) > 0 {
+		memberID = removed[0].id
+	} else {
+		memberID = moved[0].id
+	}
+	u, d := r.findOnWebsite(ctx, unionID, memberID)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, m := range removed {
+		guid, ok := u.guids[m.id]
+		if !ok {
+			diags.AddAttributeError(path.Root(m.attribute), "Error removing "+m.id+" from "+unionID, "Geni returned no guid for "+m.id+".")
+			return diags
+		}
+		if err := r.web.Detach(ctx, guid, u.webID); err != nil {
+			diags.AddAttributeError(path.Root(m.attribute), "Error removing "+m.id+" from "+unionID, err.Error())
+			return diags
+		}
+	}
+	for _, m := range moved {
+		attribute := path.Root(childAttributes[m.to])
+		guid, ok := u.guids[m.id]
+		if !ok {
+			diags.AddAttributeError(attribute, "Error changing relationship modifier of "+m.id, "Geni returned no guid for "+m.id+".")
+			return diags
+		}
+		if err := r.web.SetParentModifier(ctx, guid, u.webID, websiteModifiers[m.to]); err != nil {
+			diags.AddAttributeError(attribute, "Error changing relationship modifier of "+m.id, err.Error())
+			return diags
+		}
+	}
+
+	after, err := r.client.Union().Get(ctx, unionID)
+	if err != nil {
+		diags.AddError("Error reading union", err.Error())
+		return diags
+	}
+	remaining := tfset.Index(slices.Concat(after.Partners, after.Children))
+	for _, m := range removed {
+		if _, ok := remaining[m.id]; ok {
+			diags.AddAttributeError(path.Root(m.attribute), "Error removing "+m.id+" from "+unionID,
+				"The Geni website accepted the removal, but "+m.id+" is still a member of "+unionID+".")
+		}
+	}
+	for _, m := range moved {
+		if got := childLabel(after, m.id); got != m.to {
+			diags.AddAttributeError(path.Root(childAttributes[m.to]), "Error changing relationship modifier of "+m.id,
+				"The Geni website accepted the change, but "+m.id+" is still a "+got+" child of "+unionID+".")
+		}
+	}
+	return diags
+}
+
+// editThroughAPI makes the edits of editOnWebsite without a website session.
+// The Geni API cannot remove a member from a union, so removals fail. A child
+// moved between the biological, foster and adopted sets is added to the union
+// again with its new relationship modifier, through addAndMerge. Geni decides
+// which relationship a profile related to a union twice keeps, so the union is
+// read back to confirm every move.
+func (r *Resource) editThroughAPI(ctx context.Context, j *journal, unionID string, removed []removal, moved []childModifierChange) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, m := range removed {
+		diags.AddAttributeError(path.Root(m.attribute), "Cannot remove union member",
+			m.id+" cannot be removed from "+unionID+" without web_session in the provider configuration.")
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	live, err := r.batchClient.GetUnion(ctx, unionID)
+	if err != nil {
+		diags.AddError("Error reading union", err.Error())
+		return diags
+	}
+	moved = slices.DeleteFunc(slices.Clone(moved), func(m childModifierChange) bool {
+		return !slices.Contains(live.Children, m.id) || childLabel(live, m.id) == m.to
+	})
+	if len(moved) == 0 {
+		return diags
+	}
+
+	for _, m := range moved {
+		modifier := apiModifiers[m.to]
+		if _, err := r.addAndMerge(ctx, j, m.id, func(ctx context.Context) (*geniprofile.Profile, error) {
+			return r.client.Union().AddChild(ctx, unionID, geniprofile.WithModifier(modifier))
+		}); err != nil {
+			diags.AddAttributeError(path.Root(childAttributes[m.to]), "Error changing relationship modifier of "+m.id, err.Error())
+			return diags
+		}
+	}
+
+	after, err := r.client.Union().Get(ctx, unionID)
+	if err != nil {
+		diags.AddError("Error reading union", err.Error())
+		return diags
+	}
+	for _, m := range moved {
+		if got := childLabel(after, m.id); got != m.to {
+			diags.AddAttributeError(path.Root(childAttributes[m.to]), "Error changing relationship modifier of "+m.id,
+				m.id+" was added to "+unionID+" again through the Geni API to move it to "+childAttributes[m.to]+
+					", but Geni kept it in "+childAttributes[got]+". Set web_session in the provider configuration to change it through the Geni website.")
+		}
+	}
+	return diags
+}
@@ -0,0 +1,186 @@
+package union
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/genifake"
+	"github.com/dmalch/terraform-provider-genealogy/internal/genitest"
+)
+
+func TestUpdateEditsOnWebsite(t *testing.T) {
+	t.Run("Dropped partners and children are detached through the website", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, server := fakeServerResource(t, 5)
+		web := &fakeWeb{server: server}
+		r.web = web
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3", "profile-4"}, []string{"profile-5"}, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		web.unionID = created.ID.ValueString()
+
+		plan := unionPlan(t, []string{"profile-1"}, []string{"profile-3"}, nil, 1990)
+		plan.ID = created.ID
+		updated, diags := updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(setStrings(t, updated.Partners)).To(ConsistOf("profile-1"))
+		Expect(setStrings(t, updated.Children)).To(ConsistOf("profile-3"))
+		Expect(web.memberGuid).To(Equal(guidOf(t, r, "profile-2")))
+
+		union, err := r.client.Union().Get(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Partners).To(ConsistOf("profile-1"))
+		Expect(union.Children).To(ConsistOf("profile-3"))
+		Expect(union.AdoptedChildren).To(BeEmpty())
+	})
+
+	t.Run("A child moved to another child set is re-tagged in place", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, server := fakeServerResource(t, 4)
+		web := &fakeWeb{server: server}
+		r.web = web
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, []string{"profile-4"}, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		web.unionID = created.ID.ValueString()
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-4"}, nil, 1990)
+		plan.FosterChildren = stringSet(t, []string{"profile-3"})
+		plan.ID = created.ID
+		updated, diags := updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(setStrings(t, updated.FosterChildren)).To(ConsistOf("profile-3"))
+		Expect(setStrings(t, updated.Children)).To(ConsistOf("profile-4"))
+
+		union, err := r.client.Union().Get(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Children).To(ConsistOf("profile-3", "profile-4"))
+		Expect(union.FosterChildren).To(ConsistOf("profile-3"))
+		Expect(union.AdoptedChildren).To(BeEmpty())
+	})
+
+	t.Run("A re-tag the website does not carry out fails the apply", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		r.web = &fakeWeb{}
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, nil, []string{"profile-3"}, 1990)
+		plan.ID = created.ID
+		_, diags = updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("profile-3 is still a biological child of " + created.ID.ValueString()))
+	})
+
+	t.Run("A removal the website does not carry out fails the apply", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		r.web = &fakeWeb{}
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, nil, nil, 1990)
+		plan.ID = created.ID
+		_, diags = updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("profile-3 is still a member of " + created.ID.ValueString()))
+	})
+}
+
+func TestUpdateEditsThroughAPI(t *testing.T) {
+	t.Run("Without a website session, a child moved to adopted_children is added again as adopted", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, nil, []string{"profile-3"}, 1990)
+		plan.ID = created.ID
+		updated, diags := updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(setStrings(t, updated.AdoptedChildren)).To(ConsistOf("profile-3"))
+		Expect(updated.Children.Elements()).To(BeEmpty())
+
+		union, err := r.client.Union().Get(t.Context(), created.ID.ValueString())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union.Children).To(ConsistOf("profile-3"))
+		Expect(union.AdoptedChildren).To(ConsistOf("profile-3"))
+	})
+
+	t.Run("Without a website session, a move Geni does not carry out fails the apply", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, nil, []string{"profile-3"}, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990)
+		plan.ID = created.ID
+		_, diags = updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags.Errors()[0].Detail()).To(ContainSubstring("but Geni kept it in adopted_children"))
+	})
+
+	t.Run("Without a website session, removing a member fails the apply", func(t *testing.T) {
+		RegisterTestingT(t)
+		r, _ := fakeServerResource(t, 3)
+		created, diags := createUnion(t, r, unionPlan(t, []string{"profile-1", "profile-2"}, []string{"profile-3"}, nil, 1990))
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+
+		plan := unionPlan(t, []string{"profile-1", "profile-2"}, nil, nil, 1990)
+		plan.ID = created.ID
+		_, diags = updateUnion(t, r, created, plan)
+
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags.Errors()[0].Summary()).To(Equal("Cannot remove union member"))
+	})
+}
+
+// fakeWeb stands in for the Geni website. It takes every union to be unionID,
+// and edits the family on server, or leaves it as it is when server is nil.
+type fakeWeb struct {
+	server  *genifake.Server
+	unionID string
+	// memberGuid is the guid UnionWebID was last asked about.
+	memberGuid string
+}
+
+func (f *fakeWeb) UnionWebID(_ context.Context, memberGuid string, _, _ []string) (string, error) {
+	f.memberGuid = memberGuid
+	return f.unionID, nil
+}
+
+func (f *fakeWeb) Detach(_ context.Context, profileGuid, unionWebID string) error {
+	if f.server == nil {
+		return nil
+	}
+	return f.server.DetachFromUnion(profileGuid, unionWebID)
+}
+
+func (f *fakeWeb) SetParentModifier(_ context.Context, childGuid, unionWebID, modifier string) error {
+	if f.server == nil {
+		return nil
+	}
+	return f.server.SetParentModifier(childGuid, unionWebID, modifier)
+}
+
+// fakeServerResource returns a union Resource talking to a fake Geni server
+// that holds the given number of profiles, profile-1 onwards.
+func fakeServerResource(t *testing.T, profiles int) (*Resource, *genifake.Server) {
+	t.Helper()
+	clients, server := genitest.FakeServer(t, profiles, nil)
+	return &Resource{client: clients.Client, batchClient: clients.BatchClient}, server
+}
+
+func guidOf(t *testing.T, r *Resource, id string) string {
+	t.Helper()
+	profile, err := r.client.Profile().Get(t.Context(), id)
+	Expect(err).ToNot(HaveOccurred())
+	return profile.Guid
+}
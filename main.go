@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"flag"
+	"io"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"github.com/dmalch/terraform-provider-genealogy/internal"
@@ -16,11 +18,21 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	err := providerserver.Serve(context.Background(), internal.New, providerserver.ServeOpts{
+	// The server builds a single provider instance anyway; holding on to it
+	// lets us stop its background work once Terraform is done with us.
+	p := internal.New()
+
+	err := providerserver.Serve(context.Background(), func() provider.Provider { return p }, providerserver.ServeOpts{
 		Address: "registry.terraform.io/dmalch/genealogy",
 		Debug:   debug,
 	})
 
+	if closer, ok := p.(io.Closer); ok {
+		if closeErr := closer.Close(); closeErr != nil {
+			log.Printf("error shutting down provider: %v", closeErr)
+		}
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -2,12 +2,21 @@ package acceptance
 
 import "os"
 
-// testAccessToken is the access token used for acceptance tests.
-// Can be set via the GENI_ACCESS_TOKEN environment variable or
+// testAgainstSandbox is whether the tests run against the Geni sandbox,
+// which the GENI_ACC_SANDBOX environment variable opts in to. Otherwise they
+// run against an in-process fake Geni server (see TestMain).
+var testAgainstSandbox = os.Getenv("GENI_ACC_SANDBOX") != ""
+
+// testAccessToken is the access token used for acceptance tests against the
+// sandbox. Can be set via the GENI_ACCESS_TOKEN environment variable or
 // requested at https://sandbox.geni.com/platform/developer/api_explorer.
 // If empty, the provider falls back to browser-based OAuth login.
 var testAccessToken = os.Getenv("GENI_ACCESS_TOKEN")
 
+// testAPIBaseURL is the address of the fake Geni server the tests run
+// against, or empty when they run against the Geni sandbox.
+var testAPIBaseURL string
+
 func init() {
 	os.Setenv("GENI_USE_SANDBOX", "true")
 }
@@ -80,19 +80,24 @@ func TestAccDocument_createTextDocumentWithDetails(t *testing.T) {
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("source_url"), knownvalue.Null()),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("description"), knownvalue.StringExact("This is a test document description.")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("date"), knownvalue.ObjectExact(map[string]knownvalue.Check{
-						"year":  knownvalue.Int32Exact(1980),
-						"month": knownvalue.Int32Exact(1),
-						"day":   knownvalue.Int32Exact(1),
-						"circa": knownvalue.Bool(true),
+						"year":      knownvalue.Int32Exact(1980),
+						"month":     knownvalue.Int32Exact(1),
+						"day":       knownvalue.Int32Exact(1),
+						"circa":     knownvalue.Bool(true),
+						"calendar":  knownvalue.Null(),
+						"dual_year": knownvalue.Null(),
+						"gregorian": knownvalue.StringExact("1980-01-01"),
 					})),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("location"), knownvalue.ObjectExact(map[string]knownvalue.Check{
 						"city":            knownvalue.StringExact("New York"),
 						"country":         knownvalue.StringExact("USA"),
 						"place_name":      knownvalue.StringExact("Hospital"),
+						"place_id":        knownvalue.Null(),
 						"state":           knownvalue.StringExact("New York"),
 						"street_address1": knownvalue.StringExact("123 Main St"),
 						"street_address2": knownvalue.StringExact("Apt 1"),
 						"street_address3": knownvalue.StringExact("Floor 2"),
+						"historical":      knownvalue.Null(),
 						"county":          knownvalue.StringExact("Alameda"),
 						"latitude":        knownvalue.Null(),
 						"longitude":       knownvalue.Null(),
@@ -165,19 +170,24 @@ func TestAccDocument_updateTextDocumentWithDetails(t *testing.T) {
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("source_url"), knownvalue.Null()),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("description"), knownvalue.StringExact("This is a test document description.")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("date"), knownvalue.ObjectExact(map[string]knownvalue.Check{
-						"year":  knownvalue.Int32Exact(1980),
-						"month": knownvalue.Int32Exact(1),
-						"day":   knownvalue.Int32Exact(1),
-						"circa": knownvalue.Bool(true),
+						"year":      knownvalue.Int32Exact(1980),
+						"month":     knownvalue.Int32Exact(1),
+						"day":       knownvalue.Int32Exact(1),
+						"circa":     knownvalue.Bool(true),
+						"calendar":  knownvalue.Null(),
+						"dual_year": knownvalue.Null(),
+						"gregorian": knownvalue.StringExact("1980-01-01"),
 					})),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("location"), knownvalue.ObjectExact(map[string]knownvalue.Check{
 						"city":            knownvalue.StringExact("New York"),
 						"country":         knownvalue.StringExact("USA"),
 						"place_name":      knownvalue.StringExact("Hospital"),
+						"place_id":        knownvalue.Null(),
 						"state":           knownvalue.StringExact("New York"),
 						"street_address1": knownvalue.StringExact("123 Main St"),
 						"street_address2": knownvalue.StringExact("Apt 1"),
 						"street_address3": knownvalue.StringExact("Floor 2"),
+						"historical":      knownvalue.Null(),
 						"county":          knownvalue.StringExact("Alameda"),
 						"latitude":        knownvalue.Null(),
 						"longitude":       knownvalue.Null(),
@@ -259,19 +269,24 @@ func TestAccDocument_createPngDocumentWithDetails(t *testing.T) {
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("source_url"), knownvalue.Null()),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("description"), knownvalue.StringExact("This is a test document description.")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("date"), knownvalue.ObjectExact(map[string]knownvalue.Check{
-						"year":  knownvalue.Int32Exact(1980),
-						"month": knownvalue.Int32Exact(1),
-						"day":   knownvalue.Int32Exact(1),
-						"circa": knownvalue.Bool(true),
+						"year":      knownvalue.Int32Exact(1980),
+						"month":     knownvalue.Int32Exact(1),
+						"day":       knownvalue.Int32Exact(1),
+						"circa":     knownvalue.Bool(true),
+						"calendar":  knownvalue.Null(),
+						"dual_year": knownvalue.Null(),
+						"gregorian": knownvalue.StringExact("1980-01-01"),
 					})),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("location"), knownvalue.ObjectExact(map[string]knownvalue.Check{
 						"city":            knownvalue.StringExact("New York"),
 						"country":         knownvalue.StringExact("USA"),
 						"place_name":      knownvalue.StringExact("Hospital"),
+						"place_id":        knownvalue.Null(),
 						"state":           knownvalue.StringExact("New York"),
 						"street_address1": knownvalue.StringExact("123 Main St"),
 						"street_address2": knownvalue.StringExact("Apt 1"),
 						"street_address3": knownvalue.StringExact("Floor 2"),
+						"historical":      knownvalue.Null(),
 						"county":          knownvalue.StringExact("Alameda"),
 						"latitude":        knownvalue.Null(),
 						"longitude":       knownvalue.Null(),
@@ -347,19 +362,24 @@ func TestAccDocument_updatePngDocumentWithDetails(t *testing.T) {
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("source_url"), knownvalue.Null()),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("description"), knownvalue.StringExact("This is a test document description.")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("date"), knownvalue.ObjectExact(map[string]knownvalue.Check{
-						"year":  knownvalue.Int32Exact(1980),
-						"month": knownvalue.Int32Exact(1),
-						"day":   knownvalue.Int32Exact(1),
-						"circa": knownvalue.Bool(true),
+						"year":      knownvalue.Int32Exact(1980),
+						"month":     knownvalue.Int32Exact(1),
+						"day":       knownvalue.Int32Exact(1),
+						"circa":     knownvalue.Bool(true),
+						"calendar":  knownvalue.Null(),
+						"dual_year": knownvalue.Null(),
+						"gregorian": knownvalue.StringExact("1980-01-01"),
 					})),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("location"), knownvalue.ObjectExact(map[string]knownvalue.Check{
 						"city":            knownvalue.StringExact("New York"),
 						"country":         knownvalue.StringExact("USA"),
 						"place_name":      knownvalue.StringExact("Hospital"),
+						"place_id":        knownvalue.Null(),
 						"state":           knownvalue.StringExact("New York"),
 						"street_address1": knownvalue.StringExact("123 Main St"),
 						"street_address2": knownvalue.StringExact("Apt 1"),
 						"street_address3": knownvalue.StringExact("Floor 2"),
+						"historical":      knownvalue.Null(),
 						"county":          knownvalue.StringExact("Alameda"),
 						"latitude":        knownvalue.Null(),
 						"longitude":       knownvalue.Null(),
@@ -473,19 +493,24 @@ func TestAccDocument_createUrlDocumentWithDetails(t *testing.T) {
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("source_url"), knownvalue.StringExact("https://example.com")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("description"), knownvalue.StringExact("This is a test document description.")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("date"), knownvalue.ObjectExact(map[string]knownvalue.Check{
-						"year":  knownvalue.Int32Exact(1980),
-						"month": knownvalue.Int32Exact(1),
-						"day":   knownvalue.Int32Exact(1),
-						"circa": knownvalue.Bool(true),
+						"year":      knownvalue.Int32Exact(1980),
+						"month":     knownvalue.Int32Exact(1),
+						"day":       knownvalue.Int32Exact(1),
+						"circa":     knownvalue.Bool(true),
+						"calendar":  knownvalue.Null(),
+						"dual_year": knownvalue.Null(),
+						"gregorian": knownvalue.StringExact("1980-01-01"),
 					})),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("location"), knownvalue.ObjectExact(map[string]knownvalue.Check{
 						"city":            knownvalue.StringExact("New York"),
 						"country":         knownvalue.StringExact("USA"),
 						"place_name":      knownvalue.StringExact("Hospital"),
+						"place_id":        knownvalue.Null(),
 						"state":           knownvalue.StringExact("New York"),
 						"street_address1": knownvalue.StringExact("123 Main St"),
 						"street_address2": knownvalue.StringExact("Apt 1"),
 						"street_address3": knownvalue.StringExact("Floor 2"),
+						"historical":      knownvalue.Null(),
 						"county":          knownvalue.StringExact("Alameda"),
 						"latitude":        knownvalue.Null(),
 						"longitude":       knownvalue.Null(),
@@ -600,19 +625,24 @@ func TestAccDocument_updateUrlDocumentWithDetails(t *testing.T) {
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("source_url"), knownvalue.StringExact("https://example.com")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("description"), knownvalue.StringExact("This is a test document description.")),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("date"), knownvalue.ObjectExact(map[string]knownvalue.Check{
-						"year":  knownvalue.Int32Exact(1980),
-						"month": knownvalue.Int32Exact(1),
-						"day":   knownvalue.Int32Exact(1),
-						"circa": knownvalue.Bool(true),
+						"year":      knownvalue.Int32Exact(1980),
+						"month":     knownvalue.Int32Exact(1),
+						"day":       knownvalue.Int32Exact(1),
+						"circa":     knownvalue.Bool(true),
+						"calendar":  knownvalue.Null(),
+						"dual_year": knownvalue.Null(),
+						"gregorian": knownvalue.StringExact("1980-01-01"),
 					})),
 					statecheck.ExpectKnownValue("geni_document.test", tfjsonpath.New("location"), knownvalue.ObjectExact(map[string]knownvalue.Check{
 						"city":            knownvalue.StringExact("New York"),
 						"country":         knownvalue.StringExact("USA"),
 						"place_name":      knownvalue.StringExact("Hospital"),
+						"place_id":        knownvalue.Null(),
 						"state":           knownvalue.StringExact("New York"),
 						"street_address1": knownvalue.StringExact("123 Main St"),
 						"street_address2": knownvalue.StringExact("Apt 1"),
 						"street_address3": knownvalue.StringExact("Floor 2"),
+						"historical":      knownvalue.Null(),
 						"county":          knownvalue.StringExact("Alameda"),
 						"latitude":        knownvalue.Null(),
 						"longitude":       knownvalue.Null(),
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
@@ -44,6 +45,38 @@ func TestAccDataSourceProfile_byID(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceProfile_relatives(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: unionWithTwoPartnersAndChild() + `
+					data "geni_profile" "child" {
+					  id         = geni_profile.child.id
+					  depends_on = [geni_union.doe_family]
+					}
+
+					data "geni_profile" "husband" {
+					  id         = geni_profile.husband.id
+					  depends_on = [geni_union.doe_family]
+					}
+					`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.geni_profile.child", tfjsonpath.New("parents"), knownvalue.SetSizeExact(2)),
+					statecheck.CompareValueCollection("data.geni_profile.child", []tfjsonpath.Path{tfjsonpath.New("parents")},
+						"geni_profile.husband", tfjsonpath.New("id"), compare.ValuesSame()),
+					statecheck.ExpectKnownValue("data.geni_profile.child", tfjsonpath.New("children"), knownvalue.SetSizeExact(0)),
+					statecheck.ExpectKnownValue("data.geni_profile.husband", tfjsonpath.New("partners"), knownvalue.SetSizeExact(1)),
+					statecheck.CompareValueCollection("data.geni_profile.husband", []tfjsonpath.Path{tfjsonpath.New("partners")},
+						"geni_profile.wife", tfjsonpath.New("id"), compare.ValuesSame()),
+					statecheck.CompareValueCollection("data.geni_profile.husband", []tfjsonpath.Path{tfjsonpath.New("children")},
+						"geni_profile.child", tfjsonpath.New("id"), compare.ValuesSame()),
+				},
+			},
+		},
+	})
+}
+
 func TestAccDataSourceProfile_byGUID(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
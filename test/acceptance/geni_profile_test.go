@@ -1,6 +1,8 @@
 package acceptance
 
 import (
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -364,6 +366,89 @@ func profileWithFixedBirthDate() string {
 	`
 }
 
+func TestAccProfile_createProfileWithJulianDualDate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "geni_profile" "test" {
+					  names = {
+						"en-US" = {
+							first_name = "John"
+							last_name = "Doe"
+						}
+					  }
+					  baptism = {
+						date = {
+						  calendar = "julian"
+						  day = 11
+						  month = 2
+						  year = 1731
+						  dual_year = 1732
+						}
+					  }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("baptism").AtMapKey("date"), knownvalue.ObjectPartial(map[string]knownvalue.Check{
+						"calendar":  knownvalue.StringExact("julian"),
+						"day":       knownvalue.Int32Exact(11),
+						"month":     knownvalue.Int32Exact(2),
+						"year":      knownvalue.Int32Exact(1731),
+						"dual_year": knownvalue.Int32Exact(1732),
+						"gregorian": knownvalue.StringExact("1732-02-22"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccProfile_createProfileWithGazetteerPlace(t *testing.T) {
+	places := filepath.Join(t.TempDir(), "places.tsv")
+	if err := os.WriteFile(places, []byte("id\tcity\tcountry\tlatitude\tlongitude\n"+
+		"524901\tMoscow\tRussia\t55.75222\t37.61556\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GENI_GAZETTEER", places)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "geni_profile" "test" {
+					  names = {
+						"en-US" = {
+							first_name = "John"
+							last_name = "Doe"
+						}
+					  }
+					  birth = {
+						location = {
+						  place_id = "524901"
+						}
+					  }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("birth").AtMapKey("location"), knownvalue.ObjectPartial(map[string]knownvalue.Check{
+						"place_id":  knownvalue.StringExact("524901"),
+						"city":      knownvalue.StringExact("Moscow"),
+						"country":   knownvalue.StringExact("Russia"),
+						"state":     knownvalue.Null(),
+						"latitude":  knownvalue.Float64Exact(55.75222),
+						"longitude": knownvalue.Float64Exact(37.61556),
+					})),
+				},
+			},
+		},
+	})
+}
+
 func TestAccProfile_createProfileWithEmptyBirthLocation(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
@@ -1393,3 +1478,70 @@ func TestAccProfile_titleOccupationSuffix(t *testing.T) {
 		},
 	})
 }
+
+func TestAccProfile_customEvents(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "geni" {
+					  experimental_events = true
+					}
+
+					resource "geni_profile" "test" {
+					  names = {
+						"en-US" = { first_name = "John", last_name = "Doe" }
+					  }
+					  alive  = false
+					  public = true
+					  events = {
+						emigration = {
+						  name     = "Emigration"
+						  date     = { year = 1922 }
+						  location = { city = "Marseille", country = "France" }
+						}
+						census = {
+						  name        = "Census"
+						  description = "Listed with his mother."
+						}
+					  }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events").AtMapKey("emigration").AtMapKey("id"), knownvalue.StringRegexp(regexp.MustCompile(`^event-\d+$`))),
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events").AtMapKey("emigration").AtMapKey("date").AtMapKey("year"), knownvalue.Int32Exact(1922)),
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events").AtMapKey("census").AtMapKey("description"), knownvalue.StringExact("Listed with his mother.")),
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events").AtMapKey("census").AtMapKey("date"), knownvalue.Null()),
+				},
+			},
+			{
+				Config: `
+					provider "geni" {
+					  experimental_events = true
+					}
+
+					resource "geni_profile" "test" {
+					  names = {
+						"en-US" = { first_name = "John", last_name = "Doe" }
+					  }
+					  alive  = false
+					  public = true
+					  events = {
+						emigration = {
+						  name = "Emigration"
+						  date = { year = 1923 }
+						}
+					  }
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events").AtMapKey("emigration").AtMapKey("date").AtMapKey("year"), knownvalue.Int32Exact(1923)),
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events").AtMapKey("emigration").AtMapKey("location"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("geni_profile.test", tfjsonpath.New("events"), knownvalue.MapSizeExact(1)),
+				},
+			},
+		},
+	})
+}
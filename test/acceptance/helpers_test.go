@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -12,26 +15,39 @@ import (
 
 	"github.com/dmalch/go-geni"
 	"github.com/dmalch/terraform-provider-genealogy/internal"
+	"github.com/dmalch/terraform-provider-genealogy/internal/geniclient"
 )
 
 var testProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"geni": providerserver.NewProtocol6WithError(internal.New()),
+	"geni": func() (tfprotov6.ProviderServer, error) {
+		return providerserver.NewProtocol6WithError(testProvider())()
+	},
 }
 
-func newTestClient() *geni.Client {
-	var tokenSource oauth2.TokenSource
-	if testAccessToken != "" {
-		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: testAccessToken})
-	} else {
-		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{})
+// testProvider returns the provider under test, built on first use: TestMain
+// decides where it points before any test runs.
+var testProvider = sync.OnceValue(func() provider.Provider {
+	if testAPIBaseURL != "" {
+		return internal.New(internal.WithAPIBaseURL(testAPIBaseURL))
 	}
-	return geni.NewClient(tokenSource, true)
+	return internal.New()
+})
+
+func newTestClient() *geniclient.Client {
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: testAccessToken})
+
+	var opts geniclient.Options
+	if testAPIBaseURL != "" {
+		baseURL, err := url.Parse(testAPIBaseURL)
+		if err != nil {
+			panic(err)
+		}
+		opts.BaseURL = baseURL
+	}
+	return geniclient.New(tokenSource, true, opts)
 }
 
 func testAccCheckProfileDestroy(s *terraform.State) error {
-	if testAccessToken == "" {
-		return nil
-	}
 	client := newTestClient()
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "geni_profile" {
@@ -52,9 +68,6 @@ func testAccCheckProfileDestroy(s *terraform.State) error {
 }
 
 func testAccCheckDocumentDestroy(s *terraform.State) error {
-	if testAccessToken == "" {
-		return nil
-	}
 	client := newTestClient()
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "geni_document" {
@@ -73,9 +86,6 @@ func testAccCheckDocumentDestroy(s *terraform.State) error {
 }
 
 func testAccCheckPhotoDestroy(s *terraform.State) error {
-	if testAccessToken == "" {
-		return nil
-	}
 	client := newTestClient()
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "geni_photo" {
@@ -0,0 +1,33 @@
+package acceptance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dmalch/terraform-provider-genealogy/internal/genifake"
+)
+
+// TestMain runs the suite against an in-process fake Geni server, so it needs
+// neither network access nor an account. Setting GENI_ACC_SANDBOX opts in to
+// running it against the Geni sandbox instead, with the GENI_ACCESS_TOKEN
+// token; a token alone does not, so one left in the environment cannot turn a
+// local run into sandbox writes.
+func TestMain(m *testing.M) {
+	if testAgainstSandbox {
+		os.Exit(m.Run())
+	}
+
+	fake := genifake.NewServer()
+	fake.AddProject("project-8", "Acceptance tests")
+	fake.AddProject("project-9", "Acceptance tests (second project)")
+	testAPIBaseURL = fake.URL()
+
+	// The fake accepts any token; setting one keeps the provider from
+	// starting the browser login.
+	testAccessToken = "fake-token"
+	_ = os.Setenv("GENI_ACCESS_TOKEN", testAccessToken)
+
+	code := m.Run()
+	fake.Close()
+	os.Exit(code)
+}